@@ -1,10 +1,18 @@
 package main
 
 import (
+	"context"
+	"log"
+	"net/http"
 	"time"
 
 	"plan/config"
+	"plan/delivery/middleware"
 	route "plan/delivery/route"
+	"plan/domain"
+	"plan/internal/eventhub"
+	"plan/repository"
+	"plan/usecase"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -18,8 +26,56 @@ func main() {
 
 	timeout := time.Duration(env.ContextTimeout) * time.Second
 
-	// Create a Gin router
-	router := gin.Default()
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+
+	if err := repository.EnsureIndexes(ctx, db); err != nil {
+		log.Fatal(err)
+	}
+
+	// Start from the built-in role hierarchy until one is stored, since
+	// signup and approvals need it
+	roleRules := repository.NewRoleRuleRepository(db, domain.CollectionRoleRule)
+	if rules, err := roleRules.ListRules(ctx); err != nil {
+		log.Fatal(err)
+	} else if len(rules) == 0 {
+		if _, err := roleRules.SeedRules(ctx, domain.DefaultRoleRules); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// Push events to connected users, through MongoDB when replicas share them
+	var events domain.EventHub = eventhub.New()
+	switch env.EventBackend {
+	case "", "memory":
+	case "mongo":
+		stream := repository.NewEventStream(db, domain.CollectionEvent, events)
+		go stream.Run(ctx)
+		events = stream
+	default:
+		log.Fatalf("Unknown EVENT_BACKEND %q", env.EventBackend)
+	}
+
+	// Deliver the emails and webhooks queued in the outbox
+	outbox := repository.NewOutboxRepository(db, domain.CollectionOutbox)
+	webhooks := usecase.NewWebhookSender(
+		repository.NewWebhookRepository(db, domain.CollectionWebhook),
+		repository.NewWebhookDeliveryRepository(db, domain.CollectionWebhookDelivery),
+		&http.Client{Timeout: 10 * time.Second},
+	)
+	go usecase.NewOutboxWorker(outbox, app.Mailer, webhooks, 5*time.Second).Run(ctx)
+
+	// Remind owners of reports due at the end of the current period
+	notifier := usecase.NewNotifier(repository.NewNotificationRepository(db, domain.CollectionNotification), events)
+	fiscalYears := repository.NewFiscalYearRepository(db, domain.CollectionFiscalYear)
+	plans := repository.NewPlanRepository(db, domain.CollectionPlan)
+	reports := repository.NewReportRepository(db, domain.CollectionReport)
+	go usecase.NewReportReminder(fiscalYears, plans, reports, notifier, time.Hour).Run(ctx)
+
+	// Create a Gin router, logging requests without the tokens some pass in
+	// the query
+	router := gin.New()
+	router.Use(middleware.Logger(), gin.Recovery())
 
 	// Set up CORS middleware
 	router.Use(cors.New(cors.Config{
@@ -32,7 +88,7 @@ func main() {
 	}))
 
 	// Set up predefined routes
-	route.Setup(env, timeout, db, router)
+	route.Setup(env, timeout, db, events, router)
 
 	// Handle any route
 	router.NoRoute(func(c *gin.Context) {
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"plan/config"
+	"plan/internal/migration"
+)
+
+// Runs one or more data migrations by name, e.g. from the cmd directory:
+//
+//	go run ./migrate supervisor-ids
+func main() {
+	list := flag.Bool("list", false, "list the available migrations")
+	flag.Parse()
+
+	if *list || flag.NArg() == 0 {
+		for _, m := range migration.All() {
+			fmt.Printf("%-24s %s\n", m.Name, m.Description)
+		}
+		return
+	}
+
+	// Resolve every name before connecting so a typo doesn't half-run a batch.
+	var selected []*migration.Migration
+	for _, name := range flag.Args() {
+		m, ok := migration.Find(name)
+		if !ok {
+			log.Fatalf("unknown migration %q, use -list to see the available ones", name)
+		}
+		selected = append(selected, m)
+	}
+
+	// Only the database is needed, not the mailer and the rest of the app.
+	env := config.NewEnv()
+	client := config.NewMongoDatabase(env)
+	defer config.CloseMongoDBConnection(client)
+	db := client.Database(env.DBName)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	for _, m := range selected {
+		report, err := m.Run(context.Background(), db)
+		if report != nil {
+			encoder.Encode(report)
+		}
+		if err != nil {
+			log.Fatalf("migration %s failed: %v", m.Name, err)
+		}
+	}
+}
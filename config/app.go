@@ -1,16 +1,21 @@
 package config
 
-import "plan/database"
+import (
+	"plan/database"
+	"plan/domain"
+)
 
 type Application struct {
-	Env   *Env
-	Mongo database.Client
+	Env    *Env
+	Mongo  database.Client
+	Mailer domain.Mailer
 }
 
 func App() Application {
 	app := &Application{}
 	app.Env = NewEnv()
 	app.Mongo = NewMongoDatabase(app.Env)
+	app.Mailer = NewMailer(app.Env)
 	
 	return *app
 }
@@ -28,6 +28,15 @@ func NewMongoDatabase(env *Env) database.Client {
 		log.Fatal(err)
 	}
 
+	// Signup, account reviews, imports and unit reports write in transactions
+	transactions, err := client.SupportsTransactions(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !transactions {
+		log.Fatal("MongoDB does not support transactions: run it as a replica set, e.g. a single-node one started with --replSet rs0 and rs.initiate()")
+	}
+
 	return client
 }
 
@@ -9,7 +9,7 @@ type Env struct {
 	AppEnv                 string `mapstructure:"APP_ENV"`
 	ServerAddress          string `mapstructure:"SERVER_ADDRESS"`
 	ContextTimeout         int    `mapstructure:"CONTEXT_TIMEOUT"`
-	MONGO_URI              string `mapstructure:"MONGO_URI"`
+	MONGO_URI              string `mapstructure:"MONGO_URI"` // Must reach a replica set or mongos, since writes use transactions
 	DBName                 string `mapstructure:"DB_NAME"`
 	AccessTokenExpiryHour  int    `mapstructure:"ACCESS_TOKEN_EXPIRY_HOUR"`
 	RefreshTokenExpiryHour int    `mapstructure:"REFRESH_TOKEN_EXPIRY_HOUR"`
@@ -19,11 +19,16 @@ type Env struct {
 	SMTPPassword           string `mapstructure:"SMTPPassword"`
 	SMTPHost               string `mapstructure:"SMTPHost"`
 	SMTPPort               string `mapstructure:"SMTPPort"`
+	MailFrom               string `mapstructure:"MAIL_FROM"`        // Sender address; SMTPUsername when empty
+	MailBackend            string `mapstructure:"MAIL_BACKEND"`     // smtp (the default) or capture
+	MailCaptureDir         string `mapstructure:"MAIL_CAPTURE_DIR"` // Where the capture backend writes .eml files; memory only when empty
+	EventBackend           string `mapstructure:"EVENT_BACKEND"`    // memory, or mongo to share events between replicas through change streams
 	GoogleClientID         string `mapstructure:"GOOGLE_CLIENT_ID"`
 	GoogleClientSecret     string `mapstructure:"GOOGLE_CLIENT_SECRET"`
   AIAPIKey 			         string `mapstructure:"AIAPIKey"`
 	RootUsername           string `mapstructure:"ROOT_USERNAME"`
 	RootPassword           string `mapstructure:"ROOT_PASSWORD"`
+	PDFFontPath            string `mapstructure:"PDF_FONT_PATH"` // UTF-8 TrueType font for PDF documents, e.g. one covering Ethiopic
 }
 func NewEnv() *Env {
 	env := Env{}
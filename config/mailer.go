@@ -0,0 +1,46 @@
+package config
+
+import (
+	"log"
+	"strconv"
+
+	"plan/domain"
+	"plan/internal/mailer"
+)
+
+// NewMailer builds the mailer from the SMTP settings, or a capture mailer when
+// MAIL_BACKEND is capture. Bad or missing settings stop the server at startup
+// rather than on the first email.
+func NewMailer(env *Env) domain.Mailer {
+	from := env.MailFrom
+	if from == "" {
+		from = env.SMTPUsername
+	}
+
+	backend := env.MailBackend
+	if backend == "" {
+		backend = "smtp"
+	}
+
+	switch backend {
+	case "smtp":
+		if env.SMTPHost == "" {
+			log.Fatal("SMTPHost is not set; set MAIL_BACKEND=capture to capture mail instead of sending it")
+		}
+		port, err := strconv.Atoi(env.SMTPPort)
+		if err != nil {
+			log.Fatal("Invalid SMTPPort: ", err)
+		}
+		return mailer.New(from, mailer.NewSMTP(env.SMTPHost, port, env.SMTPUsername, env.SMTPPassword))
+	case "capture":
+		capture, err := mailer.NewCapture(env.MailCaptureDir)
+		if err != nil {
+			log.Fatal("Can't create the mail capture directory: ", err)
+		}
+		log.Println("Mail is captured instead of sent")
+		return mailer.New(from, capture)
+	}
+
+	log.Fatalf("Unknown MAIL_BACKEND %q", backend)
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/bson/bsonrw"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
@@ -30,6 +31,8 @@ type Collection interface {
 	UpdateOne(context.Context, interface{}, interface{}, ...*options.UpdateOptions) (*mongo.UpdateResult, error)
 	UpdateMany(context.Context, interface{}, interface{}, ...*options.UpdateOptions) (*mongo.UpdateResult, error)
 	DeleteMany(context.Context, interface{}) (int64, error)
+	Watch(context.Context, interface{}, ...*options.ChangeStreamOptions) (ChangeStream, error)
+	CreateIndexes(context.Context, []mongo.IndexModel) error
 }
 
 type SingleResult interface {
@@ -43,6 +46,14 @@ type Cursor interface {
 	All(context.Context, interface{}) error
 }
 
+type ChangeStream interface {
+	Next(context.Context) bool
+	Decode(interface{}) error
+	Err() error
+	ResumeToken() bson.Raw
+	Close(context.Context) error
+}
+
 type Client interface {
 	Database(string) Database
 	Connect(context.Context) error
@@ -50,6 +61,7 @@ type Client interface {
 	StartSession() (mongo.Session, error)
 	UseSession(ctx context.Context, fn func(mongo.SessionContext) error) error
 	Ping(context.Context) error
+	SupportsTransactions(context.Context) (bool, error)
 }
 
 type mongoClient struct {
@@ -108,6 +120,20 @@ func (mc *mongoClient) Ping(ctx context.Context) error {
 	return mc.cl.Ping(ctx, readpref.Primary())
 }
 
+// SupportsTransactions reports whether the server is a replica set member or
+// a mongos, the deployments that run multi-document transactions.
+func (mc *mongoClient) SupportsTransactions(ctx context.Context) (bool, error) {
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	err := mc.cl.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello)
+	if err != nil {
+		return false, err
+	}
+	return hello.SetName != "" || hello.Msg == "isdbgrid", nil
+}
+
 func (mc *mongoClient) Database(dbName string) Database {
 	db := mc.cl.Database(dbName)
 	return &mongoDatabase{db: db}
@@ -185,6 +211,19 @@ func (mc *mongoCollection) CountDocuments(ctx context.Context, filter interface{
 	return mc.coll.CountDocuments(ctx, filter, opts...)
 }
 
+func (mc *mongoCollection) Watch(ctx context.Context, pipeline interface{}, opts ...*options.ChangeStreamOptions) (ChangeStream, error) {
+	stream, err := mc.coll.Watch(ctx, pipeline, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+func (mc *mongoCollection) CreateIndexes(ctx context.Context, models []mongo.IndexModel) error {
+	_, err := mc.coll.Indexes().CreateMany(ctx, models)
+	return err
+}
+
 func (sr *mongoSingleResult) Decode(v interface{}) error {
 	return sr.sr.Decode(v)
 }
@@ -0,0 +1,36 @@
+package controller
+
+import (
+	"net/http"
+	"plan/config"
+	"plan/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DashboardController struct {
+	DashboardUsecase domain.DashboardUsecase
+	Env              *config.Env
+}
+
+// GetDashboard returns the statistics of the caller's subtree, optionally
+// for one ?fiscal_year=.
+func (dc *DashboardController) GetDashboard(c *gin.Context) {
+	claims, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	year, ok := fiscalYearQuery(c)
+	if !ok {
+		return
+	}
+
+	dashboard, err := dc.DashboardUsecase.GetDashboard(c, claims, year)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, dashboard)
+}
@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"plan/config"
+	"plan/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DocumentController struct {
+	DocumentUsecase domain.DocumentUsecase
+	Env             *config.Env
+}
+
+// documentErrorStatus maps usecase errors to HTTP status codes.
+func documentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrNotApproved):
+		return http.StatusConflict
+	case err.Error() == "unauthorized access":
+		return http.StatusForbidden
+	case err.Error() == "plan not found", err.Error() == "report not found":
+		return http.StatusNotFound
+	case err.Error() == "invalid plan ID", err.Error() == "invalid report ID":
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (dc *DocumentController) GetPlanPDF(c *gin.Context) {
+	dc.sendPDF(c, dc.DocumentUsecase.PlanPDF, c.Param("plan_id"))
+}
+
+func (dc *DocumentController) GetReportPDF(c *gin.Context) {
+	dc.sendPDF(c, dc.DocumentUsecase.ReportPDF, c.Param("report_id"))
+}
+
+func (dc *DocumentController) sendPDF(c *gin.Context, render func(c context.Context, claims *domain.JwtCustomClaims, id string, calendar string) (*domain.PDFFile, error), id string) {
+	claims, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	file, err := render(c, claims, id, c.GetString("calendar"))
+	if err != nil {
+		c.JSON(documentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	// ?inline=true shows the document in the browser instead of downloading it.
+	disposition := "attachment"
+	if c.Query("inline") == "true" {
+		disposition = "inline"
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("%s; filename=%q", disposition, file.Filename))
+	c.Data(http.StatusOK, "application/pdf", file.Content)
+}
@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"plan/config"
+	"plan/domain"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+// eventKeepAlive is how often an idle stream gets a comment line, so proxies
+// do not close it, and how often its token is checked for revocation.
+const eventKeepAlive = 30 * time.Second
+
+type EventController struct {
+	Events              domain.EventHub
+	NotificationUsecase domain.NotificationUsecase
+	Revocations         domain.RevocationRepository
+	Env                 *config.Env
+}
+
+// StreamEvents pushes the caller's events as Server-Sent Events. The stream
+// opens with an "unread" event holding the unread notification count, and
+// ends when the access token expires so the client reconnects with a fresh
+// one, or once it is revoked by a logout, a rejection or a role change.
+func (ec *EventController) StreamEvents(c *gin.Context) {
+	claims, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	unread, err := ec.NotificationUsecase.CountUnread(c, claims)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	events, unsubscribe := ec.Events.Subscribe(claims.UserID)
+	defer unsubscribe()
+
+	expiry := time.NewTimer(time.Until(time.Unix(claims.ExpiresAt, 0)))
+	defer expiry.Stop()
+	keepAlive := time.NewTicker(eventKeepAlive)
+	defer keepAlive.Stop()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no") // Keep nginx from buffering the stream
+	c.Render(http.StatusOK, sse.Event{Event: "unread", Data: gin.H{"unread": unread}})
+	c.Writer.Flush()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case <-expiry.C:
+			return false
+		case <-keepAlive.C:
+			revoked, err := ec.Revocations.IsRevoked(c.Request.Context(), claims)
+			if err != nil {
+				log.Println("failed to check event stream token:", err)
+			} else if revoked {
+				return false
+			}
+			fmt.Fprint(w, ": keep-alive\n\n")
+			return true
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.Render(-1, sse.Event{Id: event.ID.Hex(), Event: event.Type, Data: event.Data})
+			return true
+		}
+	})
+}
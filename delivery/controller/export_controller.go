@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"plan/config"
+	"plan/domain"
+	"plan/internal/export"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ExportController struct {
+	ExportUsecase domain.ExportUsecase
+	Env           *config.Env
+}
+
+// ExportPlans streams the plans matching ?status=, ?fiscal_year=, ?quarter=,
+// ?owner_id= and ?department_id= as ?format=csv (the default) or xlsx.
+func (ec *ExportController) ExportPlans(c *gin.Context) {
+	ec.export(c, "plans", ec.ExportUsecase.ExportPlans)
+}
+
+// ExportReports streams reports, with the same filters as ExportPlans.
+func (ec *ExportController) ExportReports(c *gin.Context) {
+	ec.export(c, "reports", ec.ExportUsecase.ExportReports)
+}
+
+func (ec *ExportController) export(c *gin.Context, name string, run func(c context.Context, request *domain.ExportRequest) error) {
+	request, ok := exportRequest(c)
+	if !ok {
+		return
+	}
+
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().Format("20060102"), request.Format)
+	c.Header("Content-Type", export.ContentTypes[request.Format])
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	if err := run(c, request); err != nil {
+		if c.Writer.Written() {
+			// Part of the file is out; all that is left is to cut it short.
+			c.Error(err)
+			c.Abort()
+			return
+		}
+		c.Header("Content-Type", "")
+		c.Header("Content-Disposition", "")
+		if errors.Is(err, domain.ErrInvalidExport) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+	}
+}
+
+// exportRequest reads the format and filters of an export from the query.
+func exportRequest(c *gin.Context) (*domain.ExportRequest, bool) {
+	request := &domain.ExportRequest{
+		Format:   c.DefaultQuery("format", export.CSV),
+		Calendar: c.GetString("calendar"),
+		W:        c.Writer,
+	}
+	if _, ok := export.ContentTypes[request.Format]; !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be csv or xlsx"})
+		return nil, false
+	}
+
+	request.Filter.Status = c.Query("status")
+	var ok bool
+	if request.Filter.FiscalYear, ok = fiscalYearQuery(c); !ok {
+		return nil, false
+	}
+	if value := c.Query("quarter"); value != "" {
+		quarter, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "quarter must be a number"})
+			return nil, false
+		}
+		request.Filter.Quarter = quarter
+	}
+
+	for param, target := range map[string]**primitive.ObjectID{
+		"owner_id":      &request.Filter.OwnerID,
+		"department_id": &request.Filter.DepartmentID,
+	} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		id, err := primitive.ObjectIDFromHex(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + param})
+			return nil, false
+		}
+		*target = &id
+	}
+	return request, true
+}
@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"net/http"
+	"plan/config"
+	"plan/domain"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FiscalController struct {
+	FiscalUsecase domain.FiscalUsecase
+	Env           *config.Env
+}
+
+// fiscalErrorStatus maps usecase errors to HTTP status codes.
+func fiscalErrorStatus(err error) int {
+	switch err.Error() {
+	case "fiscal year not found", "period not found":
+		return http.StatusNotFound
+	case "fiscal year already exists", "fiscal year is in use":
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// yearParam reads the :year path parameter.
+func yearParam(c *gin.Context) (int, bool) {
+	year, err := strconv.Atoi(c.Param("year"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid fiscal year"})
+		return 0, false
+	}
+	return year, true
+}
+
+func (fc *FiscalController) ListFiscalYears(c *gin.Context) {
+	years, err := fc.FiscalUsecase.ListFiscalYears(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"fiscal_years": years})
+}
+
+func (fc *FiscalController) GetFiscalYear(c *gin.Context) {
+	year, ok := yearParam(c)
+	if !ok {
+		return
+	}
+
+	fiscalYear, err := fc.FiscalUsecase.GetFiscalYear(c, year)
+	if err != nil {
+		c.JSON(fiscalErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"fiscal_year": fiscalYear})
+}
+
+func (fc *FiscalController) GetCurrentPeriod(c *gin.Context) {
+	fiscalYear, period, err := fc.FiscalUsecase.GetCurrentPeriod(c)
+	if err != nil {
+		c.JSON(fiscalErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"fiscal_year": fiscalYear, "period": period})
+}
+
+func (fc *FiscalController) CreateFiscalYear(c *gin.Context) {
+	var fiscalYear domain.FiscalYear
+	if err := c.ShouldBindJSON(&fiscalYear); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := fc.FiscalUsecase.CreateFiscalYear(c, &fiscalYear); err != nil {
+		c.JSON(fiscalErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"fiscal_year": fiscalYear})
+}
+
+func (fc *FiscalController) UpdateFiscalYear(c *gin.Context) {
+	year, ok := yearParam(c)
+	if !ok {
+		return
+	}
+
+	var fiscalYear domain.FiscalYear
+	fiscalYear.Year = year
+	if err := c.ShouldBindJSON(&fiscalYear); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := fc.FiscalUsecase.UpdateFiscalYear(c, year, &fiscalYear); err != nil {
+		c.JSON(fiscalErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"fiscal_year": fiscalYear})
+}
+
+func (fc *FiscalController) DeleteFiscalYear(c *gin.Context) {
+	year, ok := yearParam(c)
+	if !ok {
+		return
+	}
+
+	if err := fc.FiscalUsecase.DeleteFiscalYear(c, year); err != nil {
+		c.JSON(fiscalErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Fiscal year deleted successfully"})
+}
+
+// OpenPeriod and ClosePeriod decide whether a period accepts submissions.
+func (fc *FiscalController) OpenPeriod(c *gin.Context) {
+	fc.setPeriodOpen(c, true)
+}
+
+func (fc *FiscalController) ClosePeriod(c *gin.Context) {
+	fc.setPeriodOpen(c, false)
+}
+
+func (fc *FiscalController) setPeriodOpen(c *gin.Context, open bool) {
+	year, ok := yearParam(c)
+	if !ok {
+		return
+	}
+	quarter, err := strconv.Atoi(c.Param("quarter"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid quarter"})
+		return
+	}
+
+	if err := fc.FiscalUsecase.SetPeriodOpen(c, year, quarter, open); err != nil {
+		c.JSON(fiscalErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Period updated successfully"})
+}
@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"plan/config"
+	"plan/domain"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationController struct {
+	NotificationUsecase domain.NotificationUsecase
+	Env                 *config.Env
+}
+
+// ListNotifications lists the caller's notifications, newest first.
+// ?unread=true leaves out the read ones.
+func (nc *NotificationController) ListNotifications(c *gin.Context) {
+	claims, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var limit int64
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || parsed < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+		limit = parsed
+	}
+
+	notifications, err := nc.NotificationUsecase.ListNotifications(c, claims, c.Query("unread") == "true", limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"count": len(notifications), "notifications": notifications})
+}
+
+func (nc *NotificationController) GetUnreadCount(c *gin.Context) {
+	claims, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	count, err := nc.NotificationUsecase.CountUnread(c, claims)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"unread": count})
+}
+
+func (nc *NotificationController) MarkRead(c *gin.Context) {
+	nc.setRead(c, true)
+}
+
+func (nc *NotificationController) MarkUnread(c *gin.Context) {
+	nc.setRead(c, false)
+}
+
+func (nc *NotificationController) setRead(c *gin.Context, read bool) {
+	claims, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	err := nc.NotificationUsecase.MarkRead(c, claims, c.Param("notification_id"), read)
+	if errors.Is(err, domain.ErrNotificationNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Notification updated successfully"})
+}
+
+func (nc *NotificationController) MarkAllRead(c *gin.Context) {
+	claims, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	updated, err := nc.NotificationUsecase.MarkAllRead(c, claims)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"updated": updated})
+}
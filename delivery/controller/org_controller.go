@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"net/http"
+	"plan/config"
+	"plan/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrgController struct {
+	OrgUsecase domain.OrgUsecase
+	Env        *config.Env
+}
+
+// orgErrorStatus maps usecase errors to HTTP status codes.
+func orgErrorStatus(err error) int {
+	switch err.Error() {
+	case "org unit not found", "role rule not found", "user not found":
+		return http.StatusNotFound
+	case "unauthorized access":
+		return http.StatusForbidden
+	case "org unit is not empty", "other roles still report to this role":
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func (oc *OrgController) GetTree(c *gin.Context) {
+	tree, err := oc.OrgUsecase.GetTree(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"units": tree})
+}
+
+func (oc *OrgController) GetChainOfCommand(c *gin.Context) {
+	claims, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	chain, err := oc.OrgUsecase.GetChainOfCommand(c, claims, c.Param("user_id"))
+	if err != nil {
+		c.JSON(orgErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"chain": chain})
+}
+
+func (oc *OrgController) GetSubordinates(c *gin.Context) {
+	claims, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	subordinates, err := oc.OrgUsecase.GetSubordinates(c, claims, c.Param("user_id"))
+	if err != nil {
+		c.JSON(orgErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"count":        len(subordinates),
+		"subordinates": subordinates,
+	})
+}
+
+func (oc *OrgController) CreateUnit(c *gin.Context) {
+	var unit domain.OrgUnit
+	if err := c.ShouldBindJSON(&unit); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := oc.OrgUsecase.CreateUnit(c, &unit); err != nil {
+		c.JSON(orgErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"unit": unit})
+}
+
+func (oc *OrgController) UpdateUnit(c *gin.Context) {
+	var unit domain.OrgUnit
+	if err := c.ShouldBindJSON(&unit); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := oc.OrgUsecase.UpdateUnit(c, c.Param("id"), &unit); err != nil {
+		c.JSON(orgErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Org unit updated successfully"})
+}
+
+func (oc *OrgController) DeleteUnit(c *gin.Context) {
+	if err := oc.OrgUsecase.DeleteUnit(c, c.Param("id")); err != nil {
+		c.JSON(orgErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Org unit deleted successfully"})
+}
+
+func (oc *OrgController) AssignUser(c *gin.Context) {
+	var request struct {
+		OrgUnitID string `json:"org_unit_id"` // Empty to remove the user from their unit
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := oc.OrgUsecase.AssignUser(c, c.Param("user_id"), request.OrgUnitID); err != nil {
+		c.JSON(orgErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User assigned successfully"})
+}
+
+func (oc *OrgController) ListRoleRules(c *gin.Context) {
+	rules, err := oc.OrgUsecase.ListRoleRules(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"roles": rules})
+}
+
+func (oc *OrgController) SaveRoleRule(c *gin.Context) {
+	var rule domain.RoleRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := oc.OrgUsecase.SaveRoleRule(c, &rule); err != nil {
+		c.JSON(orgErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Role rule saved successfully"})
+}
+
+func (oc *OrgController) DeleteRoleRule(c *gin.Context) {
+	if err := oc.OrgUsecase.DeleteRoleRule(c, c.Param("role")); err != nil {
+		c.JSON(orgErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Role rule deleted successfully"})
+}
@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"plan/config"
+	"plan/domain"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OutboxController struct {
+	OutboxUsecase domain.OutboxUsecase
+	Env           *config.Env
+}
+
+// outboxErrorStatus maps usecase errors to HTTP status codes.
+func outboxErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrOutboxNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrOutboxNotDead):
+		return http.StatusConflict
+	case strings.HasPrefix(err.Error(), "invalid status"):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ListOutbox lists outbox messages, newest first. ?status=dead shows the
+// failed deliveries waiting for a retry.
+func (oc *OutboxController) ListOutbox(c *gin.Context) {
+	var limit int64
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || parsed < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+		limit = parsed
+	}
+
+	messages, err := oc.OutboxUsecase.ListMessages(c, c.Query("status"), limit)
+	if err != nil {
+		c.JSON(outboxErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"count": len(messages), "messages": messages})
+}
+
+func (oc *OutboxController) GetOutboxMessage(c *gin.Context) {
+	message, err := oc.OutboxUsecase.GetMessage(c, c.Param("message_id"))
+	if err != nil {
+		c.JSON(outboxErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, message)
+}
+
+func (oc *OutboxController) RetryOutboxMessage(c *gin.Context) {
+	message, err := oc.OutboxUsecase.RetryMessage(c, c.Param("message_id"))
+	if err != nil {
+		c.JSON(outboxErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, message)
+}
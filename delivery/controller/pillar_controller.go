@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"plan/config"
+	"plan/domain"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PillarController struct {
+	PillarUsecase domain.PillarUsecase
+	Env           *config.Env
+}
+
+// pillarErrorStatus maps usecase errors to HTTP status codes.
+func pillarErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrPillarNotFound), err.Error() == "fiscal year not found":
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrDuplicatePillar), err.Error() == "pillar is referenced by plans":
+		return http.StatusConflict
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// fiscalYearQuery reads the optional ?fiscal_year= filter. 0 means every year.
+func fiscalYearQuery(c *gin.Context) (int, bool) {
+	value := c.Query("fiscal_year")
+	if value == "" {
+		return 0, true
+	}
+	year, err := strconv.Atoi(value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "fiscal_year must be a number"})
+		return 0, false
+	}
+	return year, true
+}
+
+func (pc *PillarController) ListPillars(c *gin.Context) {
+	year, ok := fiscalYearQuery(c)
+	if !ok {
+		return
+	}
+
+	pillars, err := pc.PillarUsecase.ListPillars(c, year)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"pillars": pillars})
+}
+
+func (pc *PillarController) GetPillar(c *gin.Context) {
+	pillar, err := pc.PillarUsecase.GetPillar(c, c.Param("id"))
+	if err != nil {
+		c.JSON(pillarErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"pillar": pillar})
+}
+
+func (pc *PillarController) CreatePillar(c *gin.Context) {
+	var pillar domain.Pillar
+	if err := c.ShouldBindJSON(&pillar); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := pc.PillarUsecase.CreatePillar(c, &pillar); err != nil {
+		c.JSON(pillarErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"pillar": pillar})
+}
+
+func (pc *PillarController) UpdatePillar(c *gin.Context) {
+	var pillar domain.Pillar
+	if err := c.ShouldBindJSON(&pillar); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := pc.PillarUsecase.UpdatePillar(c, c.Param("id"), &pillar); err != nil {
+		c.JSON(pillarErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Pillar updated successfully"})
+}
+
+func (pc *PillarController) DeletePillar(c *gin.Context) {
+	if err := pc.PillarUsecase.DeletePillar(c, c.Param("id")); err != nil {
+		c.JSON(pillarErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Pillar deleted successfully"})
+}
+
+func (pc *PillarController) GetPillarStats(c *gin.Context) {
+	year, ok := fiscalYearQuery(c)
+	if !ok {
+		return
+	}
+
+	stats, err := pc.PillarUsecase.GetPillarStats(c, year)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"pillars": stats})
+}
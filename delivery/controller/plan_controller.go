@@ -1,6 +1,10 @@
 package controller
 
 import (
+	"errors"
+	"strconv"
+	"path/filepath"
+	"strings"
 	"plan/config"
 	"plan/domain"
 
@@ -9,6 +13,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	// "go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -22,7 +27,7 @@ func (pc *PlanController) GetUserPlansAndReports(c *gin.Context) {
 	var requestBody struct {
 		UserID string `json:"user_id" binding:"required"`
 	}
-	if err := c.ShouldBindJSON(&requestBody); err != nil {
+	if err := c.ShouldBindBodyWith(&requestBody, binding.JSON); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "User ID is required"})
 		return
 	}
@@ -43,14 +48,14 @@ func (pc *PlanController) GetUserPlansAndReports(c *gin.Context) {
 
 	// Fetch data based on type
 	if dataType == "plan" {
-		plans, err := pc.PlanUsecase.GetPlansByOwnerID(c, userID,dataType)
+		plans, err := pc.PlanUsecase.GetPlansByOwnerID(c, userID)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
 		c.JSON(http.StatusOK, gin.H{"data": plans})
 	} else if dataType == "report" {
-		reports, err := pc.PlanUsecase.GetReportsByUserID(c, userID, dataType)
+		reports, err := pc.PlanUsecase.GetReportsByUserID(c, userID)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
@@ -135,14 +140,23 @@ func (rc *PlanController) UpdateReport(c *gin.Context) {
 		return
 	}
 
-	// Ensure the status is always set to "Pending"
-	updatedReport.Status = "Pending"
+	user, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
 
 	// Call the usecase to update the report
-	err := rc.PlanUsecase.UpdateReport(c, reportID, &updatedReport)
+	err := rc.PlanUsecase.UpdateReport(c, user, reportID, &updatedReport)
 	if err != nil {
 		if err.Error() == "report not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Report not found"})
+		} else if err.Error() == "unauthorized access" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized access"})
+		} else if errors.Is(err, domain.ErrInvalidTransition) || errors.Is(err, domain.ErrClosedPeriod) || errors.Is(err, domain.ErrDuplicateReport) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		} else if isInvalidContent(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
@@ -165,14 +179,23 @@ func (pc *PlanController) UpdatePlan(c *gin.Context) {
 		return
 	}
 
-	// Ensure the status is always set to "Pending"
-	updatedPlan.Status = "Pending"
+	user, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
 
 	// Call the usecase to update the plan
-	err := pc.PlanUsecase.UpdatePlan(c, planID, &updatedPlan)
+	err := pc.PlanUsecase.UpdatePlan(c, user, planID, &updatedPlan)
 	if err != nil {
 		if err.Error() == "plan not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Plan not found"})
+		} else if err.Error() == "unauthorized access" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized access"})
+		} else if errors.Is(err, domain.ErrInvalidTransition) || errors.Is(err, domain.ErrClosedPeriod) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		} else if isInvalidContent(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
@@ -185,7 +208,7 @@ func (pc *PlanController) UpdatePlan(c *gin.Context) {
 func (rc *PlanController) UpdateReportStatus(c *gin.Context) {
 	var request struct {
 		ReportID string `json:"report_id" binding:"required"`
-		Status   string `json:"status" binding:"required"` // Should be "Approved" or "Rejected"
+		Status   string `json:"status" binding:"required"` // "Approved", or "RevisionRequested" ("Rejected" is accepted too)
 		Comment  string `json:"comment" binding:"required"`
 	}
 
@@ -200,7 +223,7 @@ func (rc *PlanController) UpdateReportStatus(c *gin.Context) {
 		return
 	}
 
-	supervisorName := user.Full_Name // Extract supervisor's name from token
+	supervisorID := user.UserID // The caller acts as the supervisor
 
 	reportID, err := primitive.ObjectIDFromHex(request.ReportID)
 	if err != nil {
@@ -208,12 +231,16 @@ func (rc *PlanController) UpdateReportStatus(c *gin.Context) {
 		return
 	}
 
-	err = rc.PlanUsecase.UpdateReportStatus(c, reportID, supervisorName, request.Status, request.Comment)
+	err = rc.PlanUsecase.UpdateReportStatus(c, reportID, supervisorID, request.Status, request.Comment)
 	if err != nil {
 		if err.Error() == "report not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Report not found"})
 		} else if err.Error() == "unauthorized access" {
 			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized access"})
+		} else if errors.Is(err, domain.ErrInvalidTransition) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		} else if err.Error() == "invalid status" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
@@ -226,7 +253,7 @@ func (rc *PlanController) UpdateReportStatus(c *gin.Context) {
 func (pc *PlanController) UpdatePlanStatus(c *gin.Context) {
 	var request struct {
 		PlanID  string `json:"plan_id" binding:"required"`
-		Status  string `json:"status" binding:"required"` // Should be "Approved" or "Rejected"
+		Status  string `json:"status" binding:"required"` // "Approved", or "RevisionRequested" ("Rejected" is accepted too)
 		Comment string `json:"comment" binding:"required"`
 	}
 
@@ -241,7 +268,7 @@ func (pc *PlanController) UpdatePlanStatus(c *gin.Context) {
 		return
 	}
 
-	supervisorName := user.Full_Name // Extract supervisor's name from token
+	supervisorID := user.UserID // The caller acts as the supervisor
 
 	planID, err := primitive.ObjectIDFromHex(request.PlanID)
 	if err != nil {
@@ -249,12 +276,16 @@ func (pc *PlanController) UpdatePlanStatus(c *gin.Context) {
 		return
 	}
 
-	err = pc.PlanUsecase.UpdatePlanStatus(c, planID, supervisorName, request.Status, request.Comment)
+	err = pc.PlanUsecase.UpdatePlanStatus(c, planID, supervisorID, request.Status, request.Comment)
 	if err != nil {
 		if err.Error() == "plan not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Plan not found"})
 		} else if err.Error() == "unauthorized access" {
 			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized access"})
+		} else if err.Error() == "plan is not awaiting approval" || errors.Is(err, domain.ErrInvalidTransition) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		} else if err.Error() == "invalid status" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
@@ -264,6 +295,72 @@ func (pc *PlanController) UpdatePlanStatus(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Plan status updated successfully"})
 }
 
+func (pc *PlanController) GetApprovalChain(c *gin.Context) {
+	user, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	planID, err := primitive.ObjectIDFromHex(c.Param("plan_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Plan ID"})
+		return
+	}
+
+	chain, err := pc.PlanUsecase.GetApprovalChain(c, user, planID)
+	if err != nil {
+		if err.Error() == "plan not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Plan not found"})
+		} else if err.Error() == "unauthorized access" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized access"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"approval_chain": chain})
+}
+
+func (pc *PlanController) GetApprovalPolicies(c *gin.Context) {
+	policies, err := pc.PlanUsecase.ListApprovalPolicies(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"policies": policies})
+}
+
+func (pc *PlanController) SaveApprovalPolicy(c *gin.Context) {
+	var policy domain.ApprovalPolicy
+	if err := c.ShouldBindJSON(&policy); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := pc.PlanUsecase.SaveApprovalPolicy(c, &policy); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Approval policy saved successfully"})
+}
+
+func (pc *PlanController) DeleteApprovalPolicy(c *gin.Context) {
+	if err := pc.PlanUsecase.DeleteApprovalPolicy(c, c.Param("owner_role")); err != nil {
+		if err.Error() == "approval policy not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Approval policy deleted successfully"})
+}
+
 func (rc *PlanController) GetReportsByStatus(c *gin.Context) {
 	reportStatus := c.Query("report_status") // Get the status from query parameters
 
@@ -278,10 +375,10 @@ func (rc *PlanController) GetReportsByStatus(c *gin.Context) {
 		return
 	}
 
-	supervisorName := user.Full_Name // Extract supervisor name from the token
+	supervisorID := user.UserID // Reports submitted to the caller
 
-	// Call usecase with both report_status and supervisor name
-	reports, err := rc.PlanUsecase.FetchReportsBySupervisorAndStatus(c, supervisorName, reportStatus)
+	// Call usecase with both report_status and supervisor ID
+	reports, err := rc.PlanUsecase.FetchReportsBySupervisorAndStatus(c, supervisorID, reportStatus)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -304,10 +401,10 @@ func (pc *PlanController) GetPlansByStatus(c *gin.Context) {
 		return
 	}
 
-	supervisorName := user.Full_Name // Supervisor name from the token
+	supervisorID := user.UserID // Plans submitted to the caller
 
-	// Call usecase with both status and supervisor name
-	plans, err := pc.PlanUsecase.FetchPlansBySupervisorAndStatus(c, supervisorName, status)
+	// Call usecase with both status and supervisor ID
+	plans, err := pc.PlanUsecase.FetchPlansBySupervisorAndStatus(c, supervisorID, status)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -316,6 +413,13 @@ func (pc *PlanController) GetPlansByStatus(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"plans": plans})
 }
 
+// isInvalidContent reports whether err rejects the content of a submitted plan
+// or report.
+func isInvalidContent(err error) bool {
+	return errors.Is(err, domain.ErrInvalidKPI) || errors.Is(err, domain.ErrInvalidParentPlan) || errors.Is(err, domain.ErrInvalidPillar) ||
+		errors.Is(err, domain.ErrInvalidPeriod) || errors.Is(err, domain.ErrInvalidReportPlan)
+}
+
 func (pc *PlanController) CreatePlan(c *gin.Context) {
 	var plan domain.Plan
 	user, ok := c.MustGet("claim").(*domain.JwtCustomClaims) // Get the user from the JWT token
@@ -335,18 +439,74 @@ func (pc *PlanController) CreatePlan(c *gin.Context) {
 	plan.OwnerRole = user.Role
 	plan.OwnerID = user.UserID
 	plan.OwnerName = user.Full_Name
-	plan.SupervisorName = user.To_whom
 	plan.CreatedBy = user.Username
 	// plan.SupervisorPlanID = &user.UserID // Assuming To_whom is the supervisor's ID
 
-	planID, err := pc.PlanUsecase.CreatePlan(c, &plan)
+	// ?draft=true saves the plan without submitting it for approval
+	planID, err := pc.PlanUsecase.CreatePlan(c, &plan, c.Query("draft") == "true")
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if isInvalidContent(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else if errors.Is(err, domain.ErrClosedPeriod) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"plan_id": planID})
 }
+
+// ImportPlans creates the caller's plans from an uploaded CSV or XLSX file.
+// ?dry_run=true only validates the rows and ?draft=true creates drafts.
+func (pc *PlanController) ImportPlans(c *gin.Context) {
+	user, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A file is required"})
+		return
+	}
+	format := c.Query("format")
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(header.Filename)), ".")
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	request := &domain.ImportRequest{
+		Format:   format,
+		Calendar: c.GetString("calendar"),
+		DryRun:   c.Query("dry_run") == "true",
+		Draft:    c.Query("draft") == "true",
+		R:        file,
+	}
+	result, err := pc.PlanUsecase.ImportPlans(c, user, request)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidImport) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	if !result.DryRun && len(result.Errors) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, result)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
 func (pc *PlanController) GetPlansByStatusAndOwner(c *gin.Context) {
 	// Get the status from query params
 	status := c.Query("status")
@@ -380,15 +540,41 @@ func (pc *PlanController) GetPlanTitlesByOwnerName(c *gin.Context) {
 		return
 	}
 
-	ownerName := user.To_whom // Get the "To_whom" field from the JWT token
-
-	titles, err := pc.PlanUsecase.GetPlanTitlesByOwnerName(c, ownerName)
+	// The supervisor's approved plans are the ones a plan can be aligned to
+	plans, err := pc.PlanUsecase.GetParentPlanOptions(c, user)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"titles": titles})
+	titles := make([]string, len(plans))
+	for i, plan := range plans {
+		titles[i] = plan.Title
+	}
+
+	c.JSON(http.StatusOK, gin.H{"titles": titles, "plans": plans})
+}
+
+// GetCascade returns the cascade tree from the strategic pillars down to the
+// staff plans the caller may see. ?pillar= narrows it to one pillar by ID.
+func (pc *PlanController) GetCascade(c *gin.Context) {
+	user, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	cascades, err := pc.PlanUsecase.GetCascade(c, user, c.Query("pillar"))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidPillar) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"pillars": cascades})
 }
 
 func (rc *PlanController) SubmitReport(c *gin.Context) {
@@ -406,7 +592,6 @@ func (rc *PlanController) SubmitReport(c *gin.Context) {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
 		return
 	}
-	report.SupervisorName = user.To_whom
 	// Attach the user ID to the report
 	report.ReportUserID = user.UserID
 	report.Type = "report"
@@ -416,17 +601,123 @@ func (rc *PlanController) SubmitReport(c *gin.Context) {
 	// Call the usecase to submit the report
 	err := rc.PlanUsecase.SubmitReport(c, &report)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if isInvalidContent(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else if errors.Is(err, domain.ErrClosedPeriod) || errors.Is(err, domain.ErrDuplicateReport) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Report submitted successfully"})
 }
+
+// periodQuery reads the period of ?fiscal_year= and ?quarter=. Both are 0,
+// meaning the current period, when fiscal_year is left out.
+func periodQuery(c *gin.Context) (int, int, bool) {
+	fiscalYear, ok := fiscalYearQuery(c)
+	if !ok || fiscalYear == 0 {
+		return 0, 0, ok
+	}
+	quarter, err := strconv.Atoi(c.Query("quarter"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "quarter must be a number"})
+		return 0, 0, false
+	}
+	return fiscalYear, quarter, true
+}
+
+// GetMissingReports lists the plans with no report for a period, the current
+// one unless ?fiscal_year= and ?quarter= are given. ?scope= is mine, team or
+// all.
+func (rc *PlanController) GetMissingReports(c *gin.Context) {
+	user, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	fiscalYear, quarter, ok := periodQuery(c)
+	if !ok {
+		return
+	}
+
+	missing, err := rc.PlanUsecase.GetMissingReports(c, user, c.Query("scope"), fiscalYear, quarter)
+	if err != nil {
+		if err.Error() == "unauthorized access" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized access"})
+		} else if errors.Is(err, domain.ErrInvalidPeriod) || strings.HasPrefix(err.Error(), "invalid scope") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"count": len(missing), "plans": missing})
+}
+
+// GetUnitReport consolidates the approved reports of the caller's subtree
+// for a period.
+func (rc *PlanController) GetUnitReport(c *gin.Context) {
+	user, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	fiscalYear, quarter, ok := periodQuery(c)
+	if !ok {
+		return
+	}
+
+	unit, err := rc.PlanUsecase.GetUnitReport(c, user, fiscalYear, quarter)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidPeriod) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, unit)
+}
+
+// SubmitUnitReport submits the caller's unit report for a period as the
+// caller's own reports.
+func (rc *PlanController) SubmitUnitReport(c *gin.Context) {
+	user, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	fiscalYear, quarter, ok := periodQuery(c)
+	if !ok {
+		return
+	}
+
+	reports, err := rc.PlanUsecase.SubmitUnitReport(c, user, fiscalYear, quarter)
+	if err != nil {
+		if isInvalidContent(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else if errors.Is(err, domain.ErrClosedPeriod) || errors.Is(err, domain.ErrDuplicateReport) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Unit report submitted successfully", "reports": reports})
+}
+
 func (rc *PlanController) GetFilteredReports(c *gin.Context) {
 	status := c.Query("status")
 
 	// Validate status parameter
-	if status != "Pending" && status != "Approved" && status != "Rejected" {
+	if !domain.IsValidStatus(status) && !domain.IsPending(status) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status query parameter"})
 		return
 	}
@@ -473,16 +764,15 @@ func (c *PlanController) CountItems(ctx *gin.Context) {
 		return
 	}
 
-	// Extract "to_whom" from claims
+	// Items are counted for the caller as supervisor
 	claims, ok := ctx.MustGet("claim").(*domain.JwtCustomClaims)
 	if !ok {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid token claims"})
 		return
 	}
-	toWhom := claims.Full_Name // Assuming `FirstName` is part of the claims
-	
+
 	// Call the use case
-	count, err := c.PlanUsecase.CountItems(ctx.Request.Context(), itemType, toWhom)
+	count, err := c.PlanUsecase.CountItems(ctx.Request.Context(), itemType, claims.UserID)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count items"})
 		return
@@ -494,8 +784,6 @@ func (c *PlanController) CountItems(ctx *gin.Context) {
 	})
 }
 
-// GetSubordinateUsers fetches users whose To_whom matches the current user's first name.
-
 func (pc *PlanController) GetPlan(c *gin.Context) {
 	user, ok := c.MustGet("claim").(*domain.JwtCustomClaims) // Extract user claims from the JWT
 	if !ok {
@@ -553,8 +841,7 @@ func (pc *PlanController) GetSubmittedPlans(c *gin.Context) {
 		return
 	}
 
-	supervisor_name := user.To_whom
-	plans, err := pc.PlanUsecase.GetSubmittedPlans(c, supervisor_name)
+	plans, err := pc.PlanUsecase.GetSubmittedPlans(c, user.UserID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -666,3 +953,273 @@ func (cc *PlanController) GetCommentsByPlanID(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"comments": comments})
 }
+
+func (pc *PlanController) TransitionPlan(c *gin.Context) {
+	var request domain.TransitionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	planID, err := primitive.ObjectIDFromHex(c.Param("plan_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Plan ID"})
+		return
+	}
+
+	err = pc.PlanUsecase.TransitionPlan(c, user, planID, &request)
+	if err != nil {
+		if err.Error() == "plan not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Plan not found"})
+		} else if err.Error() == "unauthorized access" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized access"})
+		} else if errors.Is(err, domain.ErrInvalidTransition) || errors.Is(err, domain.ErrClosedPeriod) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		} else if isInvalidContent(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Plan status updated successfully"})
+}
+
+func (pc *PlanController) TransitionReport(c *gin.Context) {
+	var request domain.TransitionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	reportID, err := primitive.ObjectIDFromHex(c.Param("report_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Report ID"})
+		return
+	}
+
+	err = pc.PlanUsecase.TransitionReport(c, user, reportID, &request)
+	if err != nil {
+		if err.Error() == "report not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Report not found"})
+		} else if err.Error() == "unauthorized access" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized access"})
+		} else if errors.Is(err, domain.ErrInvalidTransition) || errors.Is(err, domain.ErrClosedPeriod) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		} else if isInvalidContent(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Report status updated successfully"})
+}
+
+func (pc *PlanController) GetPlanTransitions(c *gin.Context) {
+	user, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	planID, err := primitive.ObjectIDFromHex(c.Param("plan_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Plan ID"})
+		return
+	}
+
+	transitions, err := pc.PlanUsecase.GetPlanTransitions(c, user, planID)
+	if err != nil {
+		if err.Error() == "plan not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Plan not found"})
+		} else if err.Error() == "unauthorized access" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized access"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"transitions": transitions})
+}
+
+func (pc *PlanController) GetReportTransitions(c *gin.Context) {
+	user, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	reportID, err := primitive.ObjectIDFromHex(c.Param("report_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Report ID"})
+		return
+	}
+
+	transitions, err := pc.PlanUsecase.GetReportTransitions(c, user, reportID)
+	if err != nil {
+		if err.Error() == "report not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Report not found"})
+		} else if err.Error() == "unauthorized access" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized access"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"transitions": transitions})
+}
+
+func (pc *PlanController) GetPlanRevisions(c *gin.Context) {
+	user, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	planID, err := primitive.ObjectIDFromHex(c.Param("plan_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Plan ID"})
+		return
+	}
+
+	revisions, err := pc.PlanUsecase.GetPlanRevisions(c, user, planID)
+	if err != nil {
+		if err.Error() == "plan not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Plan not found"})
+		} else if err.Error() == "unauthorized access" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized access"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"revisions": revisions})
+}
+
+func (pc *PlanController) DiffPlanRevisions(c *gin.Context) {
+	user, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	planID, err := primitive.ObjectIDFromHex(c.Param("plan_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Plan ID"})
+		return
+	}
+
+	from, to, ok := revisionRange(c)
+	if !ok {
+		return
+	}
+
+	diff, err := pc.PlanUsecase.DiffPlanRevisions(c, user, planID, from, to)
+	if err != nil {
+		if err.Error() == "plan not found" || err.Error() == "revision not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else if err.Error() == "unauthorized access" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized access"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+func (pc *PlanController) GetReportRevisions(c *gin.Context) {
+	user, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	reportID, err := primitive.ObjectIDFromHex(c.Param("report_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Report ID"})
+		return
+	}
+
+	revisions, err := pc.PlanUsecase.GetReportRevisions(c, user, reportID)
+	if err != nil {
+		if err.Error() == "report not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Report not found"})
+		} else if err.Error() == "unauthorized access" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized access"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"revisions": revisions})
+}
+
+func (pc *PlanController) DiffReportRevisions(c *gin.Context) {
+	user, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	reportID, err := primitive.ObjectIDFromHex(c.Param("report_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Report ID"})
+		return
+	}
+
+	from, to, ok := revisionRange(c)
+	if !ok {
+		return
+	}
+
+	diff, err := pc.PlanUsecase.DiffReportRevisions(c, user, reportID, from, to)
+	if err != nil {
+		if err.Error() == "report not found" || err.Error() == "revision not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else if err.Error() == "unauthorized access" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized access"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// revisionRange reads the from and to revision numbers of a diff request and
+// answers 400 itself when they are missing or malformed.
+func revisionRange(c *gin.Context) (int, int, bool) {
+	from, err := strconv.Atoi(c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must be a revision number"})
+		return 0, 0, false
+	}
+	to, err := strconv.Atoi(c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to must be a revision number"})
+		return 0, 0, false
+	}
+	return from, to, true
+}
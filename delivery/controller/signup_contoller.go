@@ -1,19 +1,13 @@
 package controller
 
 import (
-	"fmt"
 	"net/http"
 	"plan/config"
 	"plan/domain"
 
-	"log"
-	"os"
-	"strconv"
-
 	"github.com/gin-gonic/gin"
-	"github.com/joho/godotenv"
+	"github.com/gin-gonic/gin/binding"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"gopkg.in/gomail.v2"
 )
 
 type SignupController struct {
@@ -30,7 +24,7 @@ func (uc *SignupController) GetUserInfo(c *gin.Context) {
 		UserID string `json:"user_id" binding:"required"`
 	}
 
-	if err := c.ShouldBindJSON(&request); err != nil {
+	if err := c.ShouldBindBodyWith(&request, binding.JSON); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -62,9 +56,6 @@ func (sc *SignupController) Signup(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	if err := SendApprovalEmailcontroller(user.Email, "placeholder"); err != nil {
-		log.Println("failed to send approval email")
-	}
 	c.JSON(http.StatusOK, gin.H{"userID": userID})
 }
 
@@ -75,47 +66,31 @@ func (sc *SignupController) Login(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	token, err := sc.SignupUsecase.LoginUser(c, &user)
+	tokens, err := sc.SignupUsecase.LoginUser(c, &user)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"token": token})
+	c.JSON(http.StatusOK, gin.H{
+		"token":         tokens.AccessToken, // kept for clients that still read the single token
+		"access_token":  tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+	})
 }
-func SendApprovalEmailcontroller(to string, firstName string) error {
-	// SMTP server configuration
-	err := godotenv.Load()
-	if err != nil {
-		log.Fatalf("Error loading .env file")
-		return err
-	}
 
-	// Get SMTP credentials from environment variables
-	smtpUsername := os.Getenv("SMTPUsername")
-	smtpPassword := os.Getenv("SMTPPassword")
-	smtpHost := os.Getenv("SMTPHost")
-	smtpPortStr := os.Getenv("SMTPPort") // Replace with your email password or app password
-	smtpPort, err := strconv.Atoi(smtpPortStr)
+func (sc *SignupController) Refresh(c *gin.Context) {
+	var request domain.RefreshRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	tokens, err := sc.SignupUsecase.RefreshTokens(c, request.RefreshToken)
 	if err != nil {
-		return fmt.Errorf("invalid SMTP port: %v", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
 	}
-
-	// Email content
-	subject := "AASTU Planning System Account pending"
-	body := fmt.Sprintf("Hello %s,\n\nYour account is wating for pending.\n\nThank you for you patience!", firstName)
-
-	// Create email message
-	mailer := gomail.NewMessage()
-	mailer.SetHeader("From", smtpUsername)
-	mailer.SetHeader("To", to)
-	mailer.SetHeader("Subject", subject)
-	mailer.SetBody("text/plain", body)
-
-	// Create email dialer
-	dialer := gomail.NewDialer(smtpHost, smtpPort, smtpUsername, smtpPassword)
-
-	// Send the email
-	return dialer.DialAndSend(mailer)
+	c.JSON(http.StatusOK, tokens)
 }
 
 func (uc *SignupController) GetSubordinateUsers(c *gin.Context) {
@@ -126,11 +101,8 @@ func (uc *SignupController) GetSubordinateUsers(c *gin.Context) {
 		return
 	}
 
-	// Extract first_name from claims
-	firstName := claims.Full_Name
-
 	// Call use case to fetch users and their count
-	users, count, err := uc.SignupUsecase.GetUsersByToWhomWithCount(c.Request.Context(), firstName)
+	users, count, err := uc.SignupUsecase.GetSubordinatesWithCount(c.Request.Context(), claims.UserID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch users"})
 		return
@@ -142,17 +114,14 @@ func (uc *SignupController) GetSubordinateUsers(c *gin.Context) {
 	})
 }
 
-func (uc *SignupController) GetUnverifiedUsersByToWhom(c *gin.Context) {
-	claims := c.MustGet("claim").(*domain.JwtCustomClaims)
-	firstName := claims.Full_Name
-	fmt.Printf("First name: %s\n", claims)
-
-	if firstName == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized or missing first name"})
+func (uc *SignupController) GetUnverifiedUsers(c *gin.Context) {
+	claims, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid claims"})
 		return
 	}
 
-	users, err := uc.SignupUsecase.FetchUnverifiedUsersByToWhom(c, firstName)
+	users, err := uc.SignupUsecase.FetchUnverifiedSubordinates(c, claims.UserID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -165,7 +134,7 @@ func (uc *SignupController) VerifyUser(c *gin.Context) {
 		UserID string `json:"user_id" binding:"required"`
 	}
 
-	if err := c.ShouldBindJSON(&request); err != nil {
+	if err := c.ShouldBindBodyWith(&request, binding.JSON); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -184,7 +153,7 @@ func (sc *SignupController) RejectUser(c *gin.Context) {
 		UserID string `json:"user_id" binding:"required"`
 	}
 
-	if err := c.ShouldBindJSON(&request); err != nil {
+	if err := c.ShouldBindBodyWith(&request, binding.JSON); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -204,6 +173,91 @@ func (sc *SignupController) RejectUser(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "User rejected and deleted successfully"})
 }
 
+func (sc *SignupController) Logout(c *gin.Context) {
+	var request domain.LogoutRequest
+
+	// The body is optional; an empty one only revokes the current access token.
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	claims, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid claims"})
+		return
+	}
+
+	if err := sc.SignupUsecase.Logout(c, claims, &request); err != nil {
+		if err.Error() == "invalid refresh token" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
+}
+
+func (sc *SignupController) UpdateUserRole(c *gin.Context) {
+	var request struct {
+		UserID string `json:"user_id" binding:"required"`
+		Role   string `json:"role" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := sc.SignupUsecase.UpdateUserRole(c, request.UserID, request.Role)
+	if err != nil {
+		if err.Error() == "user not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User role updated successfully"})
+}
+
+func (sc *SignupController) UpdateCalendarPreference(c *gin.Context) {
+	var request struct {
+		Calendar string `json:"calendar" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	claims, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	tokens, err := sc.SignupUsecase.UpdateCalendarPreference(c, claims.UserID, request.Calendar)
+	if err != nil {
+		if err.Error() == "user not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":       "Calendar preference updated successfully",
+		"access_token":  tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+	})
+}
+
 // func (uc *SignupController) VerifyStatus(c *gin.Context) {
 // 	// Extract user ID from JWT token (assumes middleware sets user ID in context)
 
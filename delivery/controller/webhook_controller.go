@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"plan/config"
+	"plan/domain"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookController struct {
+	WebhookUsecase domain.WebhookUsecase
+	Env            *config.Env
+}
+
+// webhookErrorStatus maps usecase errors to HTTP status codes.
+func webhookErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrWebhookNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrInvalidWebhook):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// CreateWebhook subscribes a URL to plan and report events. The response
+// holds the signing secret, which is not shown again.
+func (wc *WebhookController) CreateWebhook(c *gin.Context) {
+	claims, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var request domain.WebhookRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	webhook, err := wc.WebhookUsecase.CreateWebhook(c, claims, &request)
+	if err != nil {
+		c.JSON(webhookErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, webhook)
+}
+
+func (wc *WebhookController) ListWebhooks(c *gin.Context) {
+	webhooks, err := wc.WebhookUsecase.ListWebhooks(c)
+	if err != nil {
+		c.JSON(webhookErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"count": len(webhooks), "webhooks": webhooks})
+}
+
+func (wc *WebhookController) GetWebhook(c *gin.Context) {
+	webhook, err := wc.WebhookUsecase.GetWebhook(c, c.Param("webhook_id"))
+	if err != nil {
+		c.JSON(webhookErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, webhook)
+}
+
+// UpdateWebhook changes the fields sent. Sending a secret, even an empty one
+// to have it generated, rotates it.
+func (wc *WebhookController) UpdateWebhook(c *gin.Context) {
+	var request domain.WebhookRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	webhook, err := wc.WebhookUsecase.UpdateWebhook(c, c.Param("webhook_id"), &request)
+	if err != nil {
+		c.JSON(webhookErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, webhook)
+}
+
+func (wc *WebhookController) DeleteWebhook(c *gin.Context) {
+	if err := wc.WebhookUsecase.DeleteWebhook(c, c.Param("webhook_id")); err != nil {
+		c.JSON(webhookErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook deleted successfully"})
+}
+
+// PingWebhook sends a ping event to the webhook and returns the delivery,
+// failed or not.
+func (wc *WebhookController) PingWebhook(c *gin.Context) {
+	delivery, err := wc.WebhookUsecase.PingWebhook(c, c.Param("webhook_id"))
+	if err != nil {
+		c.JSON(webhookErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, delivery)
+}
+
+// ListDeliveries lists the webhook's delivery attempts, newest first.
+func (wc *WebhookController) ListDeliveries(c *gin.Context) {
+	var limit int64
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || parsed < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+		limit = parsed
+	}
+
+	deliveries, err := wc.WebhookUsecase.ListDeliveries(c, c.Param("webhook_id"), limit)
+	if err != nil {
+		c.JSON(webhookErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"count": len(deliveries), "deliveries": deliveries})
+}
@@ -1,7 +1,9 @@
 package middleware
 
 import (
+	"plan/domain"
 	"plan/internal/tokenutil"
+
 	// "fmt"
 	"net/http"
 	"strings"
@@ -9,28 +11,54 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func AuthMidd(c *gin.Context) {
-	authHeader := c.GetHeader("Authorization")
-	if authHeader == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
-		c.Abort()
-		return
-	}
+// EventStreamPath is the route of the server-sent event stream.
+const EventStreamPath = "/events"
 
-	tokenString := strings.TrimSpace(strings.TrimPrefix(authHeader, "Bearer "))
-	if tokenString == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token format"})
-		c.Abort()
-		return
-	}
+// AuthMidd validates the bearer access token signed with secret, rejects it if
+// it has been revoked and stores its claims in the context under "claim".
+// Browsers cannot set headers on EventSource requests, so the event stream,
+// and only it, may pass the token as the access_token query parameter
+// instead. Logger keeps the parameter out of the access log.
+func AuthMidd(secret string, revocations domain.RevocationRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" && c.FullPath() == EventStreamPath && c.Query("access_token") != "" {
+			authHeader = "Bearer " + c.Query("access_token")
+		}
+		if authHeader == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
+			c.Abort()
+			return
+		}
+
+		tokenString := strings.TrimSpace(strings.TrimPrefix(authHeader, "Bearer "))
+		if tokenString == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token format"})
+			c.Abort()
+			return
+		}
+
+		claims, err := tokenutil.VerifyToken(tokenString, secret)
+		if err != nil || claims.Kind != domain.AccessTokenKind {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			c.Abort()
+			return
+		}
+
+		revoked, err := revocations.IsRevoked(c.Request.Context(), claims)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check token"})
+			c.Abort()
+			return
+		}
+		if revoked {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token has been revoked"})
+			c.Abort()
+			return
+		}
 
-	claims, err := tokenutil.VerifyToken(tokenString)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
-		c.Abort()
-		return
+		c.Set("userID", claims.UserID.Hex())
+		c.Set("claim", claims)
+		c.Next()
 	}
-	c.Set("userID", claims.UserID.Hex())
-	c.Set("claim", claims)
-	c.Next()
 }
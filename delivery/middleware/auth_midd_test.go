@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"plan/domain"
+	"plan/internal/tokenutil"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const testSecret = "access-secret"
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+type fakeRevocations struct {
+	domain.RevocationRepository
+	revoked map[string]bool
+	err     error
+}
+
+func (r *fakeRevocations) IsRevoked(ctx context.Context, claims *domain.JwtCustomClaims) (bool, error) {
+	return r.revoked[claims.Id], r.err
+}
+
+// authRouter serves path behind AuthMidd and answers with the ID of the
+// user in the claims it stored.
+func authRouter(revocations domain.RevocationRepository, path string) *gin.Engine {
+	router := gin.New()
+	group := router.Group("")
+	group.Use(AuthMidd(testSecret, revocations))
+	group.GET(path, func(c *gin.Context) {
+		claims := c.MustGet("claim").(*domain.JwtCustomClaims)
+		c.String(http.StatusOK, claims.UserID.Hex())
+	})
+	return router
+}
+
+func TestAuthMidd(t *testing.T) {
+	user := &domain.User{ID: primitive.NewObjectID(), Role: domain.RoleStaff}
+	access, accessClaims, err := tokenutil.CreateAccessToken(user, testSecret, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	refresh, _, err := tokenutil.CreateRefreshToken(user, testSecret, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	foreign, _, err := tokenutil.CreateAccessToken(user, "other-secret", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		authorization string
+		revocations   *fakeRevocations
+		status        int
+	}{
+		{"valid", "Bearer " + access, &fakeRevocations{}, http.StatusOK},
+		{"no header", "", &fakeRevocations{}, http.StatusUnauthorized},
+		{"empty bearer", "Bearer ", &fakeRevocations{}, http.StatusUnauthorized},
+		{"refresh token", "Bearer " + refresh, &fakeRevocations{}, http.StatusUnauthorized},
+		{"other secret", "Bearer " + foreign, &fakeRevocations{}, http.StatusUnauthorized},
+		{"revoked jti", "Bearer " + access, &fakeRevocations{revoked: map[string]bool{accessClaims.Id: true}}, http.StatusUnauthorized},
+		{"other jti revoked", "Bearer " + access, &fakeRevocations{revoked: map[string]bool{"other": true}}, http.StatusOK},
+		{"revocation check fails", "Bearer " + access, &fakeRevocations{err: errors.New("mongo down")}, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/plans", nil)
+			if tt.authorization != "" {
+				request.Header.Set("Authorization", tt.authorization)
+			}
+			recorder := httptest.NewRecorder()
+			authRouter(tt.revocations, "/plans").ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", recorder.Code, tt.status, recorder.Body)
+			}
+			if tt.status == http.StatusOK && recorder.Body.String() != user.ID.Hex() {
+				t.Errorf("claims of user %s, want %s", recorder.Body, user.ID.Hex())
+			}
+		})
+	}
+}
+
+func TestAuthMiddRejectsExpiredToken(t *testing.T) {
+	user := &domain.User{ID: primitive.NewObjectID(), Role: domain.RoleStaff}
+	token, _, err := tokenutil.CreateAccessToken(user, testSecret, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/plans", nil)
+	request.Header.Set("Authorization", "Bearer "+token)
+	recorder := httptest.NewRecorder()
+	authRouter(&fakeRevocations{}, "/plans").ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", recorder.Code, http.StatusUnauthorized)
+	}
+}
@@ -0,0 +1,199 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"plan/domain"
+	"plan/internal/ethiocal"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CalendarHeader picks the calendar of a request's dates.
+const CalendarHeader = "X-Calendar"
+
+// dateFields are the JSON fields holding dates.
+var dateFields = map[string]bool{
+	"start_date":   true,
+	"end_date":     true,
+	"created_at":   true,
+	"updated_at":   true,
+	"deadline":     true,
+	"created_time": true,
+	"decided_at":   true,
+}
+
+// Calendar lets clients read and write dates in the Ethiopian calendar. The
+// X-Calendar header picks the calendar of a request and falls back to the
+// caller's preference. Dates in JSON bodies are converted on the way in and
+// out, so the rest of the application only deals with Gregorian dates.
+//
+// Ethiopian dates keep the RFC 3339 layout with the Ethiopian day in place of
+// the Gregorian one, e.g. 2016-11-01T00:00:00Z. Requests may also send the
+// bare date.
+func Calendar() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		calendar := c.GetHeader(CalendarHeader)
+		if calendar == "" {
+			if claims, ok := c.Get("claim"); ok {
+				calendar = claims.(*domain.JwtCustomClaims).Calendar
+			}
+		}
+		if calendar == "" {
+			calendar = domain.CalendarGregorian
+		}
+		if !domain.IsValidCalendar(calendar) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Unsupported calendar: " + calendar})
+			return
+		}
+
+		c.Set("calendar", calendar)
+		c.Header(CalendarHeader, calendar)
+		if calendar != domain.CalendarEthiopian {
+			c.Next()
+			return
+		}
+
+		if c.Request.Body != nil && strings.HasPrefix(c.ContentType(), "application/json") {
+			body, err := io.ReadAll(c.Request.Body)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			if len(body) > 0 {
+				body, err = convertDates(body, toGregorian)
+				if err != nil {
+					c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+					return
+				}
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+			c.Request.ContentLength = int64(len(body))
+		}
+
+		writer := &calendarWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+		writer.flush()
+	}
+}
+
+// calendarWriter holds back JSON responses so their dates can be converted
+// once the handler is done. Other responses are written straight through.
+type calendarWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *calendarWriter) buffering() bool {
+	return strings.HasPrefix(w.Header().Get("Content-Type"), "application/json")
+}
+
+func (w *calendarWriter) Write(data []byte) (int, error) {
+	if !w.buffering() {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.body.Write(data)
+}
+
+func (w *calendarWriter) WriteString(s string) (int, error) {
+	if !w.buffering() {
+		return w.ResponseWriter.WriteString(s)
+	}
+	return w.body.WriteString(s)
+}
+
+func (w *calendarWriter) flush() {
+	if w.body.Len() == 0 {
+		return
+	}
+	body, err := convertDates(w.body.Bytes(), toEthiopian)
+	if err != nil {
+		body = w.body.Bytes()
+	}
+	w.ResponseWriter.Write(body)
+}
+
+// convertDates rewrites the string values of date fields anywhere in a JSON
+// document.
+func convertDates(data []byte, convert func(string) (string, error)) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+
+	document, err := walkDates(document, false, convert)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(document)
+}
+
+func walkDates(value interface{}, isDate bool, convert func(string) (string, error)) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			converted, err := walkDates(field, dateFields[key], convert)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = converted
+		}
+	case []interface{}:
+		for i, item := range v {
+			converted, err := walkDates(item, isDate, convert)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = converted
+		}
+	case string:
+		if isDate {
+			return convert(v)
+		}
+	}
+	return value, nil
+}
+
+// toEthiopian swaps the Gregorian day of an RFC 3339 time for the Ethiopian
+// one. Zero times and values that are not times are left alone.
+func toEthiopian(value string) (string, error) {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil || t.IsZero() {
+		return value, nil
+	}
+	return ethiocal.FromTime(t).String() + value[10:], nil
+}
+
+// toGregorian swaps the Ethiopian day of a date or RFC 3339 time for the
+// Gregorian one. Bare dates become midnight UTC.
+func toGregorian(value string) (string, error) {
+	if value == "" || strings.HasPrefix(value, "0001-01-01") {
+		return value, nil
+	}
+	if len(value) < 10 {
+		return "", fmt.Errorf("invalid Ethiopian date %q", value)
+	}
+
+	date, err := ethiocal.Parse(value[:10])
+	if err != nil {
+		return "", err
+	}
+	gregorian := date.Time(time.UTC).Format("2006-01-02")
+	if len(value) == 10 {
+		return gregorian + "T00:00:00Z", nil
+	}
+
+	converted := gregorian + value[10:]
+	if _, err := time.Parse(time.RFC3339Nano, converted); err != nil {
+		return "", fmt.Errorf("invalid Ethiopian date %q", value)
+	}
+	return converted, nil
+}
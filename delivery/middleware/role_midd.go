@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"plan/domain"
+	"plan/internal/userutil"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UserIDSource extracts the ID of the user a request acts on.
+type UserIDSource func(c *gin.Context) (string, error)
+
+// UserIDFromParam reads the target user ID from a path parameter.
+func UserIDFromParam(name string) UserIDSource {
+	return func(c *gin.Context) (string, error) {
+		return c.Param(name), nil
+	}
+}
+
+// UserIDFromJSON reads the target user ID from a field of the JSON body. The
+// body is cached, so handlers must bind it with ShouldBindBodyWith.
+func UserIDFromJSON(field string) UserIDSource {
+	return func(c *gin.Context) (string, error) {
+		var body map[string]interface{}
+		if err := c.ShouldBindBodyWith(&body, binding.JSON); err != nil {
+			return "", err
+		}
+		userID, _ := body[field].(string)
+		if userID == "" {
+			return "", errors.New(field + " is required")
+		}
+		return userID, nil
+	}
+}
+
+// RequireRole lets the request through only if the caller has one of roles.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			c.Abort()
+			return
+		}
+
+		for _, role := range roles {
+			if claims.Role == role {
+				c.Next()
+				return
+			}
+		}
+
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized access"})
+		c.Abort()
+	}
+}
+
+// RequireSupervisorRole lets the request through only if some role reports to
+// the caller's in the stored role hierarchy, so roles added there can reach
+// supervisor routes. The planning office always passes.
+func RequireSupervisorRole(rules domain.RoleRuleRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			c.Abort()
+			return
+		}
+		if claims.Role == domain.RolePlanningOffice {
+			c.Next()
+			return
+		}
+
+		subordinates, err := rules.FindSubordinateRoles(c.Request.Context(), claims.Role)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check role"})
+			c.Abort()
+			return
+		}
+		if len(subordinates) == 0 {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized access"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireSupervisorOf lets the request through only if the caller may perform
+// manip on the user identified by source, as decided by
+// userutil.CanManipulateUser.
+func RequireSupervisorOf(users domain.UserRepository, source UserIDSource, manip string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := c.MustGet("claim").(*domain.JwtCustomClaims)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			c.Abort()
+			return
+		}
+
+		userID, err := source(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			c.Abort()
+			return
+		}
+		objectID, err := primitive.ObjectIDFromHex(userID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+			c.Abort()
+			return
+		}
+
+		user, err := users.GetUserByID(c.Request.Context(), objectID)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			c.Abort()
+			return
+		}
+
+		if denied := userutil.CanManipulateUser(claims, user, manip); denied != nil {
+			c.JSON(denied.StatusCode, gin.H{"error": denied.Message})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,167 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"plan/domain"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// roleRouter serves POST path behind guard for a caller with claims.
+func roleRouter(claims *domain.JwtCustomClaims, path string, guard gin.HandlerFunc) *gin.Engine {
+	router := gin.New()
+	router.POST(path, func(c *gin.Context) {
+		c.Set("claim", claims)
+	}, guard, func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func TestRequireRole(t *testing.T) {
+	tests := []struct {
+		role   string
+		status int
+	}{
+		{domain.RoleDirector, http.StatusOK},
+		{domain.RolePlanningOffice, http.StatusOK},
+		{domain.RoleStaff, http.StatusForbidden},
+		{"", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		claims := &domain.JwtCustomClaims{UserID: primitive.NewObjectID(), Role: tt.role}
+		guard := RequireRole(domain.RoleDirector, domain.RolePlanningOffice)
+
+		recorder := httptest.NewRecorder()
+		roleRouter(claims, "/plans", guard).ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/plans", nil))
+
+		if recorder.Code != tt.status {
+			t.Errorf("role %q: status = %d, want %d", tt.role, recorder.Code, tt.status)
+		}
+	}
+}
+
+type fakeUserRepository struct {
+	domain.UserRepository
+	users map[primitive.ObjectID]*domain.User
+}
+
+func (r *fakeUserRepository) GetUserByID(ctx context.Context, userID primitive.ObjectID) (*domain.User, error) {
+	user, ok := r.users[userID]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	return user, nil
+}
+
+func TestRequireSupervisorOf(t *testing.T) {
+	supervisor := primitive.NewObjectID()
+	subordinate := &domain.User{ID: primitive.NewObjectID(), Role: domain.RoleStaff, SupervisorID: supervisor}
+	users := &fakeUserRepository{users: map[primitive.ObjectID]*domain.User{subordinate.ID: subordinate}}
+
+	tests := []struct {
+		name   string
+		caller *domain.JwtCustomClaims
+		target string
+		manip  string
+		status int
+	}{
+		{"supervisor", &domain.JwtCustomClaims{UserID: supervisor, Role: domain.RoleTeamLead}, subordinate.ID.Hex(), "approve", http.StatusOK},
+		{"planning office", &domain.JwtCustomClaims{UserID: primitive.NewObjectID(), Role: domain.RolePlanningOffice}, subordinate.ID.Hex(), "approve", http.StatusOK},
+		{"another team lead", &domain.JwtCustomClaims{UserID: primitive.NewObjectID(), Role: domain.RoleTeamLead}, subordinate.ID.Hex(), "approve", http.StatusForbidden},
+		{"own account", &domain.JwtCustomClaims{UserID: subordinate.ID, Role: domain.RoleStaff}, subordinate.ID.Hex(), "approve", http.StatusForbidden},
+		{"view own account", &domain.JwtCustomClaims{UserID: subordinate.ID, Role: domain.RoleStaff}, subordinate.ID.Hex(), "view", http.StatusOK},
+		{"unknown user", &domain.JwtCustomClaims{UserID: supervisor, Role: domain.RoleTeamLead}, primitive.NewObjectID().Hex(), "approve", http.StatusNotFound},
+		{"malformed ID", &domain.JwtCustomClaims{UserID: supervisor, Role: domain.RoleTeamLead}, "not-an-id", "approve", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guard := RequireSupervisorOf(users, UserIDFromParam("id"), tt.manip)
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodPost, "/users/"+tt.target, nil)
+			roleRouter(tt.caller, "/users/:id", guard).ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.status {
+				t.Errorf("status = %d, want %d: %s", recorder.Code, tt.status, recorder.Body)
+			}
+		})
+	}
+}
+
+func TestRequireSupervisorOfJSONBody(t *testing.T) {
+	supervisor := primitive.NewObjectID()
+	subordinate := &domain.User{ID: primitive.NewObjectID(), Role: domain.RoleStaff, SupervisorID: supervisor}
+	users := &fakeUserRepository{users: map[primitive.ObjectID]*domain.User{subordinate.ID: subordinate}}
+	caller := &domain.JwtCustomClaims{UserID: supervisor, Role: domain.RoleTeamLead}
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"supervisor", `{"user_id":"` + subordinate.ID.Hex() + `"}`, http.StatusOK},
+		{"missing field", `{}`, http.StatusBadRequest},
+		{"not JSON", `user_id=1`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		guard := RequireSupervisorOf(users, UserIDFromJSON("user_id"), "approve")
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, "/users/approve", strings.NewReader(tt.body))
+		request.Header.Set("Content-Type", "application/json")
+		roleRouter(caller, "/users/approve", guard).ServeHTTP(recorder, request)
+
+		if recorder.Code != tt.status {
+			t.Errorf("%s: status = %d, want %d: %s", tt.name, recorder.Code, tt.status, recorder.Body)
+		}
+	}
+}
+
+type fakeRoleRules struct {
+	domain.RoleRuleRepository
+	subordinates map[string][]domain.RoleRule
+	err          error
+}
+
+func (r *fakeRoleRules) FindSubordinateRoles(ctx context.Context, role string) ([]domain.RoleRule, error) {
+	return r.subordinates[role], r.err
+}
+
+func TestRequireSupervisorRole(t *testing.T) {
+	rules := &fakeRoleRules{subordinates: map[string][]domain.RoleRule{
+		domain.RoleTeamLead: {{Role: domain.RoleStaff, SuperiorRole: domain.RoleTeamLead}},
+		"regional_head":     {{Role: domain.RoleDirector, SuperiorRole: "regional_head"}},
+	}}
+
+	tests := []struct {
+		name   string
+		role   string
+		rules  *fakeRoleRules
+		status int
+	}{
+		{"team lead", domain.RoleTeamLead, rules, http.StatusOK},
+		{"role added to the hierarchy", "regional_head", rules, http.StatusOK},
+		{"planning office", domain.RolePlanningOffice, &fakeRoleRules{}, http.StatusOK},
+		{"no subordinates", domain.RoleStaff, rules, http.StatusForbidden},
+		{"lookup fails", domain.RoleTeamLead, &fakeRoleRules{err: errors.New("mongo down")}, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		claims := &domain.JwtCustomClaims{UserID: primitive.NewObjectID(), Role: tt.role}
+
+		recorder := httptest.NewRecorder()
+		roleRouter(claims, "/plans", RequireSupervisorRole(tt.rules)).ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/plans", nil))
+
+		if recorder.Code != tt.status {
+			t.Errorf("%s: status = %d, want %d", tt.name, recorder.Code, tt.status)
+		}
+	}
+}
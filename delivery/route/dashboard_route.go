@@ -0,0 +1,26 @@
+package route
+
+import (
+	"plan/config"
+	"plan/database"
+	"plan/delivery/controller"
+	"plan/domain"
+	"plan/repository"
+	"plan/usecase"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func NewDashboardRouter(env *config.Env, timeout time.Duration, db database.Database, group *gin.RouterGroup) {
+	plans := repository.NewPlanRepository(db, domain.CollectionPlan)
+	reports := repository.NewReportRepository(db, domain.CollectionReport)
+	users := repository.NewUserRepository(db, domain.CollectionStaff)
+
+	dc := controller.DashboardController{
+		DashboardUsecase: usecase.NewDashboardUsecase(plans, reports, users, timeout),
+		Env:              env,
+	}
+
+	group.GET("/dashboard", dc.GetDashboard)
+}
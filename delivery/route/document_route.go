@@ -0,0 +1,28 @@
+package route
+
+import (
+	"plan/config"
+	"plan/database"
+	"plan/delivery/controller"
+	"plan/domain"
+	"plan/repository"
+	"plan/usecase"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func NewDocumentRouter(env *config.Env, timeout time.Duration, db database.Database, group *gin.RouterGroup) {
+	plans := repository.NewPlanRepository(db, domain.CollectionPlan)
+	reports := repository.NewReportRepository(db, domain.CollectionReport)
+	transitions := repository.NewTransitionRepository(db, domain.CollectionTransition)
+	users := repository.NewUserRepository(db, domain.CollectionStaff)
+
+	dc := controller.DocumentController{
+		DocumentUsecase: usecase.NewDocumentUsecase(plans, reports, transitions, users, env.PDFFontPath, timeout),
+		Env:             env,
+	}
+
+	group.GET("/plans/:plan_id/pdf", dc.GetPlanPDF)
+	group.GET("/reports/:report_id/pdf", dc.GetReportPDF)
+}
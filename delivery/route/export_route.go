@@ -0,0 +1,30 @@
+package route
+
+import (
+	"plan/config"
+	"plan/database"
+	"plan/delivery/controller"
+	"plan/delivery/middleware"
+	"plan/domain"
+	"plan/repository"
+	"plan/usecase"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func NewExportRouter(env *config.Env, timeout time.Duration, db database.Database, group *gin.RouterGroup) {
+	plans := repository.NewPlanRepository(db, domain.CollectionPlan)
+	reports := repository.NewReportRepository(db, domain.CollectionReport)
+	users := repository.NewUserRepository(db, domain.CollectionStaff)
+	units := repository.NewOrgUnitRepository(db, domain.CollectionOrgUnit)
+
+	ec := controller.ExportController{
+		ExportUsecase: usecase.NewExportUsecase(plans, reports, users, units, timeout),
+		Env:           env,
+	}
+	planningOfficeOnly := middleware.RequireRole(domain.RolePlanningOffice)
+
+	group.GET("/export/plans", planningOfficeOnly, ec.ExportPlans)
+	group.GET("/export/reports", planningOfficeOnly, ec.ExportReports)
+}
@@ -0,0 +1,36 @@
+package route
+
+import (
+	"plan/config"
+	"plan/database"
+	"plan/delivery/controller"
+	"plan/delivery/middleware"
+	"plan/domain"
+	"plan/repository"
+	"plan/usecase"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func NewFiscalRouter(env *config.Env, timeout time.Duration, db database.Database, group *gin.RouterGroup) {
+	fyr := repository.NewFiscalYearRepository(db, domain.CollectionFiscalYear)
+	plans := repository.NewPlanRepository(db, domain.CollectionPlan)
+	reports := repository.NewReportRepository(db, domain.CollectionReport)
+	pillars := repository.NewPillarRepository(db, domain.CollectionPillar)
+
+	fc := controller.FiscalController{
+		FiscalUsecase: usecase.NewFiscalUsecase(fyr, plans, reports, pillars, timeout),
+		Env:           env,
+	}
+	planningOfficeOnly := middleware.RequireRole(domain.RolePlanningOffice)
+
+	group.GET("/fiscal-years", fc.ListFiscalYears)
+	group.GET("/fiscal-years/current", fc.GetCurrentPeriod)
+	group.GET("/fiscal-years/:year", fc.GetFiscalYear)
+	group.POST("/fiscal-years", planningOfficeOnly, fc.CreateFiscalYear)
+	group.PUT("/fiscal-years/:year", planningOfficeOnly, fc.UpdateFiscalYear)
+	group.DELETE("/fiscal-years/:year", planningOfficeOnly, fc.DeleteFiscalYear)
+	group.PUT("/fiscal-years/:year/periods/:quarter/open", planningOfficeOnly, fc.OpenPeriod)
+	group.PUT("/fiscal-years/:year/periods/:quarter/close", planningOfficeOnly, fc.ClosePeriod)
+}
@@ -0,0 +1,38 @@
+package route
+
+import (
+	"plan/config"
+	"plan/database"
+	"plan/delivery/controller"
+	"plan/delivery/middleware"
+	"plan/domain"
+	"plan/repository"
+	"plan/usecase"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func NewNotificationRouter(env *config.Env, timeout time.Duration, db database.Database, events domain.EventHub, group *gin.RouterGroup) {
+	nr := repository.NewNotificationRepository(db, domain.CollectionNotification)
+
+	nu := usecase.NewNotificationUsecase(nr, timeout)
+
+	nc := controller.NotificationController{
+		NotificationUsecase: nu,
+		Env:                 env,
+	}
+	ec := controller.EventController{
+		Events:              events,
+		NotificationUsecase: nu,
+		Revocations:         repository.NewRevocationRepository(db, domain.CollectionRevokedToken),
+		Env:                 env,
+	}
+
+	group.GET("/notifications", nc.ListNotifications)
+	group.GET("/notifications/unread-count", nc.GetUnreadCount)
+	group.PUT("/notifications/read-all", nc.MarkAllRead)
+	group.PUT("/notifications/:notification_id/read", nc.MarkRead)
+	group.PUT("/notifications/:notification_id/unread", nc.MarkUnread)
+	group.GET(middleware.EventStreamPath, ec.StreamEvents)
+}
@@ -0,0 +1,39 @@
+package route
+
+import (
+	"plan/config"
+	"plan/database"
+	"plan/delivery/controller"
+	"plan/delivery/middleware"
+	"plan/domain"
+	"plan/repository"
+	"plan/usecase"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func NewOrgRouter(env *config.Env, timeout time.Duration, db database.Database, group *gin.RouterGroup) {
+	our := repository.NewOrgUnitRepository(db, domain.CollectionOrgUnit)
+	rrr := repository.NewRoleRuleRepository(db, domain.CollectionRoleRule)
+	ur := repository.NewUserRepository(db, domain.CollectionStaff)
+
+	oc := controller.OrgController{
+		OrgUsecase: usecase.NewOrgUsecase(our, rrr, ur, timeout),
+		Env:        env,
+	}
+	planningOfficeOnly := middleware.RequireRole(domain.RolePlanningOffice)
+
+	group.GET("/org/tree", oc.GetTree)
+	group.GET("/org/users/:user_id/chain", oc.GetChainOfCommand)
+	group.GET("/org/users/:user_id/subordinates", oc.GetSubordinates)
+
+	group.POST("/org/units", planningOfficeOnly, oc.CreateUnit)
+	group.PUT("/org/units/:id", planningOfficeOnly, oc.UpdateUnit)
+	group.DELETE("/org/units/:id", planningOfficeOnly, oc.DeleteUnit)
+	group.PUT("/org/users/:user_id/unit", planningOfficeOnly, oc.AssignUser)
+
+	group.GET("/org/roles", oc.ListRoleRules)
+	group.PUT("/org/roles", planningOfficeOnly, oc.SaveRoleRule)
+	group.DELETE("/org/roles/:role", planningOfficeOnly, oc.DeleteRoleRule)
+}
@@ -0,0 +1,28 @@
+package route
+
+import (
+	"plan/config"
+	"plan/database"
+	"plan/delivery/controller"
+	"plan/delivery/middleware"
+	"plan/domain"
+	"plan/repository"
+	"plan/usecase"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func NewOutboxRouter(env *config.Env, timeout time.Duration, db database.Database, group *gin.RouterGroup) {
+	or := repository.NewOutboxRepository(db, domain.CollectionOutbox)
+
+	oc := controller.OutboxController{
+		OutboxUsecase: usecase.NewOutboxUsecase(or, timeout),
+		Env:           env,
+	}
+	planningOfficeOnly := middleware.RequireRole(domain.RolePlanningOffice)
+
+	group.GET("/outbox", planningOfficeOnly, oc.ListOutbox)
+	group.GET("/outbox/:message_id", planningOfficeOnly, oc.GetOutboxMessage)
+	group.POST("/outbox/:message_id/retry", planningOfficeOnly, oc.RetryOutboxMessage)
+}
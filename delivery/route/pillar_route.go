@@ -0,0 +1,33 @@
+package route
+
+import (
+	"plan/config"
+	"plan/database"
+	"plan/delivery/controller"
+	"plan/delivery/middleware"
+	"plan/domain"
+	"plan/repository"
+	"plan/usecase"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func NewPillarRouter(env *config.Env, timeout time.Duration, db database.Database, group *gin.RouterGroup) {
+	pr := repository.NewPillarRepository(db, domain.CollectionPillar)
+	plans := repository.NewPlanRepository(db, domain.CollectionPlan)
+	fyr := repository.NewFiscalYearRepository(db, domain.CollectionFiscalYear)
+
+	pc := controller.PillarController{
+		PillarUsecase: usecase.NewPillarUsecase(pr, plans, fyr, timeout),
+		Env:           env,
+	}
+	planningOfficeOnly := middleware.RequireRole(domain.RolePlanningOffice)
+
+	group.GET("/pillars", pc.ListPillars)
+	group.GET("/pillars/stats", pc.GetPillarStats)
+	group.GET("/pillars/:id", pc.GetPillar)
+	group.POST("/pillars", planningOfficeOnly, pc.CreatePillar)
+	group.PUT("/pillars/:id", planningOfficeOnly, pc.UpdatePillar)
+	group.DELETE("/pillars/:id", planningOfficeOnly, pc.DeletePillar)
+}
@@ -4,8 +4,9 @@ import (
 	"plan/config"
 	"plan/database"
 	"plan/delivery/controller"
+	"plan/delivery/middleware"
+	"plan/domain"
 
-	// "plan/domain"
 	"plan/repository"
 	"plan/usecase"
 	"time"
@@ -15,36 +16,72 @@ import (
 
 // Setup sets up the routes for the application
 
-func NewPlanRouter(env *config.Env, timeout time.Duration, db database.Database, group *gin.RouterGroup) {
-	ur := repository.NewPlanRepository(db, "Plan")
+func NewPlanRouter(env *config.Env, timeout time.Duration, db database.Database, events domain.EventHub, group *gin.RouterGroup) {
+	ur := repository.NewPlanRepository(db, domain.CollectionPlan)
+	rr := repository.NewReportRepository(db, domain.CollectionReport)
+	ar := repository.NewAnnouncementRepository(db, domain.CollectionAnnouncement)
+	cr := repository.NewCommentRepository(db, domain.CollectionComment)
+	users := repository.NewUserRepository(db, domain.CollectionStaff)
+	rrr := repository.NewRoleRuleRepository(db, domain.CollectionRoleRule)
+	apr := repository.NewApprovalPolicyRepository(db, domain.CollectionApprovalPolicy)
+	tr := repository.NewTransitionRepository(db, domain.CollectionTransition)
+	rvr := repository.NewRevisionRepository(db, domain.CollectionRevision)
+	pr := repository.NewPillarRepository(db, domain.CollectionPillar)
+	fyr := repository.NewFiscalYearRepository(db, domain.CollectionFiscalYear)
+	tx := repository.NewTransactor(db)
+	notifier := usecase.NewNotifier(repository.NewNotificationRepository(db, domain.CollectionNotification), events)
+	webhooks := usecase.NewWebhookDispatcher(repository.NewWebhookRepository(db, domain.CollectionWebhook), repository.NewOutboxRepository(db, domain.CollectionOutbox))
 
 	sc := controller.PlanController{
-		PlanUsecase: usecase.NewPlanUsecase(ur, timeout),
+		PlanUsecase: usecase.NewPlanUsecase(ur, rr, ar, cr, users, rrr, apr, tr, rvr, pr, fyr, tx, notifier, webhooks, timeout),
 		Env:         env,
 	}
+	supervisorOnly := middleware.RequireSupervisorRole(rrr)
+	planningOfficeOnly := middleware.RequireRole(domain.RolePlanningOffice)
+
 	group.POST("/summit/plan", sc.CreatePlan)
+	group.POST("/plans/import", sc.ImportPlans)
 	group.GET("/plans/title", sc.GetPlanTitlesByOwnerName)
+	group.GET("/plans/cascade", sc.GetCascade)
 	group.GET("/filter", sc.GetPlansByStatusAndOwner)
 	group.PUT("/update/plan/:plan_id", sc.UpdatePlan)
 
 	group.POST("/report/submit", sc.SubmitReport)
 	group.GET("plan/titles", sc.GetAllPlansByUser)
 	group.GET("/report/filter", sc.GetFilteredReports)
+	group.GET("/reports/missing", sc.GetMissingReports)
 	group.PUT("/update/report/:report_id", sc.UpdateReport)
 
 	group.GET("/plan-and-report/count", sc.CountItems)
 
-	group.GET("/plans", sc.GetPlansByStatus)
-	group.GET("/reports", sc.GetReportsByStatus)
+	group.GET("/plans", supervisorOnly, sc.GetPlansByStatus)
+	group.GET("/reports", supervisorOnly, sc.GetReportsByStatus)
+	group.GET("/reports/rollup", supervisorOnly, sc.GetUnitReport)
+	group.POST("/reports/rollup/submit", supervisorOnly, sc.SubmitUnitReport)
+
+	group.POST("/plans/update-status", supervisorOnly, sc.UpdatePlanStatus)
+	group.GET("/plans/:plan_id/approvals", sc.GetApprovalChain)
+	group.POST("/reports/update-status", supervisorOnly, sc.UpdateReportStatus)
 
-	group.POST("/plans/update-status", sc.UpdatePlanStatus)
-	group.POST("/reports/update-status", sc.UpdateReportStatus)
+	group.POST("/plans/:plan_id/transitions", sc.TransitionPlan)
+	group.GET("/plans/:plan_id/transitions", sc.GetPlanTransitions)
+	group.POST("/reports/:report_id/transitions", sc.TransitionReport)
+	group.GET("/reports/:report_id/transitions", sc.GetReportTransitions)
 
-	group.POST("/announcements", sc.PublishAnnouncement)
+	group.GET("/plans/:plan_id/revisions", sc.GetPlanRevisions)
+	group.GET("/plans/:plan_id/revisions/diff", sc.DiffPlanRevisions)
+	group.GET("/reports/:report_id/revisions", sc.GetReportRevisions)
+	group.GET("/reports/:report_id/revisions/diff", sc.DiffReportRevisions)
+
+	group.POST("/announcements", planningOfficeOnly, sc.PublishAnnouncement)
 	group.GET("/announcements", sc.GetAllAnnouncements)
-	group.DELETE("/announcements/:id",sc.DeleteAnnouncement)
+	group.DELETE("/announcements/:id", planningOfficeOnly, sc.DeleteAnnouncement)
+
+	group.GET("/approval-policies", sc.GetApprovalPolicies)
+	group.PUT("/approval-policies", planningOfficeOnly, sc.SaveApprovalPolicy)
+	group.DELETE("/approval-policies/:owner_role", planningOfficeOnly, sc.DeleteApprovalPolicy)
 
-	group.POST("/user/plan-and-report", sc.GetUserPlansAndReports)
+	group.POST("/user/plan-and-report", middleware.RequireSupervisorOf(users, middleware.UserIDFromJSON("user_id"), "view"), sc.GetUserPlansAndReports)
 
 	// group.GET("/plan", sc.GetPlan)
 	// group.PUT("/plan", sc.EditPlan)
@@ -55,7 +92,6 @@ func NewPlanRouter(env *config.Env, timeout time.Duration, db database.Database,
 
 	// Register the route in your router
 	// group.GET("/plans/submissions", sc.GetSubmittedPlans)
-	// group.PATCH("/plans/:planID/approve", sc.ApprovePlan)
 
 	// Routes for comment-related operations
 	// group.POST("/plans/:planID/comments", sc.AddComment)
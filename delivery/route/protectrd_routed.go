@@ -4,8 +4,9 @@ import (
 	"plan/config"
 	"plan/database"
 	"plan/delivery/controller"
+	"plan/delivery/middleware"
 
-	// "plan/domain"
+	"plan/domain"
 	"plan/repository"
 	"plan/usecase"
 	"time"
@@ -15,22 +16,31 @@ import (
 
 // Setup sets up the routes for the application
 
-func NewProtectedRouter(env *config.Env, timeout time.Duration, db database.Database, group *gin.RouterGroup) {
-	ur := repository.NewUserRepository(db, "Staff")
+func NewProtectedRouter(env *config.Env, timeout time.Duration, db database.Database, events domain.EventHub, group *gin.RouterGroup) {
+	ur := repository.NewUserRepository(db, domain.CollectionStaff)
+	tr := repository.NewTokenRepository(db, domain.CollectionRefreshToken)
+	rr := repository.NewRevocationRepository(db, domain.CollectionRevokedToken)
+	rrr := repository.NewRoleRuleRepository(db, domain.CollectionRoleRule)
+	or := repository.NewOutboxRepository(db, domain.CollectionOutbox)
+	tx := repository.NewTransactor(db)
+	notifier := usecase.NewNotifier(repository.NewNotificationRepository(db, domain.CollectionNotification), events)
 
 	sc := controller.SignupController{
-		SignupUsecase: usecase.NewSignupUsecase(ur, timeout),
+		SignupUsecase: usecase.NewSignupUsecase(ur, tr, rr, rrr, env, or, tx, notifier, timeout),
 		Env:           env,
 	}
 
-	// group.GET("/verify-status", sc.VerifyStatus)
-	group.GET("/users/unverified", sc.GetUnverifiedUsersByToWhom)
-	group.POST("/verify", sc.VerifyUser)
-	group.DELETE("/reject", sc.RejectUser)
-	group.GET("/users/subordinates", sc.GetSubordinateUsers)
-
-	group.POST("/users", sc.GetUserInfo)
-
-
+	supervisorOnly := middleware.RequireSupervisorRole(rrr)
+	bodyUserID := middleware.UserIDFromJSON("user_id")
 
+	// group.GET("/verify-status", sc.VerifyStatus)
+	group.GET("/users/unverified", supervisorOnly, sc.GetUnverifiedUsers)
+	group.POST("/verify", middleware.RequireSupervisorOf(ur, bodyUserID, "verify"), sc.VerifyUser)
+	group.DELETE("/reject", middleware.RequireSupervisorOf(ur, bodyUserID, "reject"), sc.RejectUser)
+	group.GET("/users/subordinates", supervisorOnly, sc.GetSubordinateUsers)
+	group.PUT("/users/role", middleware.RequireRole(domain.RolePlanningOffice), sc.UpdateUserRole)
+	group.POST("/logout", sc.Logout)
+	group.PUT("/users/preferences/calendar", sc.UpdateCalendarPreference)
+
+	group.POST("/users", middleware.RequireSupervisorOf(ur, bodyUserID, "view"), sc.GetUserInfo)
 }
@@ -3,6 +3,8 @@ package route
 import (
 	"plan/config"
 	"plan/database"
+	"plan/domain"
+	"plan/repository"
 
 	"plan/delivery/middleware"
 	"time"
@@ -11,16 +13,35 @@ import (
 	// "github.com/google/generative-ai-go/genai"
 )
 
-func Setup(env *config.Env, timeout time.Duration, db database.Database, gin *gin.Engine) {
+func Setup(env *config.Env, timeout time.Duration, db database.Database, events domain.EventHub, gin *gin.Engine) {
 	publicRouter := gin.Group("")
-	NewSignupRouter(env, timeout, db, publicRouter)
+	NewSignupRouter(env, timeout, db, events, publicRouter)
 
 	protectedRouter := gin.Group("")
-	protectedRouter.Use(middleware.AuthMidd)
+	rr := repository.NewRevocationRepository(db, domain.CollectionRevokedToken)
+	protectedRouter.Use(middleware.AuthMidd(env.AccessTokenSecret, rr), middleware.Calendar())
 
 	
-	NewProtectedRouter(env, timeout, db, protectedRouter)
+	NewProtectedRouter(env, timeout, db, events, protectedRouter)
 
-	NewPlanRouter(env, timeout, db, protectedRouter)
+	NewPlanRouter(env, timeout, db, events, protectedRouter)
+
+	NewOrgRouter(env, timeout, db, protectedRouter)
+
+	NewPillarRouter(env, timeout, db, protectedRouter)
+
+	NewFiscalRouter(env, timeout, db, protectedRouter)
+
+	NewDashboardRouter(env, timeout, db, protectedRouter)
+
+	NewExportRouter(env, timeout, db, protectedRouter)
+
+	NewDocumentRouter(env, timeout, db, protectedRouter)
+
+	NewOutboxRouter(env, timeout, db, protectedRouter)
+
+	NewNotificationRouter(env, timeout, db, events, protectedRouter)
+
+	NewWebhookRouter(env, timeout, db, protectedRouter)
 
 }
@@ -5,7 +5,7 @@ import (
 	"plan/database"
 	"plan/delivery/controller"
 
-	// "plan/domain"
+	"plan/domain"
 	"plan/repository"
 	"plan/usecase"
 	"time"
@@ -15,15 +15,22 @@ import (
 
 // Setup sets up the routes for the application
 
-func NewSignupRouter(env *config.Env, timeout time.Duration, db database.Database, group *gin.RouterGroup) {
-	ur := repository.NewUserRepository(db, "Staff")
+func NewSignupRouter(env *config.Env, timeout time.Duration, db database.Database, events domain.EventHub, group *gin.RouterGroup) {
+	ur := repository.NewUserRepository(db, domain.CollectionStaff)
+	tr := repository.NewTokenRepository(db, domain.CollectionRefreshToken)
+	rr := repository.NewRevocationRepository(db, domain.CollectionRevokedToken)
+	rrr := repository.NewRoleRuleRepository(db, domain.CollectionRoleRule)
+	or := repository.NewOutboxRepository(db, domain.CollectionOutbox)
+	tx := repository.NewTransactor(db)
+	notifier := usecase.NewNotifier(repository.NewNotificationRepository(db, domain.CollectionNotification), events)
 
 	sc := controller.SignupController{
-		SignupUsecase: usecase.NewSignupUsecase(ur, timeout),
+		SignupUsecase: usecase.NewSignupUsecase(ur, tr, rr, rrr, env, or, tx, notifier, timeout),
 		Env:           env,
 	}
 	group.POST("/signup", sc.Signup)
 	group.POST("/login", sc.Login)
+	group.POST("/refresh", sc.Refresh)
 
 	// group.GET("/UNVERIFIED_USERS", sc.UNVERIFIED_USERS)
 	// group.PATCH("/verify/:userID", sc.VerifyUser)
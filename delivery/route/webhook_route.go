@@ -0,0 +1,35 @@
+package route
+
+import (
+	"net/http"
+	"plan/config"
+	"plan/database"
+	"plan/delivery/controller"
+	"plan/delivery/middleware"
+	"plan/domain"
+	"plan/repository"
+	"plan/usecase"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func NewWebhookRouter(env *config.Env, timeout time.Duration, db database.Database, group *gin.RouterGroup) {
+	wr := repository.NewWebhookRepository(db, domain.CollectionWebhook)
+	dr := repository.NewWebhookDeliveryRepository(db, domain.CollectionWebhookDelivery)
+	sender := usecase.NewWebhookSender(wr, dr, &http.Client{Timeout: 10 * time.Second})
+
+	wc := controller.WebhookController{
+		WebhookUsecase: usecase.NewWebhookUsecase(wr, dr, sender, timeout),
+		Env:            env,
+	}
+	planningOfficeOnly := middleware.RequireRole(domain.RolePlanningOffice)
+
+	group.POST("/webhooks", planningOfficeOnly, wc.CreateWebhook)
+	group.GET("/webhooks", planningOfficeOnly, wc.ListWebhooks)
+	group.GET("/webhooks/:webhook_id", planningOfficeOnly, wc.GetWebhook)
+	group.PUT("/webhooks/:webhook_id", planningOfficeOnly, wc.UpdateWebhook)
+	group.DELETE("/webhooks/:webhook_id", planningOfficeOnly, wc.DeleteWebhook)
+	group.POST("/webhooks/:webhook_id/ping", planningOfficeOnly, wc.PingWebhook)
+	group.GET("/webhooks/:webhook_id/deliveries", planningOfficeOnly, wc.ListDeliveries)
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	CollectionApprovalPolicy = "approval_policies"
+
+	StepPending  = "Pending"
+	StepApproved = "Approved"
+	StepRejected = "Rejected"
+)
+
+// ApprovalStep is one level of a plan's approval chain. Steps are decided in
+// order; the plan is approved once the last one is.
+type ApprovalStep struct {
+	Level        int                `bson:"level" json:"level"` // 1 for the owner's direct supervisor
+	ApproverID   primitive.ObjectID `bson:"approver_id" json:"approver_id"`
+	ApproverName string             `bson:"approver_name" json:"approver_name"`
+	ApproverRole string             `bson:"approver_role" json:"approver_role"`
+	Status       string             `bson:"status" json:"status"` // Pending, Approved or Rejected
+	Comment      string             `bson:"comment" json:"comment"`
+	DecidedAt    *time.Time         `bson:"decided_at,omitempty" json:"decided_at,omitempty"`
+}
+
+// ApprovalPolicy sets how far up the chain of command plans owned by a role
+// must be approved. Roles without a policy only need their direct
+// supervisor's approval.
+type ApprovalPolicy struct {
+	ID                primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	OwnerRole         string             `bson:"owner_role" json:"owner_role" binding:"required"`
+	FinalApproverRole string             `bson:"final_approver_role" json:"final_approver_role" binding:"required"`
+	UpdatedAt         time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+type ApprovalPolicyRepository interface {
+	ListPolicies(ctx context.Context) ([]ApprovalPolicy, error)
+	GetPolicy(ctx context.Context, ownerRole string) (*ApprovalPolicy, error)
+	SavePolicy(ctx context.Context, policy *ApprovalPolicy) error
+	DeletePolicy(ctx context.Context, ownerRole string) error
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrInvalidParentPlan is returned when a plan is aligned to a plan it cannot
+// cascade from.
+var ErrInvalidParentPlan = errors.New("invalid parent plan")
+
+// PlanRef identifies a plan another plan can be aligned to.
+type PlanRef struct {
+	ID             primitive.ObjectID `bson:"_id" json:"plan_id"`
+	Title          string             `bson:"title" json:"title"`
+	OwnerName      string             `bson:"owner_name" json:"owner_name"`
+	AlignedPillary string             `bson:"aligned_pillary" json:"aligned_pillary"`
+}
+
+// CascadeNode is a plan in the cascade tree together with the plans aligned
+// to it.
+type CascadeNode struct {
+	ID          primitive.ObjectID `json:"plan_id"`
+	Title       string             `json:"title"`
+	OwnerID     primitive.ObjectID `json:"owner_id"`
+	OwnerName   string             `json:"owner_name"`
+	OwnerRole   string             `json:"owner_role"`
+	Status      string             `json:"status"`
+	Achievement float64            `json:"achievement"`
+	Children    []CascadeNode      `json:"children"`
+}
+
+// PillarCascade is the cascade tree of one strategic pillar.
+type PillarCascade struct {
+	Pillar string        `json:"pillar"`
+	Plans  []CascadeNode `json:"plans"`
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Dashboard summarizes the plans and reports of a user's subtree, or of the
+// whole organization for the planning office.
+type Dashboard struct {
+	Scope      string          `json:"scope"`       // subtree or organization
+	FiscalYear int             `json:"fiscal_year"` // 0 when every year is counted
+	Plans      PlanDashboard   `json:"plans"`
+	Reports    ReportDashboard `json:"reports"`
+}
+
+// PlanDashboard holds the plan statistics of a dashboard. Achievement is
+// averaged over approved and closed plans.
+type PlanDashboard struct {
+	Total              int            `json:"total"`
+	ByStatus           []StatusCount  `json:"by_status"`
+	ByQuarter          []QuarterCount `json:"by_quarter"`
+	ByPillar           []GroupStat    `json:"by_pillar"`
+	ByDepartment       []GroupStat    `json:"by_department"`
+	AverageAchievement float64        `json:"average_achievement"`
+	Overdue            int            `json:"overdue"`
+	OverduePlans       []OverduePlan  `json:"overdue_plans"` // The longest overdue ones
+	Turnaround         TurnaroundStat `json:"turnaround"`
+}
+
+// ReportDashboard holds the report statistics of a dashboard.
+type ReportDashboard struct {
+	Total      int            `json:"total"`
+	ByStatus   []StatusCount  `json:"by_status"`
+	ByQuarter  []QuarterCount `json:"by_quarter"`
+	Turnaround TurnaroundStat `json:"turnaround"`
+}
+
+type StatusCount struct {
+	Status string `bson:"status" json:"status"`
+	Count  int    `bson:"count" json:"count"`
+}
+
+type QuarterCount struct {
+	FiscalYear int `bson:"fiscal_year" json:"fiscal_year"`
+	Quarter    int `bson:"quarter" json:"quarter"`
+	Count      int `bson:"count" json:"count"`
+}
+
+// GroupStat counts the plans of a pillar or department. Plans of owners with
+// no department are grouped under a zero ID.
+type GroupStat struct {
+	ID                 primitive.ObjectID `bson:"_id" json:"id"`
+	Name               string             `bson:"name" json:"name"`
+	Plans              int                `bson:"plans" json:"plans"`
+	AverageAchievement float64            `bson:"average_achievement" json:"average_achievement"`
+}
+
+// OverduePlan is an approved plan past its end date that has not reached its
+// target.
+type OverduePlan struct {
+	ID          primitive.ObjectID `bson:"_id" json:"plan_id"`
+	Title       string             `bson:"title" json:"title"`
+	OwnerID     primitive.ObjectID `bson:"owner_id" json:"owner_id"`
+	OwnerName   string             `bson:"owner_name" json:"owner_name"`
+	EndDate     time.Time          `bson:"end_date" json:"end_date"`
+	Achievement float64            `bson:"achievement" json:"achievement"`
+}
+
+// TurnaroundStat measures, in hours, how long approved documents took from
+// their first submission to their approval.
+type TurnaroundStat struct {
+	Approved     int     `bson:"approved" json:"approved"`
+	AverageHours float64 `bson:"average_hours" json:"average_hours"`
+	MinHours     float64 `bson:"min_hours" json:"min_hours"`
+	MaxHours     float64 `bson:"max_hours" json:"max_hours"`
+}
+
+type DashboardUsecase interface {
+	GetDashboard(c context.Context, claims *JwtCustomClaims, fiscalYear int) (*Dashboard, error)
+}
@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotApproved is returned when a document is printed before it is
+// approved.
+var ErrNotApproved = errors.New("document is not approved")
+
+// PDFFile is a rendered document.
+type PDFFile struct {
+	Filename string
+	Content  []byte
+}
+
+type DocumentUsecase interface {
+	PlanPDF(c context.Context, claims *JwtCustomClaims, planID string, calendar string) (*PDFFile, error)
+	ReportPDF(c context.Context, claims *JwtCustomClaims, reportID string, calendar string) (*PDFFile, error)
+}
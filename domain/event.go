@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const CollectionEvent = "events"
+
+// Event types pushed to connected users.
+const (
+	EventNotification = "notification" // Data is the new Notification
+)
+
+// Event is a message pushed to one user over the event stream. Data is the
+// JSON payload of the event's type.
+type Event struct {
+	ID          primitive.ObjectID `bson:"_id" json:"id"`
+	Type        string             `bson:"type" json:"type"`
+	RecipientID primitive.ObjectID `bson:"recipient_id" json:"-"`
+	Data        json.RawMessage    `bson:"data" json:"data"`
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// NewEvent returns an event of the type for the recipient, with v as its
+// payload.
+func NewEvent(eventType string, recipientID primitive.ObjectID, v interface{}) (Event, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return Event{}, err
+	}
+	return Event{
+		ID:          primitive.NewObjectID(),
+		Type:        eventType,
+		RecipientID: recipientID,
+		Data:        data,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// EventHub passes events from publishers to the subscribers of their
+// recipients. Events are not stored for later: users who are not connected
+// miss them.
+type EventHub interface {
+	Publish(ctx context.Context, events ...Event) error
+	// Subscribe returns the channel the user's events arrive on and a
+	// function that ends the subscription and closes the channel.
+	Subscribe(userID primitive.ObjectID) (<-chan Event, func())
+}
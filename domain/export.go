@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrInvalidExport is returned for an export with an unknown format or
+// filter.
+var ErrInvalidExport = errors.New("invalid export")
+
+// ExportFilter picks the plans or reports of an export. Zero fields match
+// everything.
+type ExportFilter struct {
+	Status       string
+	FiscalYear   int
+	Quarter      int
+	OwnerID      *primitive.ObjectID
+	DepartmentID *primitive.ObjectID  // Owners in the department or any unit below it
+	OwnerIDs     []primitive.ObjectID // Owners in the department, narrowed to OwnerID; set by the usecase
+}
+
+// ExportRequest asks for plans or reports to be written to W as a CSV or XLSX
+// table. Dates are written in Calendar.
+type ExportRequest struct {
+	Filter   ExportFilter
+	Format   string
+	Calendar string
+	W        io.Writer
+}
+
+type ExportUsecase interface {
+	ExportPlans(c context.Context, request *ExportRequest) error
+	ExportReports(c context.Context, request *ExportRequest) error
+}
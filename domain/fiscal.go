@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const CollectionFiscalYear = "fiscal_years"
+
+var (
+	// ErrInvalidPeriod is returned for dates or periods the fiscal calendar
+	// does not cover.
+	ErrInvalidPeriod = errors.New("invalid period")
+	// ErrClosedPeriod is returned for submissions into a closed period.
+	ErrClosedPeriod = errors.New("period is closed")
+)
+
+// Period is a reporting period of a fiscal year. It covers StartDate up to,
+// but not including, EndDate. Only open periods accept submissions.
+type Period struct {
+	Quarter   int       `bson:"quarter" json:"quarter"`
+	Name      string    `bson:"name" json:"name"` // Q1 to Q4
+	StartDate time.Time `bson:"start_date" json:"start_date"`
+	EndDate   time.Time `bson:"end_date" json:"end_date"`
+	Open      bool      `bson:"open" json:"open"`
+}
+
+// FiscalYear is a fiscal year of the calendar and its periods. Year is the
+// label of the year: usually the Gregorian year it starts in, or for an
+// Ethiopian fiscal year the Ethiopian year it ends in.
+type FiscalYear struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Year      int                `bson:"year" json:"year" binding:"required"`
+	Calendar  string             `bson:"calendar" json:"calendar"` // Ethiopian years start on Hamle 1
+	StartDate time.Time          `bson:"start_date" json:"start_date"`
+	EndDate   time.Time          `bson:"end_date" json:"end_date"`
+	Periods   []Period           `bson:"periods" json:"periods"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// GenerateQuarters splits a year starting at start into four open quarters of
+// three months each.
+func GenerateQuarters(start time.Time) []Period {
+	periods := make([]Period, 4)
+	for i := range periods {
+		periods[i] = Period{
+			Quarter:   i + 1,
+			Name:      fmt.Sprintf("Q%d", i+1),
+			StartDate: start.AddDate(0, 3*i, 0),
+			EndDate:   start.AddDate(0, 3*(i+1), 0),
+			Open:      true,
+		}
+	}
+	return periods
+}
+
+// Normalize numbers and names the periods and checks that they follow each
+// other without gaps from StartDate. EndDate is set to the end of the last
+// period.
+func (fy *FiscalYear) Normalize() error {
+	if fy.StartDate.IsZero() {
+		return fmt.Errorf("%w: start date is required", ErrInvalidPeriod)
+	}
+	if len(fy.Periods) == 0 {
+		fy.Periods = GenerateQuarters(fy.StartDate)
+	}
+
+	next := fy.StartDate
+	for i := range fy.Periods {
+		period := &fy.Periods[i]
+		period.Quarter = i + 1
+		if period.Name == "" {
+			period.Name = fmt.Sprintf("Q%d", i+1)
+		}
+		if !period.StartDate.Equal(next) {
+			return fmt.Errorf("%w: %s must start on %s", ErrInvalidPeriod, period.Name, next.Format("2006-01-02"))
+		}
+		if !period.EndDate.After(period.StartDate) {
+			return fmt.Errorf("%w: %s ends before it starts", ErrInvalidPeriod, period.Name)
+		}
+		next = period.EndDate
+	}
+
+	fy.EndDate = next
+	return nil
+}
+
+// PeriodAt returns the period covering t.
+func (fy *FiscalYear) PeriodAt(t time.Time) (*Period, bool) {
+	for i := range fy.Periods {
+		period := &fy.Periods[i]
+		if !t.Before(period.StartDate) && t.Before(period.EndDate) {
+			return period, true
+		}
+	}
+	return nil, false
+}
+
+// Period returns the period with the given quarter number.
+func (fy *FiscalYear) Period(quarter int) (*Period, bool) {
+	if quarter < 1 || quarter > len(fy.Periods) {
+		return nil, false
+	}
+	return &fy.Periods[quarter-1], true
+}
+
+type FiscalYearRepository interface {
+	CreateFiscalYear(ctx context.Context, year *FiscalYear) error
+	UpdateFiscalYear(ctx context.Context, year *FiscalYear) error
+	DeleteFiscalYear(ctx context.Context, year int) error
+	GetFiscalYear(ctx context.Context, year int) (*FiscalYear, error)
+	FindByDate(ctx context.Context, date time.Time) (*FiscalYear, error)
+	FindOverlapping(ctx context.Context, start, end time.Time) ([]FiscalYear, error)
+	ListFiscalYears(ctx context.Context) ([]FiscalYear, error)
+	SetPeriodOpen(ctx context.Context, year, quarter int, open bool) error
+}
+
+type FiscalUsecase interface {
+	ListFiscalYears(c context.Context) ([]FiscalYear, error)
+	GetFiscalYear(c context.Context, year int) (*FiscalYear, error)
+	GetCurrentPeriod(c context.Context) (*FiscalYear, *Period, error)
+	CreateFiscalYear(c context.Context, year *FiscalYear) error
+	UpdateFiscalYear(c context.Context, year int, fiscalYear *FiscalYear) error
+	DeleteFiscalYear(c context.Context, year int) error
+	SetPeriodOpen(c context.Context, year, quarter int, open bool) error
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestFiscalYearNormalize(t *testing.T) {
+	fy := FiscalYear{Year: 2024, StartDate: date("2024-07-01")}
+	if err := fy.Normalize(); err != nil {
+		t.Fatal(err)
+	}
+	if len(fy.Periods) != 4 {
+		t.Fatalf("got %d periods, want 4", len(fy.Periods))
+	}
+	if !fy.EndDate.Equal(date("2025-07-01")) {
+		t.Errorf("EndDate = %s, want 2025-07-01", fy.EndDate)
+	}
+
+	gap := FiscalYear{StartDate: date("2024-07-01"), Periods: []Period{
+		{StartDate: date("2024-07-01"), EndDate: date("2024-10-01")},
+		{StartDate: date("2024-10-02"), EndDate: date("2025-01-01")},
+	}}
+	if err := gap.Normalize(); !errors.Is(err, ErrInvalidPeriod) {
+		t.Errorf("Normalize() with a gap error = %v, want ErrInvalidPeriod", err)
+	}
+}
+
+func TestFiscalYearPeriodAt(t *testing.T) {
+	fy := FiscalYear{StartDate: date("2024-07-01")}
+	if err := fy.Normalize(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		at      string
+		quarter int
+		ok      bool
+	}{
+		{"2024-07-01", 1, true},
+		{"2024-09-30", 1, true},
+		{"2024-10-01", 2, true}, // periods end before their end date
+		{"2025-06-30", 4, true},
+		{"2024-06-30", 0, false},
+		{"2025-07-01", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.at, func(t *testing.T) {
+			period, ok := fy.PeriodAt(date(tt.at))
+			if ok != tt.ok {
+				t.Fatalf("PeriodAt(%s) ok = %v, want %v", tt.at, ok, tt.ok)
+			}
+			if ok && period.Quarter != tt.quarter {
+				t.Errorf("PeriodAt(%s) = Q%d, want Q%d", tt.at, period.Quarter, tt.quarter)
+			}
+		})
+	}
+}
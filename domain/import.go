@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"errors"
+	"io"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrInvalidImport is returned for an import file that cannot be read as a
+// table of plans.
+var ErrInvalidImport = errors.New("invalid import")
+
+// MaxImportRows caps the plans of one import.
+const MaxImportRows = 1000
+
+// ImportRequest asks for the plans in R, a CSV or XLSX table, to be created
+// for the caller. Dates in the table are in Calendar.
+type ImportRequest struct {
+	Format   string
+	Calendar string
+	DryRun   bool // Only validate the rows
+	Draft    bool // Create the plans as drafts instead of submitting them
+	R        io.Reader
+}
+
+// ImportResult reports on an import. Plans are only created when every row
+// is valid, all at once.
+type ImportResult struct {
+	DryRun  bool                 `json:"dry_run"`
+	Rows    int                  `json:"rows"`
+	Valid   int                  `json:"valid"`
+	Created int                  `json:"created"`
+	PlanIDs []primitive.ObjectID `json:"plan_ids"`
+	Errors  []ImportRowError     `json:"errors"`
+}
+
+// ImportRowError is a problem with one row of an import. Row counts from 1
+// at the header, as spreadsheets do.
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
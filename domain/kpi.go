@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	DirectionIncrease = "increase"
+	DirectionDecrease = "decrease"
+)
+
+// ErrInvalidKPI is returned for a plan whose KPI cannot be measured.
+var ErrInvalidKPI = errors.New("invalid KPI")
+
+// Normalize defaults the direction to increase and rejects unknown ones, as
+// well as targets that lie behind the baseline for the direction.
+func (q *Quantify) Normalize() error {
+	switch q.Direction {
+	case "":
+		q.Direction = DirectionIncrease
+	case DirectionIncrease, DirectionDecrease:
+	default:
+		return fmt.Errorf("%w: unknown direction %q", ErrInvalidKPI, q.Direction)
+	}
+
+	if (q.Direction == DirectionIncrease && q.Target < q.Baseline) || (q.Direction == DirectionDecrease && q.Target > q.Baseline) {
+		return fmt.Errorf("%w: target lies behind the baseline", ErrInvalidKPI)
+	}
+	return nil
+}
+
+// UpdateAchievement recomputes Achievement from Baseline, Target and Actual.
+// It is 0 at the baseline and 100 at the target, may exceed 100 when the
+// target is overshot and never goes below 0.
+func (q *Quantify) UpdateAchievement() {
+	span := q.Target - q.Baseline
+	progress := q.Actual - q.Baseline
+	if q.Direction == DirectionDecrease {
+		span, progress = -span, -progress
+	}
+
+	switch {
+	case span > 0:
+		q.Achievement = progress / span * 100
+	case progress >= 0:
+		// The target equals the baseline: holding the line counts as met.
+		q.Achievement = 100
+	default:
+		q.Achievement = 0
+	}
+
+	if q.Achievement < 0 {
+		q.Achievement = 0
+	}
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestQuantifyNormalize(t *testing.T) {
+	tests := []struct {
+		name      string
+		quantify  Quantify
+		direction string
+		wantErr   bool
+	}{
+		{"defaults to increase", Quantify{Baseline: 10, Target: 20}, DirectionIncrease, false},
+		{"decrease", Quantify{Direction: DirectionDecrease, Baseline: 20, Target: 10}, DirectionDecrease, false},
+		{"target equals baseline", Quantify{Baseline: 10, Target: 10}, DirectionIncrease, false},
+		{"unknown direction", Quantify{Direction: "sideways"}, "sideways", true},
+		{"increase target behind baseline", Quantify{Baseline: 20, Target: 10}, DirectionIncrease, true},
+		{"decrease target behind baseline", Quantify{Direction: DirectionDecrease, Baseline: 10, Target: 20}, DirectionDecrease, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.quantify.Normalize()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Normalize() error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidKPI) {
+				t.Errorf("Normalize() error = %v, want ErrInvalidKPI", err)
+			}
+			if tt.quantify.Direction != tt.direction {
+				t.Errorf("Direction = %q, want %q", tt.quantify.Direction, tt.direction)
+			}
+		})
+	}
+}
+
+func TestQuantifyUpdateAchievement(t *testing.T) {
+	tests := []struct {
+		name     string
+		quantify Quantify
+		want     float64
+	}{
+		{"at baseline", Quantify{Direction: DirectionIncrease, Baseline: 10, Target: 20, Actual: 10}, 0},
+		{"halfway", Quantify{Direction: DirectionIncrease, Baseline: 10, Target: 20, Actual: 15}, 50},
+		{"at target", Quantify{Direction: DirectionIncrease, Baseline: 10, Target: 20, Actual: 20}, 100},
+		{"overshot", Quantify{Direction: DirectionIncrease, Baseline: 10, Target: 20, Actual: 25}, 150},
+		{"behind baseline", Quantify{Direction: DirectionIncrease, Baseline: 10, Target: 20, Actual: 5}, 0},
+		{"decrease halfway", Quantify{Direction: DirectionDecrease, Baseline: 20, Target: 10, Actual: 15}, 50},
+		{"decrease overshot", Quantify{Direction: DirectionDecrease, Baseline: 20, Target: 10, Actual: 5}, 150},
+		{"decrease behind baseline", Quantify{Direction: DirectionDecrease, Baseline: 20, Target: 10, Actual: 25}, 0},
+		{"flat target held", Quantify{Direction: DirectionIncrease, Baseline: 10, Target: 10, Actual: 12}, 100},
+		{"flat target missed", Quantify{Direction: DirectionIncrease, Baseline: 10, Target: 10, Actual: 8}, 0},
+		{"flat decrease target held", Quantify{Direction: DirectionDecrease, Baseline: 10, Target: 10, Actual: 8}, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.quantify.UpdateAchievement()
+			if tt.quantify.Achievement != tt.want {
+				t.Errorf("Achievement = %v, want %v", tt.quantify.Achievement, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const CollectionTransition = "transitions"
+
+// Lifecycle states shared by plans and reports.
+const (
+	StatusDraft             = "Draft"
+	StatusSubmitted         = "Submitted"
+	StatusUnderReview       = "UnderReview"
+	StatusRevisionRequested = "RevisionRequested"
+	StatusApproved          = "Approved"
+	StatusClosed            = "Closed"
+	StatusArchived          = "Archived"
+)
+
+// ErrInvalidTransition is returned when a document cannot move from its
+// current status to the requested one.
+var ErrInvalidTransition = errors.New("invalid status transition")
+
+// transitions lists the states each state may move to.
+var transitions = map[string][]string{
+	StatusDraft:             {StatusSubmitted, StatusArchived},
+	StatusSubmitted:         {StatusUnderReview, StatusApproved, StatusRevisionRequested, StatusDraft},
+	StatusUnderReview:       {StatusApproved, StatusRevisionRequested},
+	StatusRevisionRequested: {StatusSubmitted, StatusDraft},
+	StatusApproved:          {StatusClosed, StatusArchived},
+	StatusClosed:            {StatusArchived},
+	StatusArchived:          {},
+}
+
+// PendingStatuses are the states in which a document waits on a reviewer.
+var PendingStatuses = []string{StatusSubmitted, StatusUnderReview}
+
+// StatusPending is the status older clients filter on. It stands for all of
+// PendingStatuses.
+const StatusPending = "Pending"
+
+// IsPending reports whether status is one of PendingStatuses or StatusPending.
+func IsPending(status string) bool {
+	if status == StatusPending {
+		return true
+	}
+	for _, pending := range PendingStatuses {
+		if status == pending {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidStatus reports whether status is a lifecycle state.
+func IsValidStatus(status string) bool {
+	_, ok := transitions[status]
+	return ok
+}
+
+// IsEditable reports whether the owner may still change a document's content.
+func IsEditable(status string) bool {
+	return status == StatusDraft || status == StatusRevisionRequested
+}
+
+// ValidateTransition returns an error wrapping ErrInvalidTransition unless
+// from may move to to.
+func ValidateTransition(from, to string) error {
+	for _, next := range transitions[from] {
+		if next == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s to %s", ErrInvalidTransition, from, to)
+}
+
+// DecisionStatus maps a reviewer's decision to the state it leads to.
+// "Rejected" is still accepted from older clients and requests a revision.
+func DecisionStatus(decision string) (string, error) {
+	switch decision {
+	case StatusApproved:
+		return StatusApproved, nil
+	case StatusRevisionRequested, "Rejected":
+		return StatusRevisionRequested, nil
+	}
+	return "", errors.New("invalid status")
+}
+
+// Transition is one entry of a plan's or report's status history.
+type Transition struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	DocumentID   primitive.ObjectID `bson:"document_id" json:"document_id"`
+	DocumentType string             `bson:"document_type" json:"document_type"` // plan or report
+	From         string             `bson:"from" json:"from"`                   // Empty when the document was created
+	To           string             `bson:"to" json:"to"`
+	ActorID      primitive.ObjectID `bson:"actor_id" json:"actor_id"`
+	Comment      string             `bson:"comment" json:"comment"`
+	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// TransitionRequest asks for a document to be moved to another state.
+type TransitionRequest struct {
+	To      string `json:"to" binding:"required"`
+	Comment string `json:"comment"`
+}
+
+type TransitionRepository interface {
+	LogTransition(ctx context.Context, transition *Transition) error
+	FindByDocument(ctx context.Context, documentID primitive.ObjectID) ([]Transition, error)
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "context"
+
+// Mail events. Each event has its own subject, text and HTML templates.
+const (
+	MailAccountPending  = "account_pending"
+	MailAccountApproved = "account_approved"
+	MailAccountRejected = "account_rejected"
+)
+
+// Mail is an email to send for an event. Data fills the templates of the
+// event.
+type Mail struct {
+	Event string                 `bson:"event" json:"event"`
+	To    []string               `bson:"to" json:"to"`
+	Data  map[string]interface{} `bson:"data" json:"data"`
+}
+
+// Mailer renders mail from the templates of its event and sends it.
+type Mailer interface {
+	Send(ctx context.Context, mail *Mail) error
+}
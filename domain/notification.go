@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const CollectionNotification = "notifications"
+
+// Notification types.
+const (
+	NotifyPlanSubmitted            = "plan_submitted" // A plan is waiting on the recipient's approval
+	NotifyPlanApproved             = "plan_approved"
+	NotifyPlanRejected             = "plan_rejected"    // Sent back for revision
+	NotifyReportSubmitted          = "report_submitted" // A report is waiting on the recipient's approval
+	NotifyReportApproved           = "report_approved"
+	NotifyReportRejected           = "report_rejected" // Sent back for revision
+	NotifyReportDue                = "report_due"
+	NotifyUserAwaitingVerification = "user_awaiting_verification"
+	NotifyAnnouncementPublished    = "announcement_published"
+)
+
+var ErrNotificationNotFound = errors.New("notification not found")
+
+// Notification tells one recipient about an event. SubjectType and SubjectID
+// point to what the event is about: a plan, report, user or announcement.
+// Notifications with a Key are recorded at most once per recipient and key.
+type Notification struct {
+	ID          primitive.ObjectID  `bson:"_id" json:"id"`
+	RecipientID primitive.ObjectID  `bson:"recipient_id" json:"recipient_id"`
+	Type        string              `bson:"type" json:"type"`
+	Title       string              `bson:"title" json:"title"`
+	Message     string              `bson:"message" json:"message"`
+	SubjectType string              `bson:"subject_type,omitempty" json:"subject_type,omitempty"`
+	SubjectID   *primitive.ObjectID `bson:"subject_id,omitempty" json:"subject_id,omitempty"`
+	ActorID     *primitive.ObjectID `bson:"actor_id,omitempty" json:"actor_id,omitempty"`
+	Key         string              `bson:"key,omitempty" json:"-"`
+	Read        bool                `bson:"read" json:"read"`
+	ReadAt      *time.Time          `bson:"read_at,omitempty" json:"read_at,omitempty"`
+	CreatedAt   time.Time           `bson:"created_at" json:"created_at"`
+}
+
+type NotificationRepository interface {
+	// Create records the notifications, skipping keyed ones the recipient
+	// already has, and returns those it recorded.
+	Create(ctx context.Context, notifications []Notification) ([]Notification, error)
+	List(ctx context.Context, recipientID primitive.ObjectID, unreadOnly bool, limit int64) ([]Notification, error)
+	CountUnread(ctx context.Context, recipientID primitive.ObjectID) (int64, error)
+	SetRead(ctx context.Context, recipientID, id primitive.ObjectID, read bool, at time.Time) error
+	MarkAllRead(ctx context.Context, recipientID primitive.ObjectID, at time.Time) (int64, error)
+}
+
+// Notifier records notifications for the events of the other usecases.
+type Notifier interface {
+	Notify(ctx context.Context, notifications ...Notification) error
+}
+
+type NotificationUsecase interface {
+	ListNotifications(c context.Context, claims *JwtCustomClaims, unreadOnly bool, limit int64) ([]Notification, error)
+	CountUnread(c context.Context, claims *JwtCustomClaims) (int64, error)
+	MarkRead(c context.Context, claims *JwtCustomClaims, id string, read bool) error
+	MarkAllRead(c context.Context, claims *JwtCustomClaims) (int64, error)
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	CollectionOrgUnit  = "org_units"
+	CollectionRoleRule = "role_hierarchy"
+
+	OrgUnitDepartment = "department"
+	OrgUnitTeam       = "team"
+	OrgUnitOffice     = "office"
+)
+
+// OrgUnit is a department, team or office of the organization. Units form a
+// tree through ParentID.
+type OrgUnit struct {
+	ID        primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	Name      string              `bson:"name" json:"name" binding:"required"`
+	Kind      string              `bson:"kind" json:"kind" binding:"required"` // department, team or office
+	ParentID  *primitive.ObjectID `bson:"parent_id,omitempty" json:"parent_id,omitempty"`
+	HeadID    *primitive.ObjectID `bson:"head_id,omitempty" json:"head_id,omitempty"` // User leading the unit
+	CreatedAt time.Time           `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time           `bson:"updated_at" json:"updated_at"`
+}
+
+// OrgUnitNode is an OrgUnit with its members and sub-units, as returned by the
+// tree endpoint.
+type OrgUnitNode struct {
+	OrgUnit
+	Members  []User         `json:"members"`
+	Children []*OrgUnitNode `json:"children"`
+}
+
+// RoleRule states which role a role reports to. The top of the hierarchy has
+// an empty SuperiorRole.
+type RoleRule struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Role         string             `bson:"role" json:"role" binding:"required"`
+	SuperiorRole string             `bson:"superior_role" json:"superior_role"`
+	Rank         int                `bson:"rank" json:"rank"` // Lower ranks sit lower in the hierarchy
+}
+
+// DefaultRoleRules is the built-in role hierarchy, stored at startup while no
+// rules are.
+var DefaultRoleRules = []RoleRule{
+	{Role: RoleStaff, SuperiorRole: RoleTeamLead, Rank: 0},
+	{Role: RoleTeamLead, SuperiorRole: RoleDirector, Rank: 1},
+	{Role: RoleDirector, SuperiorRole: RoleVicePresident, Rank: 2},
+	{Role: RoleVicePresident, SuperiorRole: RolePlanningOffice, Rank: 3},
+	{Role: RolePlanningOffice, SuperiorRole: "", Rank: 4},
+}
+
+// Subordinate is a user below another one in the chain of command. Depth is 0
+// for direct reports.
+type Subordinate struct {
+	User  `bson:",inline"`
+	Depth int `bson:"depth" json:"depth"`
+}
+
+type OrgUnitRepository interface {
+	CreateUnit(ctx context.Context, unit *OrgUnit) error
+	UpdateUnit(ctx context.Context, unit *OrgUnit) error
+	DeleteUnit(ctx context.Context, unitID primitive.ObjectID) error
+	GetUnitByID(ctx context.Context, unitID primitive.ObjectID) (*OrgUnit, error)
+	ListUnits(ctx context.Context) ([]OrgUnit, error)
+	CountChildren(ctx context.Context, unitID primitive.ObjectID) (int64, error)
+}
+
+type RoleRuleRepository interface {
+	ListRules(ctx context.Context) ([]RoleRule, error)
+	GetRule(ctx context.Context, role string) (*RoleRule, error)
+	FindSubordinateRoles(ctx context.Context, role string) ([]RoleRule, error)
+	SaveRule(ctx context.Context, rule *RoleRule) error
+	SeedRules(ctx context.Context, rules []RoleRule) (int, error)
+	DeleteRule(ctx context.Context, role string) error
+}
+
+type OrgUsecase interface {
+	GetTree(c context.Context) ([]*OrgUnitNode, error)
+	GetChainOfCommand(c context.Context, claims *JwtCustomClaims, userID string) ([]User, error)
+	GetSubordinates(c context.Context, claims *JwtCustomClaims, userID string) ([]Subordinate, error)
+	CreateUnit(c context.Context, unit *OrgUnit) error
+	UpdateUnit(c context.Context, unitID string, unit *OrgUnit) error
+	DeleteUnit(c context.Context, unitID string) error
+	AssignUser(c context.Context, userID string, unitID string) error
+	ListRoleRules(c context.Context) ([]RoleRule, error)
+	SaveRoleRule(c context.Context, rule *RoleRule) error
+	DeleteRoleRule(c context.Context, role string) error
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const CollectionOutbox = "outbox"
+
+// Outbox statuses. A pending message is delivered once its next attempt is
+// due; a dead one failed every attempt and waits for an admin to retry it.
+const (
+	OutboxPending = "pending"
+	OutboxSent    = "sent"
+	OutboxDead    = "dead"
+)
+
+// Kinds of outbox messages.
+const (
+	OutboxMail    = "mail"
+	OutboxWebhook = "webhook"
+)
+
+// OutboxMaxAttempts is the number of deliveries tried before a message is
+// dead-lettered.
+const OutboxMaxAttempts = 8
+
+var (
+	ErrOutboxNotFound = errors.New("outbox message not found")
+	ErrOutboxNotDead  = errors.New("only dead outbox messages can be retried")
+)
+
+// OutboxMessage is a notification saved with the change it reports, in the
+// same transaction, and delivered afterwards by the outbox worker.
+type OutboxMessage struct {
+	ID            primitive.ObjectID `bson:"_id" json:"id"`
+	Kind          string             `bson:"kind" json:"kind"`
+	Mail          *Mail              `bson:"mail,omitempty" json:"mail,omitempty"`
+	Webhook       *WebhookMessage    `bson:"webhook,omitempty" json:"webhook,omitempty"`
+	Status        string             `bson:"status" json:"status"`
+	Attempts      int                `bson:"attempts" json:"attempts"`
+	NextAttemptAt time.Time          `bson:"next_attempt_at" json:"next_attempt_at"`
+	LastError     string             `bson:"last_error,omitempty" json:"last_error,omitempty"`
+	CreatedAt     time.Time          `bson:"created_at" json:"created_at"`
+	SentAt        *time.Time         `bson:"sent_at,omitempty" json:"sent_at,omitempty"`
+}
+
+type OutboxRepository interface {
+	Enqueue(ctx context.Context, message *OutboxMessage) error
+	// ClaimDue takes the oldest due pending message, counts the attempt and
+	// hides it from other workers until the lease ends. It returns nil when
+	// nothing is due.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*OutboxMessage, error)
+	MarkSent(ctx context.Context, id primitive.ObjectID, sentAt time.Time) error
+	// MarkFailed records a failed attempt, and either schedules the next one
+	// or, when next is nil, dead-letters the message.
+	MarkFailed(ctx context.Context, id primitive.ObjectID, lastError string, next *time.Time) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*OutboxMessage, error)
+	List(ctx context.Context, status string, limit int64) ([]OutboxMessage, error)
+	// Requeue makes a dead message pending again, with its attempts reset.
+	Requeue(ctx context.Context, id primitive.ObjectID, now time.Time) error
+}
+
+type OutboxUsecase interface {
+	ListMessages(c context.Context, status string, limit int64) ([]OutboxMessage, error)
+	GetMessage(c context.Context, id string) (*OutboxMessage, error)
+	RetryMessage(c context.Context, id string) (*OutboxMessage, error)
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const CollectionPillar = "pillars"
+
+var (
+	// ErrInvalidPillar is returned when a plan does not reference a usable
+	// pillar of the catalog.
+	ErrInvalidPillar = errors.New("invalid pillar")
+	// ErrPillarNotFound is returned for pillars missing from the catalog.
+	ErrPillarNotFound = errors.New("pillar not found")
+	// ErrDuplicatePillar is returned for a code already used in the fiscal
+	// year.
+	ErrDuplicatePillar = errors.New("pillar code already exists for this fiscal year")
+)
+
+// Pillar is a strategic pillar or objective of a fiscal year. Top-level plans
+// reference one and the plans cascading from them inherit it.
+type Pillar struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	FiscalYear  int                `bson:"fiscal_year" json:"fiscal_year" binding:"required"`
+	Code        string             `bson:"code" json:"code" binding:"required"` // Short reference such as P1
+	Name        string             `bson:"name" json:"name" binding:"required"`
+	Description string             `bson:"description" json:"description"`
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// PillarStat summarizes the plans under a pillar. AverageAchievement is taken
+// over the approved and closed plans.
+type PillarStat struct {
+	PillarID           primitive.ObjectID `bson:"_id" json:"pillar_id"`
+	Code               string             `bson:"-" json:"code"`
+	Name               string             `bson:"-" json:"name"`
+	FiscalYear         int                `bson:"-" json:"fiscal_year"`
+	Plans              int                `bson:"plans" json:"plans"`
+	ApprovedPlans      int                `bson:"approved_plans" json:"approved_plans"`
+	AverageAchievement float64            `bson:"average_achievement" json:"average_achievement"`
+}
+
+type PillarRepository interface {
+	CreatePillar(ctx context.Context, pillar *Pillar) error
+	UpdatePillar(ctx context.Context, pillar *Pillar) error
+	DeletePillar(ctx context.Context, pillarID primitive.ObjectID) error
+	GetPillarByID(ctx context.Context, pillarID primitive.ObjectID) (*Pillar, error)
+	GetPillarByCode(ctx context.Context, fiscalYear int, code string) (*Pillar, error)
+	ListPillars(ctx context.Context, fiscalYear int) ([]Pillar, error)
+}
+
+type PillarUsecase interface {
+	ListPillars(c context.Context, fiscalYear int) ([]Pillar, error)
+	GetPillar(c context.Context, pillarID string) (*Pillar, error)
+	CreatePillar(c context.Context, pillar *Pillar) error
+	UpdatePillar(c context.Context, pillarID string, pillar *Pillar) error
+	DeletePillar(c context.Context, pillarID string) error
+	GetPillarStats(c context.Context, fiscalYear int) ([]PillarStat, error)
+}
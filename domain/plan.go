@@ -8,37 +8,52 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+const (
+	CollectionPlan         = "plans"
+	CollectionReport       = "reports"
+	CollectionAnnouncement = "announcements"
+	CollectionComment      = "comments"
+)
+
 // Quantify represents the quantifiable metrics for the plan's success
 type Quantify struct {
-	Target   string    `bson:"target" json:"target"`     // What is the target (e.g., sales target, project completion percentage)
-	Actual   string    `bson:"actual" json:"actual"`     // The actual result (can be updated over time)
-	Unit     string    `bson:"unit" json:"unit"`         // Unit of measurement (e.g., percentage, hours, count)
-	Deadline time.Time `bson:"deadline" json:"deadline"` // Target deadline for the quantifiable metric
+	Target      float64   `bson:"target" json:"target"`           // Value the plan aims for
+	Baseline    float64   `bson:"baseline" json:"baseline"`       // Value when the plan started
+	Actual      float64   `bson:"actual" json:"actual"`           // Latest approved report value, set by the system
+	Unit        string    `bson:"unit" json:"unit"`               // Unit of measurement (e.g., percentage, hours, count)
+	Direction   string    `bson:"direction" json:"direction"`     // increase or decrease
+	Achievement float64   `bson:"achievement" json:"achievement"` // Percent of the way from baseline to target, set by the system
+	Deadline    time.Time `bson:"deadline" json:"deadline"`       // Target deadline for the quantifiable metric
 }
 
 // Plan represents the structure of a plan in the system
 type Plan struct {
-	ID               primitive.ObjectID  `bson:"_id,omitempty" json:"plan_id"`                           // MongoDB Object ID
-	Title            string              `bson:"title" json:"title"`                                     // Title of the plan
-	Description      string              `bson:"description" json:"description"`                         // Description of the plan
-	Priority         string              `bson:"priority" json:"priority"`                               // Priority of the plan (e.g., High, Medium, Low)
-	OwnerName        string              `bson:"owner_name" json:"owner_name"`                           // Owner of the plan (name of the person responsible)
-	OwnerRole        string              `bson:"owner_role" json:"owner_role"`                           // Owner of the plan (name of the person responsible)
-	SupervisorName   string              `bson:"supervisor_name" json:"supervisor_name"`                 // Supervisor's name (1 level higher in hierarchy)
-	WhichQuarter     string              `bson:"which_quarter" json:"which_quarter"`                     // The quarter (e.g., Q1, Q2, etc.)
-	Quantify         Quantify            `bson:"quantify" json:"quantify"`                               // Metrics and quantifiable targets
-	CreatedBy        string              `bson:"created_by" json:"created_by"`                           // ID of the user who created the plan
-	SupervisorPlanID *primitive.ObjectID `bson:"supervisor_plan_id,omitempty" json:"supervisor_plan_id"` // Parent plan (supervisor's plan)
-	Status           string              `bson:"status" json:"status"`                                   // Status of the plan (e.g., Pending, Approved, Completed)
-	CreatedAt        time.Time           `bson:"created_at" json:"created_at"`                           // Time when the plan was created
-	UpdatedAt        time.Time           `bson:"updated_at" json:"updated_at"`
-	OwnerID          primitive.ObjectID  `bson:"owner_id" json:"owner_id"`               // ID of the user who created the plan
-	SuperiorPlan     string              `bson:"superior_plan" json:"superior_plan"`     // ID of the user who created the plan
-	AlignedPillary   string              `bson:"aligned_pillary" json:"aligned_pillary"` // ID of the user who created the plan
-	Quarter          int                 `bson:"quarter" json:"quarter"`                 // ID of the user who created the plan
-	StartDate        time.Time           `bson:"start_date" json:"start_date"`           // ID of the user who created the plan
-	EndDate          time.Time           `bson:"end_date" json:"end_date"`               // ID of the user who created the plan
-	Type             string              `bson:"type" json:"type"`
+	ID                primitive.ObjectID  `bson:"_id,omitempty" json:"plan_id"`                             // MongoDB Object ID
+	Title             string              `bson:"title" json:"title"`                                       // Title of the plan
+	Description       string              `bson:"description" json:"description"`                           // Description of the plan
+	Priority          string              `bson:"priority" json:"priority"`                                 // Priority of the plan (e.g., High, Medium, Low)
+	OwnerName         string              `bson:"owner_name" json:"owner_name"`                             // Owner of the plan (name of the person responsible)
+	OwnerRole         string              `bson:"owner_role" json:"owner_role"`                             // Owner of the plan (name of the person responsible)
+	SupervisorName    string              `bson:"supervisor_name" json:"supervisor_name"`                   // Supervisor's name (1 level higher in hierarchy)
+	SupervisorID      primitive.ObjectID  `bson:"supervisor_id,omitempty" json:"supervisor_id"`             // Supervisor's user ID
+	WhichQuarter      string              `bson:"which_quarter" json:"which_quarter"`                       // The quarter (e.g., Q1, Q2, etc.), set by the system
+	Quantify          Quantify            `bson:"quantify" json:"quantify"`                                 // Metrics and quantifiable targets
+	CreatedBy         string              `bson:"created_by" json:"created_by"`                             // ID of the user who created the plan
+	SupervisorPlanID  *primitive.ObjectID `bson:"supervisor_plan_id,omitempty" json:"supervisor_plan_id"`   // Parent plan (supervisor's plan)
+	Status            string              `bson:"status" json:"status"`                                     // Status of the plan (e.g., Pending, Approved, Completed)
+	ApprovalChain     []ApprovalStep      `bson:"approval_chain,omitempty" json:"approval_chain"`           // Approvers in order, with their decisions
+	CurrentApproverID *primitive.ObjectID `bson:"current_approver_id,omitempty" json:"current_approver_id"` // Approver the plan is waiting on
+	CreatedAt         time.Time           `bson:"created_at" json:"created_at"`                             // Time when the plan was created
+	UpdatedAt         time.Time           `bson:"updated_at" json:"updated_at"`
+	OwnerID           primitive.ObjectID  `bson:"owner_id" json:"owner_id"`               // ID of the user who created the plan
+	SuperiorPlan      string              `bson:"superior_plan" json:"superior_plan"`     // ID of the user who created the plan
+	AlignedPillary    string              `bson:"aligned_pillary" json:"aligned_pillary"` // Name of the pillar, kept in sync with the catalog
+	PillarID          *primitive.ObjectID `bson:"pillar_id,omitempty" json:"pillar_id"`   // Strategic pillar of the catalog the plan serves
+	FiscalYear        int                 `bson:"fiscal_year" json:"fiscal_year"`         // Fiscal year the plan starts in, set by the system
+	Quarter           int                 `bson:"quarter" json:"quarter"`                 // Period the plan starts in, set by the system
+	StartDate         time.Time           `bson:"start_date" json:"start_date"`           // ID of the user who created the plan
+	EndDate           time.Time           `bson:"end_date" json:"end_date"`               // ID of the user who created the plan
+	Type              string              `bson:"type" json:"type"`
 
 	Comment string  `bson:"comment" json:"comment"`
 	Value   float64 `bson:"value" json:"value"`
@@ -49,17 +64,20 @@ type Report struct {
 	ID                primitive.ObjectID `bson:"_id,omitempty" json:"report_id"`
 	ReportUserID      primitive.ObjectID `bson:"report_user_id" json:"report_user_id"`
 	ReportTitle       string             `bson:"report_title" json:"report_title"`     // Title of the plan
-	AccomplishedValue string             `bson:"acomplished_value" json:"acomplished"` // Description of the plan
+	AccomplishedValue float64            `bson:"acomplished_value" json:"acomplished"` // Value reached for the plan's KPI
 	ReportDetails     string             `bson:"report_details" json:"report_details"` // Priority of the plan (e.g., High, Medium, Low)
 	Status            string             `bson:"status" json:"status"`                 // Owner of the plan (name of the person responsible)
 	Type              string             `bson:"type" json:"type"`                     // Owner of the plan (name of the person responsible)
 
-	SupervisorName  string `bson:"supervisor_name" json:"supervisor_name"` // Supervisor's name (1 level higher in hierarchy)
-	Comment         string `bson:"comment" json:"comment"`      
-	         // ID of the user who created the plan
-// Supervisor's name (1 level higher in hierarchy)
-	Value float64 `bson:"value" json:"value"`
-	PlanID 		 primitive.ObjectID `bson:"plan_id" json:"plan_id"`
+	SupervisorName string             `bson:"supervisor_name" json:"supervisor_name"` // Supervisor's name (1 level higher in hierarchy)
+	SupervisorID   primitive.ObjectID `bson:"supervisor_id,omitempty" json:"supervisor_id"`
+	Comment        string             `bson:"comment" json:"comment"`
+	// ID of the user who created the plan
+	// Supervisor's name (1 level higher in hierarchy)
+	Value      float64            `bson:"value" json:"value"`
+	PlanID     primitive.ObjectID `bson:"plan_id" json:"plan_id"`         // Approved plan of the submitter the report is on
+	FiscalYear int                `bson:"fiscal_year" json:"fiscal_year"` // Reported period; the current one when left out
+	Quarter    int                `bson:"quarter" json:"quarter"`
 }
 
 // Comment represents a comment on a plan.
@@ -79,8 +97,26 @@ type Announcement struct {
 	Type        string             `bson:"type" json:"type"`
 }
 
+// MissingReport is a plan running in a period that has no report for it.
+type MissingReport struct {
+	PlanID     primitive.ObjectID `json:"plan_id"`
+	Title      string             `json:"title"`
+	OwnerID    primitive.ObjectID `json:"owner_id"`
+	OwnerName  string             `json:"owner_name"`
+	FiscalYear int                `json:"fiscal_year"`
+	Quarter    int                `json:"quarter"`
+	Period     string             `json:"period"`
+}
+
 // PlanResponse represents the response returned when fetching a plan.
 
 var (
-	ErrPlanNotFound = errors.New("plan not found")
+	ErrPlanNotFound   = errors.New("plan not found")
+	ErrReportNotFound = errors.New("report not found")
+	// ErrInvalidReportPlan is returned for reports on a plan the submitter
+	// cannot report on.
+	ErrInvalidReportPlan = errors.New("invalid report plan")
+	// ErrDuplicateReport is returned for a second report on a plan for the
+	// same period.
+	ErrDuplicateReport = errors.New("plan already has a report for this period")
 )
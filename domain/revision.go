@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const CollectionRevision = "revisions"
+
+// Revision is an immutable snapshot of a plan or report taken after a write.
+// Numbers start at 1 for each document. Exactly one of Plan and Report is set.
+type Revision struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	DocumentID   primitive.ObjectID `bson:"document_id" json:"document_id"`
+	DocumentType string             `bson:"document_type" json:"document_type"` // plan or report
+	Number       int                `bson:"number" json:"number"`
+	AuthorID     primitive.ObjectID `bson:"author_id" json:"author_id"` // User whose action produced the revision
+	Plan         *Plan              `bson:"plan,omitempty" json:"plan,omitempty"`
+	Report       *Report            `bson:"report,omitempty" json:"report,omitempty"`
+	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
+}
+
+type RevisionRepository interface {
+	SaveRevision(ctx context.Context, revision *Revision) error
+	FindByDocument(ctx context.Context, documentID primitive.ObjectID) ([]Revision, error)
+	GetRevision(ctx context.Context, documentID primitive.ObjectID, number int) (*Revision, error)
+}
+
+// FieldChange is a field that differs between two values. Field is the dotted
+// path of JSON names, e.g. "quantify.target".
+type FieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// RevisionDiff lists the changes from one revision of a document to another.
+type RevisionDiff struct {
+	From    int           `json:"from"`
+	To      int           `json:"to"`
+	Changes []FieldChange `json:"changes"`
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UnitReport consolidates the approved reports of everyone below a user for
+// one period.
+type UnitReport struct {
+	UserID     primitive.ObjectID `json:"user_id"`
+	FiscalYear int                `json:"fiscal_year"`
+	Quarter    int                `json:"quarter"`
+	Period     string             `json:"period"`
+	Reports    int                `json:"reports"` // Number of reports consolidated
+	KPIs       []KPIRollup        `json:"kpis"`
+	Narrative  string             `json:"narrative"` // Narratives of all KPIs, in order
+}
+
+// KPIRollup totals the reports that roll up to one plan of the user, through
+// the chain of parent plans. Reports on plans that do not reach a plan of the
+// user are grouped by unit, without a plan.
+type KPIRollup struct {
+	PlanID       *primitive.ObjectID `json:"plan_id"` // Plan of the user the reports roll up to
+	Title        string              `json:"title"`
+	Unit         string              `json:"unit"`
+	Target       float64             `json:"target"`       // Sum of the targets of the reported plans
+	Baseline     float64             `json:"baseline"`     // Sum of the baselines of the reported plans
+	Accomplished float64             `json:"accomplished"` // Sum of the reported values
+	Achievement  float64             `json:"achievement"`  // Percent achieved, from the sums or, when Mixed, the average of the sections
+	Mixed        bool                `json:"mixed"`        // The reported plans differ in unit or direction, so the sums are not comparable
+	Sections     []ReportSection     `json:"sections"`
+	Narrative    string              `json:"narrative"` // Details of the sections, concatenated
+}
+
+// ReportSection is one approved report within a roll-up.
+type ReportSection struct {
+	ReportID     primitive.ObjectID `json:"report_id"`
+	PlanID       primitive.ObjectID `json:"plan_id"`
+	PlanTitle    string             `json:"plan_title"`
+	OwnerID      primitive.ObjectID `json:"owner_id"`
+	OwnerName    string             `json:"owner_name"`
+	Unit         string             `json:"unit"`
+	Accomplished float64            `json:"accomplished"`
+	Achievement  float64            `json:"achievement"`
+	Details      string             `json:"details"`
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	CollectionRefreshToken = "refresh_tokens"
+	CollectionRevokedToken = "revoked_tokens"
+
+	AccessTokenKind  = "access"
+	RefreshTokenKind = "refresh"
+)
+
+// RefreshToken is the server-side record of an issued refresh token. Only the
+// token's jti is stored; a refresh token whose jti is missing here has either
+// been rotated already or revoked.
+type RefreshToken struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	TokenID   string             `bson:"token_id" json:"token_id"` // jti of the refresh token
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	ExpiresAt time.Time          `bson:"expires_at" json:"expires_at"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// TokenPair is returned by /login and /refresh.
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// Revocation invalidates access tokens before they expire. A record either
+// names a single token by its jti, or revokes every token issued to UserID
+// before IssuedBefore.
+type Revocation struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	TokenID      string             `bson:"token_id,omitempty" json:"token_id,omitempty"`
+	UserID       primitive.ObjectID `bson:"user_id,omitempty" json:"user_id,omitempty"`
+	IssuedBefore time.Time          `bson:"issued_before,omitempty" json:"issued_before,omitempty"`
+	ExpiresAt    time.Time          `bson:"expires_at" json:"expires_at"` // the record can be dropped after this
+	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+	All          bool   `json:"all"` // end every session of the user, not just this one
+}
@@ -0,0 +1,10 @@
+package domain
+
+import "context"
+
+// Transactor runs a function in a database transaction. Repository calls
+// made with the context passed to fn take part in the transaction, which is
+// committed when fn returns nil and aborted otherwise.
+type Transactor interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
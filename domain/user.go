@@ -7,21 +7,45 @@ import (
 type Role string
 
 const (
-	CollectionUser      = "users"
-	AdminRole      Role = "ADMIN"
-	UserRole       Role = "USER"
+	CollectionUser       = "users"
+	CollectionStaff      = "Staff"
+	AdminRole       Role = "ADMIN"
+	UserRole        Role = "USER"
 )
 
+// Roles of the planning hierarchy, from the bottom up.
+const (
+	RoleStaff          = "Staff"
+	RoleTeamLead       = "team_lead"
+	RoleDirector       = "director"
+	RoleVicePresident  = "vice_president"
+	RolePlanningOffice = "planning_office"
+)
+
+// Calendars dates can be read and written in. The application stores
+// Gregorian dates; Ethiopian ones are converted at the edge.
+const (
+	CalendarGregorian = "gregorian"
+	CalendarEthiopian = "ethiopian"
+)
+
+// IsValidCalendar reports whether calendar is one of the supported calendars.
+func IsValidCalendar(calendar string) bool {
+	return calendar == CalendarGregorian || calendar == CalendarEthiopian
+}
+
 type User struct {
-	ID              primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
-	Full_Name       string             `bson:"full_name" json:"full_name"`
-	Email           string             `bson:"email" json:"email"`
-	Password        string             `bson:"password" json:"password" `
-	Role            string             `bson:"role" json:"role"`
-	Bio             string             `bson:"bio" json:"bio"`
-	To_whom         string             `bson:"to_whom" json:"to_whom"`
-	Verify          bool               `bson:"verify" json:"verify"`
-	Profile_Picture string             `bson:"profile_picture" 
-	json:"profile_picture"`
-	Created_At      primitive.DateTime `bson:"created_at" json:"created_at"`
+	ID              primitive.ObjectID  `bson:"_id,omitempty" json:"_id,omitempty"`
+	Full_Name       string              `bson:"full_name" json:"full_name"`
+	Email           string              `bson:"email" json:"email"`
+	Password        string              `bson:"password" json:"password" `
+	Role            string              `bson:"role" json:"role"`
+	Bio             string              `bson:"bio" json:"bio"`
+	To_whom         string              `bson:"to_whom" json:"to_whom"` // Supervisor's name, kept for display
+	SupervisorID    primitive.ObjectID  `bson:"supervisor_id,omitempty" json:"supervisor_id,omitempty"`
+	OrgUnitID       *primitive.ObjectID `bson:"org_unit_id,omitempty" json:"org_unit_id,omitempty"`
+	Verify          bool                `bson:"verify" json:"verify"`
+	Profile_Picture string              `bson:"profile_picture" json:"profile_picture"`
+	Calendar        string              `bson:"calendar,omitempty" json:"calendar,omitempty"` // Preferred calendar, Gregorian when empty
+	Created_At      primitive.DateTime  `bson:"created_at" json:"created_at"`
 }
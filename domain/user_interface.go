@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
@@ -9,13 +10,16 @@ import (
 )
 
 type JwtCustomClaims struct {
-	UserID    primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
-	Full_Name string             `json:"full_name"`
-	Email     string             `json:"email"`
-	Username  string             `json:"username"`
-	Role      string             `json:"role"`
-	To_whom   string             `json:"to_whom"`
-	Status    bool               `json:"status"`
+	UserID       primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
+	Full_Name    string             `json:"full_name"`
+	Email        string             `json:"email"`
+	Username     string             `json:"username"`
+	Role         string             `json:"role"`
+	To_whom      string             `json:"to_whom"`
+	SupervisorID primitive.ObjectID `json:"supervisor_id,omitempty"`
+	Status       bool               `json:"status"`
+	Kind         string             `json:"kind"` // access or refresh
+	Calendar     string             `json:"calendar,omitempty"`
 	jwt.StandardClaims
 }
 
@@ -25,6 +29,7 @@ type AuthSignup struct {
 	Password        string             `json:"password"`
 	Role            string             `json:"role"`
 	To_whom         string             `json:"to_whom"`
+	SupervisorID    string             `json:"supervisor_id"`
 	Verify          bool               `json:"verify"`
 	Profile_Picture string             `json:"profile_picture"`
 	Full_Name       string             `json:"full_name"`
@@ -40,25 +45,50 @@ type UserRepository interface {
 	GetUserByUsername(ctx context.Context, username string) (*User, error)
 	// GetUserByID(ctx context.Context, userID string) (*User, error)
 	FindUsersByRole(c context.Context, role string) ([]User, error)
-	FindUnverifiedUsersByToWhom(ctx context.Context, firstName string) ([]User, error)
+	FindUsersByFullName(ctx context.Context, fullName string) ([]User, error)
+	FindUnverifiedUsersBySupervisor(ctx context.Context, supervisorID primitive.ObjectID) ([]User, error)
 	UpdateVerifyStatus(ctx context.Context, userID primitive.ObjectID, verify bool) error
-	FetchByToWhom(ctx context.Context, firstName string) ([]User, error)
+	FetchBySupervisor(ctx context.Context, supervisorID primitive.ObjectID) ([]User, error)
 	DeleteUser(ctx context.Context, userID primitive.ObjectID) error
 	GetUserByID(ctx context.Context, userID primitive.ObjectID) (*User, error)
-	
+	UpdateRole(ctx context.Context, userID primitive.ObjectID, role string) error
+	UpdateCalendar(ctx context.Context, userID primitive.ObjectID, calendar string) error
+	UpdateOrgUnit(ctx context.Context, userID primitive.ObjectID, unitID *primitive.ObjectID) error
+	FindUnitMembers(ctx context.Context) ([]User, error)
+	CountUsersInOrgUnit(ctx context.Context, unitID primitive.ObjectID) (int64, error)
+	GetChainOfCommand(ctx context.Context, userID primitive.ObjectID) ([]User, error)
+	GetSubordinates(ctx context.Context, userID primitive.ObjectID) ([]Subordinate, error)
+	FindVerifiedUserIDs(ctx context.Context) ([]primitive.ObjectID, error)
+}
+
+type TokenRepository interface {
+	SaveToken(ctx context.Context, token *RefreshToken) error
+	DeleteToken(ctx context.Context, tokenID string) error
+	DeleteTokensByUser(ctx context.Context, userID primitive.ObjectID) error
+}
+
+type RevocationRepository interface {
+	RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error
+	RevokeUserTokens(ctx context.Context, userID primitive.ObjectID, issuedBefore time.Time, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, claims *JwtCustomClaims) (bool, error)
 }
 
 // Role is a type for user roles
 type SignupUsecase interface {
 	RegisterUser(c context.Context, user *AuthSignup) (*primitive.ObjectID, error)
-	LoginUser(ctx context.Context, auth *AuthLogin) (string, error)
+	LoginUser(ctx context.Context, auth *AuthLogin) (*TokenPair, error)
+	RefreshTokens(ctx context.Context, refreshToken string) (*TokenPair, error)
+	Logout(ctx context.Context, claims *JwtCustomClaims, request *LogoutRequest) error
+	RevokeUserSessions(ctx context.Context, userID primitive.ObjectID) error
+	UpdateUserRole(c context.Context, userID string, role string) error
 	// GetVerificationStatus(ctx context.Context, userID string) (bool, error)
 	GetSuperiors(c context.Context, role string) ([]User, error)
-	FetchUnverifiedUsersByToWhom(c context.Context, firstName string) ([]User, error)
+	FetchUnverifiedSubordinates(c context.Context, supervisorID primitive.ObjectID) ([]User, error)
 	VerifyUser(c context.Context, userID string) error
-	GetUsersByToWhomWithCount(ctx context.Context, firstName string) ([]User, int, error)
+	GetSubordinatesWithCount(ctx context.Context, supervisorID primitive.ObjectID) ([]User, int, error)
 	RejectUser(c context.Context, userID string) error
 	FetchUserByID(c context.Context, userID primitive.ObjectID) (*User, error)
+	UpdateCalendarPreference(c context.Context, userID primitive.ObjectID, calendar string) (*TokenPair, error)
 }
 
 type PlanRepository interface {
@@ -66,57 +96,105 @@ type PlanRepository interface {
 	GetPlan(ctx context.Context, ownerID primitive.ObjectID) (*Plan, error)
 
 	// UpdatePlan(ctx context.Context, plan *Plan) error
-	GetSubmittedPlans(ctx context.Context, supervisor_name string) ([]Plan, error)
+	GetSubmittedPlans(ctx context.Context, supervisorID primitive.ObjectID) ([]Plan, error)
 	GetPlanByID(ctx context.Context, planID primitive.ObjectID) (*Plan, error)
-	// ForwardPlanToSupervisor(ctx context.Context, plan *Plan) error
 	UpdatePlanPacth(ctx context.Context, plan *Plan) error
-	CreateComment(ctx context.Context, comment *Comment) error
-	FetchSupervisorComments(ctx context.Context, userID primitive.ObjectID) ([]Comment, error)
-	FetchCommentsByPlanID(ctx context.Context, planID primitive.ObjectID) ([]Comment, error)
-	GetPlanTitlesByOwnerName(ctx context.Context, ownerName string) ([]string, error)
+	UpdateStatus(ctx context.Context, id primitive.ObjectID, from, to string) error
+	GetPlanTitlesByOwnerID(ctx context.Context, ownerID primitive.ObjectID) ([]string, error)
 	GetPlansByStatusAndOwner(ctx context.Context, userID primitive.ObjectID, status string) ([]Plan, error)
-	SubmitReport(ctx context.Context, report *Report) error
-	GetFilteredReports(ctx context.Context, userID primitive.ObjectID, status string) ([]Report, error)
 	// GetAllTitlesByUser(ctx context.Context, userID primitive.ObjectID) ([]string, error)
-	CountItems(ctx context.Context, itemType string, toWhom string) (int, error)
-	GetPlansBySupervisorAndStatus(ctx context.Context, supervisorName, status string) ([]Plan, error)
-	GetReportsBySupervisorAndStatus(ctx context.Context, supervisorName, reportStatus string) ([]Report, error)
-	UpdatePlanStatus(ctx context.Context, planID primitive.ObjectID, supervisorName, status, comment string) error
-	UpdateReportStatus(ctx context.Context, reportID primitive.ObjectID, supervisorName, status, comment string) error
-	UpdatePlan(ctx context.Context, planID primitive.ObjectID, updatedPlan *Plan) error
-	UpdateReport(ctx context.Context, reportID primitive.ObjectID, updatedReport *Report) error
+	CountAwaitingApproval(ctx context.Context, approverID primitive.ObjectID) (int, error)
+	GetPlansBySupervisorAndStatus(ctx context.Context, supervisorID primitive.ObjectID, status string) ([]Plan, error)
+	UpdateApprovalState(ctx context.Context, plan *Plan, approverID primitive.ObjectID) error
+	UpdateKPIActual(ctx context.Context, planID primitive.ObjectID, actual, achievement float64) error
+	UpdatePlan(ctx context.Context, planID primitive.ObjectID, from string, updatedPlan *Plan) error
 	GetAllPlansByUser(ctx context.Context, userID primitive.ObjectID) ([]Plan, error)
+	FindByOwnerID(ctx context.Context, ownerID primitive.ObjectID) ([]Plan, error)
+	GetAlignablePlans(ctx context.Context, ownerID primitive.ObjectID) ([]PlanRef, error)
+	FindCascade(ctx context.Context, pillarID *primitive.ObjectID, ownerIDs []primitive.ObjectID) ([]Plan, error)
+	CountByPillar(ctx context.Context, pillarID primitive.ObjectID) (int64, error)
+	RenamePillar(ctx context.Context, pillarID primitive.ObjectID, name string) error
+	GetPillarStats(ctx context.Context, pillarIDs []primitive.ObjectID) ([]PillarStat, error)
+	CountByFiscalYear(ctx context.Context, year int) (int64, error)
+	FindRunningPlans(ctx context.Context, ownerIDs []primitive.ObjectID, start, end time.Time) ([]Plan, error)
+	FindByIDs(ctx context.Context, planIDs []primitive.ObjectID) ([]Plan, error)
+	GetDashboard(ctx context.Context, ownerIDs []primitive.ObjectID, fiscalYear int, now time.Time) (*PlanDashboard, error)
+	StreamPlans(ctx context.Context, filter ExportFilter, fn func(*Plan) error) error
+}
+
+type ReportRepository interface {
+	SubmitReport(ctx context.Context, report *Report) error
+	GetReportByID(ctx context.Context, reportID primitive.ObjectID) (*Report, error)
+	GetFilteredReports(ctx context.Context, userID primitive.ObjectID, status string) ([]Report, error)
+	GetReportsBySupervisorAndStatus(ctx context.Context, supervisorID primitive.ObjectID, reportStatus string) ([]Report, error)
+	CountPending(ctx context.Context, supervisorID primitive.ObjectID) (int, error)
+	UpdateReport(ctx context.Context, reportID primitive.ObjectID, from string, updatedReport *Report) error
+	UpdateReportStatus(ctx context.Context, reportID primitive.ObjectID, supervisorID primitive.ObjectID, status, comment string) error
+	UpdateStatus(ctx context.Context, id primitive.ObjectID, from, to string) error
+	FindByUserID(ctx context.Context, userID primitive.ObjectID) ([]Report, error)
+	CountByFiscalYear(ctx context.Context, year int) (int64, error)
+	FindForPeriod(ctx context.Context, planID primitive.ObjectID, fiscalYear, quarter int) (*Report, error)
+	FindLatestApproved(ctx context.Context, planID primitive.ObjectID) (*Report, error)
+	FindReportedPlanIDs(ctx context.Context, planIDs []primitive.ObjectID, fiscalYear, quarter int) ([]primitive.ObjectID, error)
+	FindApprovedForPeriod(ctx context.Context, userIDs []primitive.ObjectID, fiscalYear, quarter int) ([]Report, error)
+	GetDashboard(ctx context.Context, userIDs []primitive.ObjectID, fiscalYear int) (*ReportDashboard, error)
+	StreamReports(ctx context.Context, filter ExportFilter, fn func(*Report) error) error
+}
+
+type AnnouncementRepository interface {
 	CreateAnnouncement(ctx context.Context, announcement *Announcement) error
 	GetAllAnnouncements(ctx context.Context) ([]Announcement, error)
-	Delete(ctx context.Context, id primitive.ObjectID) error
-	FindByOwnerID(ctx context.Context, ownerID primitive.ObjectID, datatype string) ([]Plan, error)
-	FindByUserID(ctx context.Context, userID primitive.ObjectID, datatype string) ([]Report, error)
+	DeleteAnnouncement(ctx context.Context, id primitive.ObjectID) error
 }
+
+type CommentRepository interface {
+	CreateComment(ctx context.Context, comment *Comment) error
+	FetchSupervisorComments(ctx context.Context, planIDs []primitive.ObjectID) ([]Comment, error)
+	FetchCommentsByPlanID(ctx context.Context, planID primitive.ObjectID) ([]Comment, error)
+}
+
 type PlanUsecase interface {
-	CreatePlan(c context.Context, plan *Plan) (*primitive.ObjectID, error)
+	CreatePlan(c context.Context, plan *Plan, draft bool) (*primitive.ObjectID, error)
 	GetPlan(ctx context.Context, ownerID primitive.ObjectID) (*Plan, error)
 	// EditPlan(ctx context.Context, plan *Plan) error
-	GetSubmittedPlans(ctx context.Context, supervisor_name string) ([]Plan, error)
-	// ApprovePlan(ctx context.Context, planID, ownerID primitive.ObjectID) error
+	GetSubmittedPlans(ctx context.Context, supervisorID primitive.ObjectID) ([]Plan, error)
 	AddComment(ctx context.Context, comment *Comment) error
 	GetSupervisorComments(ctx context.Context, userID primitive.ObjectID) ([]Comment, error)
 	GetCommentsByPlanID(ctx context.Context, planID primitive.ObjectID) ([]Comment, error)
-	GetPlanTitlesByOwnerName(ctx context.Context, ownerName string) ([]string, error)
+	GetPlanTitlesByOwnerID(ctx context.Context, ownerID primitive.ObjectID) ([]string, error)
 	GetPlansByStatusAndOwner(ctx context.Context, userID primitive.ObjectID, status string) ([]Plan, error)
 	SubmitReport(ctx context.Context, report *Report) error
 	GetFilteredReports(ctx context.Context, userID primitive.ObjectID, status string) ([]Report, error)
 	// GetAllTitlesByUser(ctx context.Context, userID primitive.ObjectID) ([]string, error)
-	CountItems(ctx context.Context, itemType string, toWhom string) (int, error)
-	FetchPlansBySupervisorAndStatus(c context.Context, supervisorName, status string) ([]Plan, error)
-	FetchReportsBySupervisorAndStatus(c context.Context, supervisorName, reportStatus string) ([]Report, error)
-	UpdatePlanStatus(c context.Context, planID primitive.ObjectID, supervisorName, status, comment string) error
-	UpdateReportStatus(c context.Context, reportID primitive.ObjectID, supervisorName, status, comment string) error
-	UpdatePlan(c context.Context, planID string, updatedPlan *Plan) error
-	UpdateReport(c context.Context, reportID string, updatedReport *Report) error
+	CountItems(ctx context.Context, itemType string, supervisorID primitive.ObjectID) (int, error)
+	FetchPlansBySupervisorAndStatus(c context.Context, supervisorID primitive.ObjectID, status string) ([]Plan, error)
+	FetchReportsBySupervisorAndStatus(c context.Context, supervisorID primitive.ObjectID, reportStatus string) ([]Report, error)
+	UpdatePlanStatus(c context.Context, planID primitive.ObjectID, supervisorID primitive.ObjectID, status, comment string) error
+	GetApprovalChain(c context.Context, claims *JwtCustomClaims, planID primitive.ObjectID) ([]ApprovalStep, error)
+	ListApprovalPolicies(c context.Context) ([]ApprovalPolicy, error)
+	SaveApprovalPolicy(c context.Context, policy *ApprovalPolicy) error
+	DeleteApprovalPolicy(c context.Context, ownerRole string) error
+	UpdateReportStatus(c context.Context, reportID primitive.ObjectID, supervisorID primitive.ObjectID, status, comment string) error
+	UpdatePlan(c context.Context, claims *JwtCustomClaims, planID string, updatedPlan *Plan) error
+	UpdateReport(c context.Context, claims *JwtCustomClaims, reportID string, updatedReport *Report) error
+	TransitionPlan(c context.Context, claims *JwtCustomClaims, planID primitive.ObjectID, request *TransitionRequest) error
+	TransitionReport(c context.Context, claims *JwtCustomClaims, reportID primitive.ObjectID, request *TransitionRequest) error
+	GetPlanTransitions(c context.Context, claims *JwtCustomClaims, planID primitive.ObjectID) ([]Transition, error)
+	GetReportTransitions(c context.Context, claims *JwtCustomClaims, reportID primitive.ObjectID) ([]Transition, error)
+	GetPlanRevisions(c context.Context, claims *JwtCustomClaims, planID primitive.ObjectID) ([]Revision, error)
+	GetReportRevisions(c context.Context, claims *JwtCustomClaims, reportID primitive.ObjectID) ([]Revision, error)
+	DiffPlanRevisions(c context.Context, claims *JwtCustomClaims, planID primitive.ObjectID, from, to int) (*RevisionDiff, error)
+	DiffReportRevisions(c context.Context, claims *JwtCustomClaims, reportID primitive.ObjectID, from, to int) (*RevisionDiff, error)
 	GetAllPlansByUser(ctx context.Context, userID primitive.ObjectID) ([]Plan, error)
 	PublishAnnouncement(ctx context.Context, announcement *Announcement) error
 	GetAllAnnouncements(ctx context.Context) ([]Announcement, error)
 	DeleteAnnouncement(ctx context.Context, id primitive.ObjectID) error
-	GetPlansByOwnerID(ctx context.Context, ownerID primitive.ObjectID, datatype string) ([]Plan, error)
-	GetReportsByUserID(ctx context.Context, userID primitive.ObjectID, datatype string) ([]Report, error)
+	GetPlansByOwnerID(ctx context.Context, ownerID primitive.ObjectID) ([]Plan, error)
+	GetReportsByUserID(ctx context.Context, userID primitive.ObjectID) ([]Report, error)
+	GetParentPlanOptions(c context.Context, claims *JwtCustomClaims) ([]PlanRef, error)
+	GetCascade(c context.Context, claims *JwtCustomClaims, pillarID string) ([]PillarCascade, error)
+	GetMissingReports(c context.Context, claims *JwtCustomClaims, scope string, fiscalYear, quarter int) ([]MissingReport, error)
+	GetUnitReport(c context.Context, claims *JwtCustomClaims, fiscalYear, quarter int) (*UnitReport, error)
+	SubmitUnitReport(c context.Context, claims *JwtCustomClaims, fiscalYear, quarter int) ([]Report, error)
+	ImportPlans(c context.Context, claims *JwtCustomClaims, request *ImportRequest) (*ImportResult, error)
 }
@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	CollectionWebhook         = "webhooks"
+	CollectionWebhookDelivery = "webhook_deliveries"
+)
+
+// Webhook event types.
+const (
+	WebhookPlanSubmitted           = "plan.submitted"
+	WebhookPlanApproved            = "plan.approved"
+	WebhookPlanRevisionRequested   = "plan.revision_requested"
+	WebhookReportSubmitted         = "report.submitted"
+	WebhookReportApproved          = "report.approved"
+	WebhookReportRevisionRequested = "report.revision_requested"
+	WebhookPing                    = "ping" // Sent by the test-ping endpoint only
+)
+
+// WebhookEventTypes are the event types a webhook can subscribe to.
+var WebhookEventTypes = []string{
+	WebhookPlanSubmitted,
+	WebhookPlanApproved,
+	WebhookPlanRevisionRequested,
+	WebhookReportSubmitted,
+	WebhookReportApproved,
+	WebhookReportRevisionRequested,
+}
+
+var (
+	ErrWebhookNotFound = errors.New("webhook not found")
+	ErrInvalidWebhook  = errors.New("invalid webhook")
+)
+
+// Webhook is a subscription of another system to events. Payloads are POSTed
+// to URL as JSON and signed with Secret. A webhook with no Events receives
+// every event type.
+type Webhook struct {
+	ID        primitive.ObjectID `bson:"_id" json:"id"`
+	Name      string             `bson:"name" json:"name"`
+	URL       string             `bson:"url" json:"url"`
+	Secret    string             `bson:"secret" json:"secret,omitempty"` // Only shown when set
+	Events    []string           `bson:"events" json:"events"`
+	Active    bool               `bson:"active" json:"active"`
+	CreatedBy primitive.ObjectID `bson:"created_by" json:"created_by"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// WebhookRequest creates or updates a webhook. Fields left out of an update
+// keep their value; an empty secret on create gets a random one.
+type WebhookRequest struct {
+	Name   *string   `json:"name"`
+	URL    *string   `json:"url"`
+	Secret *string   `json:"secret"`
+	Events *[]string `json:"events"`
+	Active *bool     `json:"active"`
+}
+
+// WebhookPayload is the JSON body POSTed for an event. ID is the same for
+// every webhook and retry of one event, so receivers can drop duplicates.
+type WebhookPayload struct {
+	ID        primitive.ObjectID `json:"id"`
+	Type      string             `json:"type"`
+	CreatedAt time.Time          `json:"created_at"`
+	Data      interface{}        `json:"data"`
+}
+
+// WebhookMessage is an outbox message delivering one event to one webhook.
+// The body is rendered when the event happens, so retries send the same
+// bytes.
+type WebhookMessage struct {
+	WebhookID primitive.ObjectID `bson:"webhook_id" json:"webhook_id"`
+	EventID   primitive.ObjectID `bson:"event_id" json:"event_id"`
+	EventType string             `bson:"event_type" json:"event_type"`
+	Body      json.RawMessage    `bson:"body" json:"body"`
+}
+
+// WebhookDelivery logs one attempt to deliver an event to a webhook.
+type WebhookDelivery struct {
+	ID           primitive.ObjectID `bson:"_id" json:"id"`
+	WebhookID    primitive.ObjectID `bson:"webhook_id" json:"webhook_id"`
+	EventID      primitive.ObjectID `bson:"event_id" json:"event_id"`
+	EventType    string             `bson:"event_type" json:"event_type"`
+	Attempt      int                `bson:"attempt" json:"attempt"`
+	URL          string             `bson:"url" json:"url"`
+	StatusCode   int                `bson:"status_code,omitempty" json:"status_code,omitempty"`
+	Success      bool               `bson:"success" json:"success"`
+	Error        string             `bson:"error,omitempty" json:"error,omitempty"`
+	ResponseBody string             `bson:"response_body,omitempty" json:"response_body,omitempty"` // Truncated
+	DurationMs   int64              `bson:"duration_ms" json:"duration_ms"`
+	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
+}
+
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *Webhook) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*Webhook, error)
+	List(ctx context.Context) ([]Webhook, error)
+	// FindSubscribed returns the active webhooks receiving the event type.
+	FindSubscribed(ctx context.Context, eventType string) ([]Webhook, error)
+	Update(ctx context.Context, webhook *Webhook) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+}
+
+type WebhookDeliveryRepository interface {
+	Log(ctx context.Context, delivery *WebhookDelivery) error
+	ListByWebhook(ctx context.Context, webhookID primitive.ObjectID, limit int64) ([]WebhookDelivery, error)
+}
+
+// WebhookDispatcher queues an event for the webhooks subscribed to it. The
+// usecases call it with the context of the change, so in a transaction the
+// deliveries are queued with the change.
+type WebhookDispatcher interface {
+	Dispatch(ctx context.Context, eventType string, data interface{}) error
+}
+
+// WebhookSender POSTs webhook messages and logs each attempt.
+type WebhookSender interface {
+	// Deliver sends a queued message to its webhook. Messages for webhooks
+	// deleted or deactivated since are dropped.
+	Deliver(ctx context.Context, message *WebhookMessage, attempt int) error
+	Send(ctx context.Context, webhook *Webhook, message *WebhookMessage, attempt int) (*WebhookDelivery, error)
+}
+
+type WebhookUsecase interface {
+	CreateWebhook(c context.Context, claims *JwtCustomClaims, request *WebhookRequest) (*Webhook, error)
+	ListWebhooks(c context.Context) ([]Webhook, error)
+	GetWebhook(c context.Context, id string) (*Webhook, error)
+	UpdateWebhook(c context.Context, id string, request *WebhookRequest) (*Webhook, error)
+	DeleteWebhook(c context.Context, id string) error
+	PingWebhook(c context.Context, id string) (*WebhookDelivery, error)
+	ListDeliveries(c context.Context, id string, limit int64) ([]WebhookDelivery, error)
+}
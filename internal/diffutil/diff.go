@@ -0,0 +1,77 @@
+package diffutil
+
+import (
+	"encoding/json"
+	"plan/domain"
+	"reflect"
+	"sort"
+)
+
+// Diff compares the JSON forms of from and to field by field, descending into
+// nested objects. Arrays are compared as a whole. Paths listed in ignore are
+// skipped. Changes are sorted by field.
+func Diff(from, to interface{}, ignore ...string) ([]domain.FieldChange, error) {
+	a, err := toMap(from)
+	if err != nil {
+		return nil, err
+	}
+	b, err := toMap(to)
+	if err != nil {
+		return nil, err
+	}
+
+	skip := make(map[string]bool, len(ignore))
+	for _, path := range ignore {
+		skip[path] = true
+	}
+
+	changes := []domain.FieldChange{}
+	diffMaps("", a, b, skip, &changes)
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes, nil
+}
+
+func toMap(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func diffMaps(prefix string, a, b map[string]interface{}, skip map[string]bool, changes *[]domain.FieldChange) {
+	keys := make(map[string]struct{}, len(a)+len(b))
+	for key := range a {
+		keys[key] = struct{}{}
+	}
+	for key := range b {
+		keys[key] = struct{}{}
+	}
+
+	for key := range keys {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if skip[path] {
+			continue
+		}
+
+		av, bv := a[key], b[key]
+		am, aIsMap := av.(map[string]interface{})
+		bm, bIsMap := bv.(map[string]interface{})
+		if aIsMap && bIsMap {
+			diffMaps(path, am, bm, skip, changes)
+			continue
+		}
+
+		if !reflect.DeepEqual(av, bv) {
+			*changes = append(*changes, domain.FieldChange{Field: path, From: av, To: bv})
+		}
+	}
+}
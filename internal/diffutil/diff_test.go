@@ -0,0 +1,44 @@
+package diffutil
+
+import (
+	"reflect"
+	"testing"
+
+	"plan/domain"
+)
+
+func TestDiff(t *testing.T) {
+	type kpi struct {
+		Target float64 `json:"target"`
+		Unit   string  `json:"unit"`
+	}
+	type doc struct {
+		Title     string   `json:"title"`
+		Quantify  kpi      `json:"quantify"`
+		Tags      []string `json:"tags"`
+		UpdatedAt string   `json:"updated_at"`
+	}
+
+	from := doc{Title: "Plan", Quantify: kpi{Target: 10, Unit: "%"}, Tags: []string{"a"}, UpdatedAt: "monday"}
+	to := doc{Title: "Plan", Quantify: kpi{Target: 20, Unit: "%"}, Tags: []string{"a", "b"}, UpdatedAt: "tuesday"}
+
+	changes, err := Diff(from, to, "updated_at")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []domain.FieldChange{
+		{Field: "quantify.target", From: 10.0, To: 20.0},
+		{Field: "tags", From: []interface{}{"a"}, To: []interface{}{"a", "b"}},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("Diff() = %v, want %v", changes, want)
+	}
+
+	changes, err = Diff(from, from)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("Diff() of equal values = %v, want no changes", changes)
+	}
+}
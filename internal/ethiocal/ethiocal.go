@@ -0,0 +1,145 @@
+// Package ethiocal converts dates between the Gregorian and Ethiopian
+// calendars.
+//
+// The Ethiopian year has twelve months of 30 days followed by Pagume, which
+// has 5 days, or 6 in the year before a Gregorian leap year. The year starts
+// on Meskerem 1, which falls on September 11 or 12.
+package ethiocal
+
+import (
+	"fmt"
+	"time"
+)
+
+// Months of the Ethiopian year.
+const (
+	Meskerem = iota + 1
+	Tikimt
+	Hidar
+	Tahsas
+	Tir
+	Yekatit
+	Megabit
+	Miyazia
+	Ginbot
+	Sene
+	Hamle
+	Nehase
+	Pagume
+)
+
+var monthNames = [...]string{
+	"Meskerem", "Tikimt", "Hidar", "Tahsas", "Tir", "Yekatit", "Megabit",
+	"Miyazia", "Ginbot", "Sene", "Hamle", "Nehase", "Pagume",
+}
+
+// epoch is the Julian day number of Meskerem 1 of year 1.
+const epoch = 1724221
+
+// unixEpoch is the Julian day number of January 1, 1970.
+const unixEpoch = 2440588
+
+// Date is a day of the Ethiopian calendar.
+type Date struct {
+	Year  int
+	Month int
+	Day   int
+}
+
+// IsLeap reports whether the Ethiopian year has a sixth day of Pagume.
+func IsLeap(year int) bool {
+	return mod(year, 4) == 3
+}
+
+// Valid reports whether d is a day of the Ethiopian calendar.
+func (d Date) Valid() bool {
+	switch {
+	case d.Month < 1 || d.Month > 13 || d.Day < 1:
+		return false
+	case d.Month < 13:
+		return d.Day <= 30
+	case IsLeap(d.Year):
+		return d.Day <= 6
+	default:
+		return d.Day <= 5
+	}
+}
+
+// String formats d as YYYY-MM-DD.
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// MonthName returns the name of the month, e.g. Hamle.
+func (d Date) MonthName() string {
+	if d.Month < 1 || d.Month > 13 {
+		return ""
+	}
+	return monthNames[d.Month-1]
+}
+
+// Parse reads a date written as YYYY-MM-DD.
+func Parse(s string) (Date, error) {
+	var d Date
+	if len(s) != 10 || s[4] != '-' || s[7] != '-' {
+		return d, fmt.Errorf("invalid Ethiopian date %q", s)
+	}
+	if _, err := fmt.Sscanf(s, "%4d-%2d-%2d", &d.Year, &d.Month, &d.Day); err != nil {
+		return d, fmt.Errorf("invalid Ethiopian date %q", s)
+	}
+	if !d.Valid() {
+		return d, fmt.Errorf("invalid Ethiopian date %q", s)
+	}
+	return d, nil
+}
+
+// FromTime returns the Ethiopian date of t's calendar day in t's location.
+func FromTime(t time.Time) Date {
+	y, m, d := t.Date()
+	days := time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400
+	return fromJDN(int(days) + unixEpoch)
+}
+
+// Time returns midnight of d in loc.
+func (d Date) Time(loc *time.Location) time.Time {
+	days := d.jdn() - unixEpoch
+	t := time.Unix(int64(days)*86400, 0).UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// FiscalQuarterBounds returns the five dates bounding the quarters of the
+// Ethiopian fiscal year fiscalYear, which runs from Hamle 1 of the year
+// before to Sene 30. Quarter i runs from bounds[i] up to bounds[i+1].
+func FiscalQuarterBounds(fiscalYear int) []Date {
+	return []Date{
+		{Year: fiscalYear - 1, Month: Hamle, Day: 1},
+		{Year: fiscalYear, Month: Tikimt, Day: 1},
+		{Year: fiscalYear, Month: Tir, Day: 1},
+		{Year: fiscalYear, Month: Miyazia, Day: 1},
+		{Year: fiscalYear, Month: Hamle, Day: 1},
+	}
+}
+
+func (d Date) jdn() int {
+	return epoch - 1 + 365*(d.Year-1) + floorDiv(d.Year, 4) + 30*(d.Month-1) + d.Day
+}
+
+func fromJDN(jdn int) Date {
+	year := floorDiv(4*(jdn-epoch)+1463, 1461)
+	start := Date{Year: year, Month: 1, Day: 1}.jdn()
+	month := (jdn-start)/30 + 1
+	day := jdn - Date{Year: year, Month: month, Day: 1}.jdn() + 1
+	return Date{Year: year, Month: month, Day: day}
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+func mod(a, b int) int {
+	return a - b*floorDiv(a, b)
+}
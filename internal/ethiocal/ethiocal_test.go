@@ -0,0 +1,101 @@
+package ethiocal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConversion(t *testing.T) {
+	tests := []struct {
+		gregorian string
+		ethiopian Date
+	}{
+		{"2023-09-11", Date{2015, Pagume, 6}}, // last day of a leap year
+		{"2023-09-12", Date{2016, Meskerem, 1}},
+		{"2024-01-07", Date{2016, Tahsas, 28}},
+		{"2024-03-02", Date{2016, Yekatit, 23}},
+		{"2024-07-08", Date{2016, Hamle, 1}},
+		{"2024-09-10", Date{2016, Pagume, 5}},
+		{"2024-09-11", Date{2017, Meskerem, 1}},
+		{"2000-02-29", Date{1992, Yekatit, 21}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.gregorian, func(t *testing.T) {
+			g, err := time.Parse("2006-01-02", tt.gregorian)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := FromTime(g); got != tt.ethiopian {
+				t.Errorf("FromTime(%s) = %s, want %s", tt.gregorian, got, tt.ethiopian)
+			}
+			if got := tt.ethiopian.Time(time.UTC); !got.Equal(g) {
+				t.Errorf("%s.Time() = %s, want %s", tt.ethiopian, got.Format("2006-01-02"), tt.gregorian)
+			}
+		})
+	}
+}
+
+func TestFromTimeUsesLocalDay(t *testing.T) {
+	addis := time.FixedZone("EAT", 3*60*60)
+	// 22:00 UTC on September 11 is already September 12 in Addis Ababa.
+	instant := time.Date(2023, 9, 11, 22, 0, 0, 0, time.UTC)
+	if got, want := FromTime(instant.In(addis)), (Date{2016, Meskerem, 1}); got != want {
+		t.Errorf("FromTime() = %s, want %s", got, want)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	start := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+	for day := 0; day < 365*50; day++ {
+		g := start.AddDate(0, 0, day)
+		d := FromTime(g)
+		if !d.Valid() {
+			t.Fatalf("FromTime(%s) = %s, which is not a valid date", g.Format("2006-01-02"), d)
+		}
+		if got := d.Time(time.UTC); !got.Equal(g) {
+			t.Fatalf("%s.Time() = %s, want %s", d, got.Format("2006-01-02"), g.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Date
+		wantErr bool
+	}{
+		{"2016-11-01", Date{2016, Hamle, 1}, false},
+		{"2015-13-06", Date{2015, Pagume, 6}, false},
+		{"2016-13-06", Date{}, true}, // Pagume has 5 days outside leap years
+		{"2016-01-31", Date{}, true},
+		{"2016-14-01", Date{}, true},
+		{"2016-1-1", Date{}, true},
+		{"not a date", Date{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%q) error = %v, want error %v", tt.input, err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("Parse(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFiscalQuarterBounds(t *testing.T) {
+	want := []string{"2023-07-08", "2023-10-12", "2024-01-10", "2024-04-09", "2024-07-08"}
+	bounds := FiscalQuarterBounds(2016)
+	if len(bounds) != len(want) {
+		t.Fatalf("got %d bounds, want %d", len(bounds), len(want))
+	}
+	for i, bound := range bounds {
+		if got := bound.Time(time.UTC).Format("2006-01-02"); got != want[i] {
+			t.Errorf("bound %d = %s, want %s", i, got, want[i])
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Package eventhub passes events between the goroutines of one process. A
+// hub backed by MongoDB change streams uses it to fan events out to the
+// subscribers connected to each replica.
+package eventhub
+
+import (
+	"context"
+	"sync"
+
+	"plan/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// subscriberBuffer is how many events a subscriber may fall behind before
+// further events to it are dropped.
+const subscriberBuffer = 16
+
+// Hub is an in-process EventHub.
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[primitive.ObjectID]map[chan domain.Event]struct{}
+}
+
+func New() *Hub {
+	return &Hub{subscribers: make(map[primitive.ObjectID]map[chan domain.Event]struct{})}
+}
+
+// Publish delivers the events to the subscribers connected now. It never
+// blocks on a slow subscriber; events that do not fit in its buffer are
+// dropped.
+func (h *Hub) Publish(ctx context.Context, events ...domain.Event) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for _, event := range events {
+		for ch := range h.subscribers[event.RecipientID] {
+			select {
+			case ch <- event:
+			default:
+			}
+		}
+	}
+	return nil
+}
+
+func (h *Hub) Subscribe(userID primitive.ObjectID) (<-chan domain.Event, func()) {
+	ch := make(chan domain.Event, subscriberBuffer)
+
+	h.mu.Lock()
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = make(map[chan domain.Event]struct{})
+	}
+	h.subscribers[userID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.subscribers[userID], ch)
+			if len(h.subscribers[userID]) == 0 {
+				delete(h.subscribers, userID)
+			}
+			h.mu.Unlock()
+			close(ch)
+		})
+	}
+	return ch, unsubscribe
+}
@@ -0,0 +1,136 @@
+// Package export writes tables as CSV or XLSX one row at a time, so exports
+// can be streamed from a database cursor, and reads them back for imports.
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Formats of an export.
+const (
+	CSV  = "csv"
+	XLSX = "xlsx"
+)
+
+// ContentTypes maps each format to its MIME type.
+var ContentTypes = map[string]string{
+	CSV:  "text/csv; charset=utf-8",
+	XLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// Writer writes the rows of a table. Close must be called once all rows are
+// written; nothing may be complete on the underlying writer before then.
+type Writer interface {
+	WriteRow(cells []interface{}) error
+	Close() error
+}
+
+// NewWriter returns a Writer of the format writing to w.
+func NewWriter(format string, w io.Writer, sheet string) (Writer, error) {
+	switch format {
+	case CSV:
+		return &csvWriter{writer: csv.NewWriter(w)}, nil
+	case XLSX:
+		return newXLSXWriter(w, sheet)
+	}
+	return nil, fmt.Errorf("unknown export format %q", format)
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func (cw *csvWriter) WriteRow(cells []interface{}) error {
+	record := make([]string, len(cells))
+	for i, cell := range cells {
+		switch v := cell.(type) {
+		case nil:
+		case float64:
+			record[i] = strconv.FormatFloat(v, 'f', -1, 64)
+		case string:
+			record[i] = EscapeFormula(v)
+		default:
+			record[i] = fmt.Sprint(v)
+		}
+	}
+	return cw.writer.Write(record)
+}
+
+// formulaPrefixes are the first characters that make a spreadsheet read a
+// CSV cell as a formula.
+const formulaPrefixes = "=+-@\t\r"
+
+// EscapeFormula prefixes text that a spreadsheet would read as a formula with
+// an apostrophe, so it is shown as text. XLSX cells are written as typed
+// strings and need no escaping.
+func EscapeFormula(text string) string {
+	if text != "" && strings.ContainsRune(formulaPrefixes, rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
+
+// UnescapeFormula undoes EscapeFormula.
+func UnescapeFormula(text string) string {
+	if len(text) > 1 && text[0] == '\'' && strings.ContainsRune(formulaPrefixes, rune(text[1])) {
+		return text[1:]
+	}
+	return text
+}
+
+func (cw *csvWriter) Close() error {
+	cw.writer.Flush()
+	return cw.writer.Error()
+}
+
+// xlsxWriter streams rows into a single sheet. excelize keeps rows beyond its
+// memory threshold in a temporary file until the workbook is written out.
+type xlsxWriter struct {
+	w      io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func newXLSXWriter(w io.Writer, sheet string) (*xlsxWriter, error) {
+	file := excelize.NewFile()
+	if sheet != "" {
+		if err := file.SetSheetName("Sheet1", sheet); err != nil {
+			file.Close()
+			return nil, err
+		}
+	} else {
+		sheet = "Sheet1"
+	}
+
+	stream, err := file.NewStreamWriter(sheet)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &xlsxWriter{w: w, file: file, stream: stream}, nil
+}
+
+func (xw *xlsxWriter) WriteRow(cells []interface{}) error {
+	xw.row++
+	cell, err := excelize.CoordinatesToCellName(1, xw.row)
+	if err != nil {
+		return err
+	}
+	return xw.stream.SetRow(cell, cells)
+}
+
+func (xw *xlsxWriter) Close() error {
+	defer xw.file.Close()
+	if err := xw.stream.Flush(); err != nil {
+		return err
+	}
+	_, err := xw.file.WriteTo(xw.w)
+	return err
+}
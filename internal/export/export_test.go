@@ -0,0 +1,63 @@
+package export
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestEscapeFormula(t *testing.T) {
+	tests := []struct {
+		text, want string
+	}{
+		{"", ""},
+		{"Build roads", "Build roads"},
+		{"=HYPERLINK(\"x\")", "'=HYPERLINK(\"x\")"},
+		{"+1", "'+1"},
+		{"-1", "'-1"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"\tindented", "'\tindented"},
+		{"'quoted", "'quoted"},
+	}
+	for _, tt := range tests {
+		got := EscapeFormula(tt.text)
+		if got != tt.want {
+			t.Errorf("EscapeFormula(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+		if back := UnescapeFormula(got); back != tt.text {
+			t.Errorf("UnescapeFormula(%q) = %q, want %q", got, back, tt.text)
+		}
+	}
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := NewWriter(CSV, &buf, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.WriteRow([]interface{}{"=1+1", -2.5, "plain"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "'=1+1,-2.5,plain\n"; got != want {
+		t.Fatalf("wrote %q, want %q", got, want)
+	}
+
+	reader, err := NewReader(CSV, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	record, err := reader.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record[0] != "=1+1" || record[1] != "-2.5" || record[2] != "plain" {
+		t.Fatalf("read %q", record)
+	}
+	if _, err := reader.Read(); err != io.EOF {
+		t.Fatalf("second Read error = %v, want io.EOF", err)
+	}
+}
@@ -0,0 +1,86 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Reader reads the rows of a table written in one of the formats. Read
+// returns io.EOF after the last row.
+type Reader interface {
+	Read() ([]string, error)
+	Close() error
+}
+
+// NewReader returns a Reader of the format reading from r. XLSX tables are
+// read from the first sheet; cells are read raw, so dates come as serial
+// numbers.
+func NewReader(format string, r io.Reader) (Reader, error) {
+	switch format {
+	case CSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		return &csvReader{reader: reader}, nil
+	case XLSX:
+		return newXLSXReader(r)
+	}
+	return nil, fmt.Errorf("unknown format %q", format)
+}
+
+type csvReader struct {
+	reader *csv.Reader
+}
+
+func (cr *csvReader) Read() ([]string, error) {
+	record, err := cr.reader.Read()
+	for i, cell := range record {
+		record[i] = UnescapeFormula(cell)
+	}
+	return record, err
+}
+
+func (cr *csvReader) Close() error {
+	return nil
+}
+
+type xlsxReader struct {
+	file *excelize.File
+	rows *excelize.Rows
+}
+
+func newXLSXReader(r io.Reader) (*xlsxReader, error) {
+	file, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, err
+	}
+	sheets := file.GetSheetList()
+	if len(sheets) == 0 {
+		file.Close()
+		return nil, fmt.Errorf("the workbook has no sheets")
+	}
+	rows, err := file.Rows(sheets[0])
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &xlsxReader{file: file, rows: rows}, nil
+}
+
+func (xr *xlsxReader) Read() ([]string, error) {
+	if !xr.rows.Next() {
+		if err := xr.rows.Error(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+	return xr.rows.Columns(excelize.Options{RawCellValue: true})
+}
+
+func (xr *xlsxReader) Close() error {
+	xr.rows.Close()
+	return xr.file.Close()
+}
@@ -0,0 +1,106 @@
+// Package mailer renders the mail of an event from its templates and hands
+// it to a transport: SMTP in production, or a capture that keeps the messages
+// in memory and optionally writes them to a directory for development and
+// tests.
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+
+	"plan/domain"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// Message is a rendered email.
+type Message struct {
+	Event   string
+	From    string
+	To      []string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Transport delivers rendered messages.
+type Transport interface {
+	Deliver(ctx context.Context, msg *Message) error
+}
+
+type event struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+type mailer struct {
+	from      string
+	transport Transport
+	events    map[string]event
+}
+
+// New returns a Mailer sending from the address through the transport. It
+// panics if a template does not parse, as they are embedded in the binary.
+func New(from string, transport Transport) domain.Mailer {
+	files, err := templateFS.ReadDir("templates")
+	if err != nil {
+		panic(err)
+	}
+
+	events := make(map[string]event, len(files))
+	for _, file := range files {
+		name := "templates/" + file.Name()
+		events[strings.TrimSuffix(file.Name(), ".tmpl")] = event{
+			text: texttemplate.Must(texttemplate.ParseFS(templateFS, name)),
+			html: htmltemplate.Must(htmltemplate.ParseFS(templateFS, name)),
+		}
+	}
+
+	return &mailer{from: from, transport: transport, events: events}
+}
+
+func (m *mailer) Send(ctx context.Context, mail *domain.Mail) error {
+	if len(mail.To) == 0 {
+		return errors.New("mail has no recipient")
+	}
+
+	msg, err := m.render(mail)
+	if err != nil {
+		return err
+	}
+	return m.transport.Deliver(ctx, msg)
+}
+
+func (m *mailer) render(mail *domain.Mail) (*Message, error) {
+	tmpl, ok := m.events[mail.Event]
+	if !ok {
+		return nil, fmt.Errorf("no templates for mail event %q", mail.Event)
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := tmpl.text.ExecuteTemplate(&subject, "subject", mail.Data); err != nil {
+		return nil, err
+	}
+	if err := tmpl.text.ExecuteTemplate(&text, "text", mail.Data); err != nil {
+		return nil, err
+	}
+	if err := tmpl.html.ExecuteTemplate(&html, "html", mail.Data); err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		Event:   mail.Event,
+		From:    m.from,
+		To:      mail.To,
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
@@ -0,0 +1,131 @@
+package mailer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"plan/domain"
+)
+
+func TestSendRendersEvent(t *testing.T) {
+	capture, err := NewCapture("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := New("planning@example.com", capture)
+
+	err = m.Send(context.Background(), &domain.Mail{
+		Event: domain.MailAccountApproved,
+		To:    []string{"abebe@example.com"},
+		Data:  map[string]interface{}{"Name": "Abebe <Kebede>"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	messages := capture.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("captured %d messages, want 1", len(messages))
+	}
+	msg := messages[0]
+	if msg.Event != domain.MailAccountApproved || msg.From != "planning@example.com" {
+		t.Errorf("event %q from %q", msg.Event, msg.From)
+	}
+	if len(msg.To) != 1 || msg.To[0] != "abebe@example.com" {
+		t.Errorf("To = %q", msg.To)
+	}
+	if msg.Subject != "AASTU Planning System Account Approved" {
+		t.Errorf("Subject = %q", msg.Subject)
+	}
+	if !strings.Contains(msg.Text, "Hello Abebe <Kebede>,") {
+		t.Errorf("Text = %q", msg.Text)
+	}
+	if !strings.Contains(msg.HTML, "Hello Abebe &lt;Kebede&gt;,") {
+		t.Errorf("HTML is not escaped: %q", msg.HTML)
+	}
+}
+
+func TestSendRejectsBadMail(t *testing.T) {
+	capture, err := NewCapture("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := New("planning@example.com", capture)
+
+	tests := []struct {
+		name string
+		mail *domain.Mail
+	}{
+		{"no recipient", &domain.Mail{Event: domain.MailAccountPending}},
+		{"unknown event", &domain.Mail{Event: "no_such_event", To: []string{"a@example.com"}}},
+	}
+	for _, tt := range tests {
+		if err := m.Send(context.Background(), tt.mail); err == nil {
+			t.Errorf("%s: Send succeeded", tt.name)
+		}
+	}
+	if n := len(capture.Messages()); n != 0 {
+		t.Errorf("captured %d messages, want none", n)
+	}
+}
+
+func TestCaptureKeepsLatest(t *testing.T) {
+	capture, err := NewCapture("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < captureLimit+5; i++ {
+		if err := capture.Deliver(context.Background(), &Message{Subject: string(rune('a' + i%26))}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	messages := capture.Messages()
+	if len(messages) != captureLimit {
+		t.Fatalf("kept %d messages, want %d", len(messages), captureLimit)
+	}
+	if got, want := messages[0].Subject, string(rune('a'+5%26)); got != want {
+		t.Errorf("oldest kept message is %q, want %q", got, want)
+	}
+
+	capture.Reset()
+	if n := len(capture.Messages()); n != 0 {
+		t.Errorf("kept %d messages after Reset", n)
+	}
+}
+
+func TestCaptureWritesFiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	capture, err := NewCapture(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := New("planning@example.com", capture)
+
+	err = m.Send(context.Background(), &domain.Mail{
+		Event: domain.MailAccountRejected,
+		To:    []string{"abebe@example.com"},
+		Data:  map[string]interface{}{"Name": "Abebe"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || !strings.HasSuffix(files[0].Name(), "-account_rejected.eml") {
+		t.Fatalf("files = %v", files)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "Subject: AASTU Planning System Account Rejected") {
+		t.Errorf("message lacks its subject:\n%s", data)
+	}
+}
@@ -0,0 +1,105 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"gopkg.in/gomail.v2"
+)
+
+// gomailMessage builds the MIME message of msg, with the text and HTML bodies
+// as alternatives.
+func gomailMessage(msg *Message) *gomail.Message {
+	m := gomail.NewMessage()
+	m.SetHeader("From", msg.From)
+	m.SetHeader("To", msg.To...)
+	m.SetHeader("Subject", msg.Subject)
+	m.SetBody("text/plain", msg.Text)
+	if msg.HTML != "" {
+		m.AddAlternative("text/html", msg.HTML)
+	}
+	return m
+}
+
+// SMTP delivers messages through an SMTP server, dialing it for each message.
+type SMTP struct {
+	dialer *gomail.Dialer
+}
+
+func NewSMTP(host string, port int, username, password string) *SMTP {
+	return &SMTP{dialer: gomail.NewDialer(host, port, username, password)}
+}
+
+func (s *SMTP) Deliver(ctx context.Context, msg *Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.dialer.DialAndSend(gomailMessage(msg))
+}
+
+// captureLimit is the number of messages a Capture keeps in memory; older
+// ones are dropped.
+const captureLimit = 100
+
+// Capture keeps delivered messages in memory instead of sending them and,
+// when it has a directory, also writes each one there as an .eml file.
+type Capture struct {
+	dir      string
+	mu       sync.Mutex
+	messages []Message
+	count    int
+}
+
+// NewCapture returns a Capture writing to dir, or only keeping messages in
+// memory when dir is empty.
+func NewCapture(dir string) (*Capture, error) {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+	}
+	return &Capture{dir: dir}, nil
+}
+
+func (c *Capture) Deliver(ctx context.Context, msg *Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.count++
+	c.messages = append(c.messages, *msg)
+	if len(c.messages) > captureLimit {
+		c.messages = c.messages[len(c.messages)-captureLimit:]
+	}
+
+	if c.dir == "" {
+		return nil
+	}
+	name := fmt.Sprintf("%s-%04d-%s.eml", time.Now().Format("20060102T150405"), c.count, msg.Event)
+	file, err := os.Create(filepath.Join(c.dir, name))
+	if err != nil {
+		return err
+	}
+	if _, err := gomailMessage(msg).WriteTo(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Messages returns the messages kept in memory, oldest first.
+func (c *Capture) Messages() []Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Message(nil), c.messages...)
+}
+
+// Reset drops the messages kept in memory.
+func (c *Capture) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.messages = nil
+}
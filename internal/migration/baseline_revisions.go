@@ -0,0 +1,91 @@
+package migration
+
+import (
+	"context"
+	"plan/database"
+	"plan/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	register(Migration{
+		Name:        "baseline-revisions",
+		Description: "store a first revision for plans and reports that have none",
+		Run:         baselineRevisions,
+	})
+}
+
+func baselineRevisions(ctx context.Context, db database.Database) (*Report, error) {
+	report := &Report{Migration: "baseline-revisions"}
+
+	for _, name := range planCollections {
+		if err := baselineCollectionRevisions(ctx, db, name, report); err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+func baselineCollectionRevisions(ctx context.Context, db database.Database, collectionName string, report *Report) error {
+	revisions := db.Collection(domain.CollectionRevision)
+
+	cursor, err := db.Collection(collectionName).Find(ctx, bson.M{"type": bson.M{"$in": bson.A{"plan", "report"}}})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var header struct {
+			ID   primitive.ObjectID `bson:"_id"`
+			Type string             `bson:"type"`
+		}
+		if err := cursor.Decode(&header); err != nil {
+			return err
+		}
+
+		count, err := revisions.CountDocuments(ctx, bson.M{"document_id": header.ID})
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+
+		revision := domain.Revision{
+			ID:           primitive.NewObjectID(),
+			DocumentID:   header.ID,
+			DocumentType: header.Type,
+			Number:       1,
+			CreatedAt:    time.Now(),
+		}
+		if header.Type == "plan" {
+			revision.Plan = &domain.Plan{}
+			err = cursor.Decode(revision.Plan)
+			revision.AuthorID = revision.Plan.OwnerID
+		} else {
+			revision.Report = &domain.Report{}
+			err = cursor.Decode(revision.Report)
+			revision.AuthorID = revision.Report.ReportUserID
+		}
+		if err != nil {
+			report.Issues = append(report.Issues, Issue{
+				Collection: collectionName,
+				DocumentID: header.ID,
+				Reason:     err.Error(),
+			})
+			continue
+		}
+
+		if _, err := revisions.InsertOne(ctx, revision); err != nil {
+			return err
+		}
+		report.Updated++
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package migration
+
+import (
+	"context"
+	"plan/database"
+	"plan/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	register(Migration{
+		Name:        "fiscal-periods",
+		Description: "derive the fiscal year and quarter of plans from their start date and of reports from their creation time",
+		Run:         deriveFiscalPeriods,
+	})
+}
+
+// deriveFiscalPeriods needs the fiscal calendar to be set up first. Reports
+// never stored when they were written, so the time is read from their ID.
+func deriveFiscalPeriods(ctx context.Context, db database.Database) (*Report, error) {
+	report := &Report{Migration: "fiscal-periods"}
+
+	cursor, err := db.Collection(domain.CollectionFiscalYear).Find(ctx, bson.M{})
+	if err != nil {
+		return report, err
+	}
+	var years []domain.FiscalYear
+	err = cursor.All(ctx, &years)
+	cursor.Close(ctx)
+	if err != nil {
+		return report, err
+	}
+
+	periodAt := func(t time.Time) (*domain.FiscalYear, *domain.Period) {
+		for i := range years {
+			if period, ok := years[i].PeriodAt(t); ok {
+				return &years[i], period
+			}
+		}
+		return nil, nil
+	}
+
+	plans := db.Collection(domain.CollectionPlan)
+	cursor, err = plans.Find(ctx, bson.M{"fiscal_year": bson.M{"$in": bson.A{0, nil}}})
+	if err != nil {
+		return report, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var plan struct {
+			ID        primitive.ObjectID `bson:"_id"`
+			StartDate time.Time          `bson:"start_date"`
+		}
+		if err := cursor.Decode(&plan); err != nil {
+			return report, err
+		}
+
+		year, period := periodAt(plan.StartDate)
+		if year == nil {
+			report.Issues = append(report.Issues, Issue{
+				Collection: domain.CollectionPlan,
+				DocumentID: plan.ID,
+				Field:      "start_date",
+				Value:      plan.StartDate.Format(time.RFC3339),
+				Reason:     "no fiscal year covers the start date",
+			})
+			continue
+		}
+
+		update := bson.M{"$set": bson.M{
+			"fiscal_year":   year.Year,
+			"quarter":       period.Quarter,
+			"which_quarter": period.Name,
+		}}
+		if _, err := plans.UpdateOne(ctx, bson.M{"_id": plan.ID}, update); err != nil {
+			return report, err
+		}
+		report.Updated++
+	}
+
+	reports := db.Collection(domain.CollectionReport)
+	reportCursor, err := reports.Find(ctx, bson.M{"fiscal_year": bson.M{"$in": bson.A{0, nil}}})
+	if err != nil {
+		return report, err
+	}
+	defer reportCursor.Close(ctx)
+
+	for reportCursor.Next(ctx) {
+		var doc struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := reportCursor.Decode(&doc); err != nil {
+			return report, err
+		}
+
+		written := doc.ID.Timestamp()
+		year, period := periodAt(written)
+		if year == nil {
+			report.Issues = append(report.Issues, Issue{
+				Collection: domain.CollectionReport,
+				DocumentID: doc.ID,
+				Field:      "_id",
+				Value:      written.Format(time.RFC3339),
+				Reason:     "no fiscal year covers the time the report was written",
+			})
+			continue
+		}
+
+		update := bson.M{"$set": bson.M{"fiscal_year": year.Year, "quarter": period.Quarter}}
+		if _, err := reports.UpdateOne(ctx, bson.M{"_id": doc.ID}, update); err != nil {
+			return report, err
+		}
+		report.Updated++
+	}
+
+	return report, nil
+}
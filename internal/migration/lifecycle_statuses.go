@@ -0,0 +1,62 @@
+package migration
+
+import (
+	"context"
+	"plan/database"
+	"plan/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func init() {
+	register(Migration{
+		Name:        "lifecycle-statuses",
+		Description: "move report_status into status and map Pending/Rejected to the lifecycle states",
+		Run:         migrateLifecycleStatuses,
+	})
+}
+
+func migrateLifecycleStatuses(ctx context.Context, db database.Database) (*Report, error) {
+	report := &Report{Migration: "lifecycle-statuses"}
+
+	for _, name := range planCollections {
+		if err := migrateCollectionStatuses(ctx, db.Collection(name), report); err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+func migrateCollectionStatuses(ctx context.Context, collection database.Collection, report *Report) error {
+	// Review decisions on reports were written to report_status while the
+	// report itself kept status, so report_status is the more recent one.
+	moved, err := collection.UpdateMany(ctx,
+		bson.M{"type": "report", "report_status": bson.M{"$exists": true}},
+		bson.A{
+			bson.M{"$set": bson.M{"status": "$report_status"}},
+			bson.M{"$unset": "report_status"},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	report.Updated += int(moved.ModifiedCount)
+
+	renames := map[string]string{
+		"Pending":  domain.StatusSubmitted,
+		"Rejected": domain.StatusRevisionRequested,
+	}
+	for from, to := range renames {
+		result, err := collection.UpdateMany(ctx,
+			bson.M{"type": bson.M{"$in": bson.A{"plan", "report"}}, "status": from},
+			bson.M{"$set": bson.M{"status": to}},
+		)
+		if err != nil {
+			return err
+		}
+		report.Updated += int(result.ModifiedCount)
+	}
+
+	return nil
+}
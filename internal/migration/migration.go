@@ -0,0 +1,53 @@
+package migration
+
+import (
+	"context"
+	"plan/database"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Migration is a named one-shot data migration. Every migration must be safe
+// to run again: documents that were already migrated are skipped.
+type Migration struct {
+	Name        string
+	Description string
+	Run         func(ctx context.Context, db database.Database) (*Report, error)
+}
+
+// Issue records a document the migration could not handle and why.
+type Issue struct {
+	Collection string             `json:"collection"`
+	DocumentID primitive.ObjectID `json:"document_id"`
+	Field      string             `json:"field"`
+	Value      string             `json:"value"`
+	Reason     string             `json:"reason"`
+}
+
+// Report summarizes a migration run.
+type Report struct {
+	Migration string  `json:"migration"`
+	Updated   int     `json:"updated"`
+	Issues    []Issue `json:"issues"`
+}
+
+var migrations []Migration
+
+func register(m Migration) {
+	migrations = append(migrations, m)
+}
+
+// All returns the registered migrations in registration order.
+func All() []Migration {
+	return migrations
+}
+
+// Find returns the migration with the given name.
+func Find(name string) (*Migration, bool) {
+	for i := range migrations {
+		if migrations[i].Name == name {
+			return &migrations[i], true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,158 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"plan/database"
+	"plan/domain"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func init() {
+	register(Migration{
+		Name:        "numeric-kpis",
+		Description: "convert free-text KPI targets and accomplished values to numbers and compute plan achievement",
+		Run:         migrateNumericKPIs,
+	})
+}
+
+// numericFields lists the fields that used to hold free text, per collection.
+func numericFields() map[string][]string {
+	fields := map[string][]string{
+		domain.CollectionRevision: {"plan.quantify.target", "plan.quantify.actual", "report.acomplished_value"},
+	}
+	for _, name := range planCollections {
+		fields[name] = []string{"quantify.target", "quantify.actual", "acomplished_value"}
+	}
+	return fields
+}
+
+func migrateNumericKPIs(ctx context.Context, db database.Database) (*Report, error) {
+	report := &Report{Migration: "numeric-kpis"}
+
+	for name, fields := range numericFields() {
+		for _, field := range fields {
+			if err := convertStringField(ctx, db.Collection(name), name, field, report); err != nil {
+				return report, err
+			}
+		}
+	}
+
+	for _, name := range planCollections {
+		if err := computeAchievements(ctx, db.Collection(name), report); err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+// convertStringField rewrites every string value of field as a number.
+// Values that do not parse are set to 0 and reported with their original text.
+func convertStringField(ctx context.Context, collection database.Collection, collectionName, field string, report *Report) error {
+	cursor, err := collection.Find(ctx,
+		bson.M{field: bson.M{"$type": "string"}},
+		options.Find().SetProjection(bson.M{field: 1}),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc bson.M
+		if err := cursor.Decode(&doc); err != nil {
+			return err
+		}
+		id, _ := doc["_id"].(primitive.ObjectID)
+		text, _ := lookup(doc, field).(string)
+
+		value, err := parseNumber(text)
+		if err != nil {
+			report.Issues = append(report.Issues, Issue{
+				Collection: collectionName,
+				DocumentID: id,
+				Field:      field,
+				Value:      text,
+				Reason:     "not a number, set to 0",
+			})
+		}
+
+		if _, err := collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{field: value}}); err != nil {
+			return err
+		}
+		report.Updated++
+	}
+
+	return nil
+}
+
+// computeAchievements fills in the direction and achievement of plans that
+// predate numeric KPIs.
+func computeAchievements(ctx context.Context, collection database.Collection, report *Report) error {
+	cursor, err := collection.Find(ctx, bson.M{"type": "plan", "quantify.direction": bson.M{"$exists": false}})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var plan domain.Plan
+		if err := cursor.Decode(&plan); err != nil {
+			return err
+		}
+
+		plan.Quantify.Direction = domain.DirectionIncrease
+		plan.Quantify.UpdateAchievement()
+
+		update := bson.M{"$set": bson.M{
+			"quantify.direction":   plan.Quantify.Direction,
+			"quantify.achievement": plan.Quantify.Achievement,
+		}}
+		if _, err := collection.UpdateOne(ctx, bson.M{"_id": plan.ID}, update); err != nil {
+			return err
+		}
+		report.Updated++
+	}
+
+	return nil
+}
+
+// lookup returns the value at a dotted path of a decoded document.
+func lookup(doc interface{}, path string) interface{} {
+	for _, key := range strings.Split(path, ".") {
+		switch d := doc.(type) {
+		case bson.M:
+			doc = d[key]
+		case bson.D:
+			var next interface{}
+			for _, e := range d {
+				if e.Key == key {
+					next = e.Value
+				}
+			}
+			doc = next
+		default:
+			return nil
+		}
+	}
+	return doc
+}
+
+// parseNumber reads numbers written as free text, such as "1,200" or "85%".
+func parseNumber(text string) (float64, error) {
+	cleaned := strings.NewReplacer(",", "", "%", "").Replace(strings.TrimSpace(text))
+	if cleaned == "" {
+		return 0, nil
+	}
+	value, err := strconv.ParseFloat(cleaned, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse %q: %w", text, err)
+	}
+	return value, nil
+}
@@ -0,0 +1,93 @@
+package migration
+
+import (
+	"context"
+	"plan/database"
+	"plan/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func init() {
+	register(Migration{
+		Name:        "parent-plan-ids",
+		Description: "resolve the free-text superior_plan of plans to the supervisor's plan with that title",
+		Run:         resolveParentPlanIDs,
+	})
+}
+
+func resolveParentPlanIDs(ctx context.Context, db database.Database) (*Report, error) {
+	report := &Report{Migration: "parent-plan-ids"}
+	plans := db.Collection(domain.CollectionPlan)
+
+	cursor, err := plans.Find(ctx, bson.M{
+		"superior_plan":      bson.M{"$nin": bson.A{"", nil}},
+		"supervisor_plan_id": bson.M{"$exists": false},
+	})
+	if err != nil {
+		return report, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var plan struct {
+			ID           primitive.ObjectID `bson:"_id"`
+			SupervisorID primitive.ObjectID `bson:"supervisor_id"`
+			SuperiorPlan string             `bson:"superior_plan"`
+		}
+		if err := cursor.Decode(&plan); err != nil {
+			return report, err
+		}
+
+		issue := Issue{
+			Collection: domain.CollectionPlan,
+			DocumentID: plan.ID,
+			Field:      "superior_plan",
+			Value:      plan.SuperiorPlan,
+		}
+		if plan.SupervisorID.IsZero() {
+			issue.Reason = "plan has no supervisor"
+			report.Issues = append(report.Issues, issue)
+			continue
+		}
+
+		// The old form offered the supervisor's plan titles, so a title is
+		// only trusted when exactly one of the supervisor's plans has it.
+		candidates, err := plans.Find(ctx,
+			bson.M{"owner_id": plan.SupervisorID, "title": plan.SuperiorPlan},
+			options.Find().SetProjection(bson.M{"_id": 1}).SetLimit(2),
+		)
+		if err != nil {
+			return report, err
+		}
+		var parents []struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		err = candidates.All(ctx, &parents)
+		candidates.Close(ctx)
+		if err != nil {
+			return report, err
+		}
+
+		switch len(parents) {
+		case 0:
+			issue.Reason = "no plan of the supervisor has this title"
+			report.Issues = append(report.Issues, issue)
+			continue
+		case 2:
+			issue.Reason = "several plans of the supervisor have this title"
+			report.Issues = append(report.Issues, issue)
+			continue
+		}
+
+		update := bson.M{"$set": bson.M{"supervisor_plan_id": parents[0].ID}}
+		if _, err := plans.UpdateOne(ctx, bson.M{"_id": plan.ID}, update); err != nil {
+			return report, err
+		}
+		report.Updated++
+	}
+
+	return report, nil
+}
@@ -0,0 +1,107 @@
+package migration
+
+import (
+	"context"
+	"plan/database"
+	"plan/domain"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	register(Migration{
+		Name:        "pillar-catalog",
+		Description: "link plans to the pillar catalog entry whose name or code matches their free-text aligned_pillary",
+		Run:         linkPillarCatalog,
+	})
+}
+
+// linkPillarCatalog needs the catalog to be filled in first. Text matching
+// several pillars, for instance the same name in two fiscal years, is left
+// for the planning office to resolve by hand.
+func linkPillarCatalog(ctx context.Context, db database.Database) (*Report, error) {
+	report := &Report{Migration: "pillar-catalog"}
+
+	cursor, err := db.Collection(domain.CollectionPillar).Find(ctx, bson.M{})
+	if err != nil {
+		return report, err
+	}
+	var pillars []domain.Pillar
+	err = cursor.All(ctx, &pillars)
+	cursor.Close(ctx)
+	if err != nil {
+		return report, err
+	}
+
+	matches := make(map[string][]domain.Pillar)
+	for _, pillar := range pillars {
+		for _, key := range []string{pillarKey(pillar.Name), pillarKey(pillar.Code)} {
+			matches[key] = append(matches[key], pillar)
+		}
+	}
+
+	plans := db.Collection(domain.CollectionPlan)
+	cursor, err = plans.Find(ctx, bson.M{"type": "plan", "pillar_id": bson.M{"$exists": false}})
+	if err != nil {
+		return report, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var plan struct {
+			ID             primitive.ObjectID `bson:"_id"`
+			AlignedPillary string             `bson:"aligned_pillary"`
+		}
+		if err := cursor.Decode(&plan); err != nil {
+			return report, err
+		}
+
+		issue := Issue{
+			Collection: domain.CollectionPlan,
+			DocumentID: plan.ID,
+			Field:      "aligned_pillary",
+			Value:      plan.AlignedPillary,
+		}
+		found := uniquePillars(matches[pillarKey(plan.AlignedPillary)])
+		switch {
+		case strings.TrimSpace(plan.AlignedPillary) == "":
+			issue.Reason = "plan names no pillar"
+		case len(found) == 0:
+			issue.Reason = "no pillar of the catalog matches"
+		case len(found) > 1:
+			issue.Reason = "several pillars of the catalog match"
+		}
+		if issue.Reason != "" {
+			report.Issues = append(report.Issues, issue)
+			continue
+		}
+
+		update := bson.M{"$set": bson.M{"pillar_id": found[0].ID, "aligned_pillary": found[0].Name}}
+		if _, err := plans.UpdateOne(ctx, bson.M{"_id": plan.ID}, update); err != nil {
+			return report, err
+		}
+		report.Updated++
+	}
+
+	return report, nil
+}
+
+func pillarKey(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
+
+// uniquePillars drops the duplicates of a pillar whose name and code are the
+// same text.
+func uniquePillars(pillars []domain.Pillar) []domain.Pillar {
+	seen := make(map[primitive.ObjectID]bool)
+	var unique []domain.Pillar
+	for _, pillar := range pillars {
+		if !seen[pillar.ID] {
+			seen[pillar.ID] = true
+			unique = append(unique, pillar)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,40 @@
+package migration
+
+import (
+	"context"
+	"plan/database"
+	"plan/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func init() {
+	register(Migration{
+		Name:        "seed-role-hierarchy",
+		Description: "store the built-in role ladder as role rules, leaving existing rules untouched",
+		Run:         seedRoleHierarchy,
+	})
+}
+
+func seedRoleHierarchy(ctx context.Context, db database.Database) (*Report, error) {
+	report := &Report{Migration: "seed-role-hierarchy"}
+	collection := db.Collection(domain.CollectionRoleRule)
+
+	for _, rule := range domain.DefaultRoleRules {
+		update := bson.M{"$setOnInsert": bson.M{
+			"role":          rule.Role,
+			"superior_role": rule.SuperiorRole,
+			"rank":          rule.Rank,
+		}}
+		result, err := collection.UpdateOne(ctx, bson.M{"role": rule.Role}, update, options.Update().SetUpsert(true))
+		if err != nil {
+			return report, err
+		}
+		if result.UpsertedCount > 0 {
+			report.Updated++
+		}
+	}
+
+	return report, nil
+}
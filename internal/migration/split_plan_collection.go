@@ -0,0 +1,97 @@
+package migration
+
+import (
+	"context"
+	"plan/database"
+	"plan/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// legacyPlanCollection held plans, reports, announcements and comments before
+// they were split into their own collections.
+const legacyPlanCollection = "Plan"
+
+// planCollections are the collections that may hold plans and reports,
+// depending on whether split-plan-collection has run yet.
+var planCollections = []string{legacyPlanCollection, domain.CollectionPlan, domain.CollectionReport}
+
+func init() {
+	register(Migration{
+		Name:        "split-plan-collection",
+		Description: "move plans, reports, announcements and comments out of the shared Plan collection",
+		Run:         splitPlanCollection,
+	})
+}
+
+// splitPlanCollection copies each document to its typed collection, keeping
+// its _id, and only then removes it from the legacy collection. A run that
+// stops halfway is finished by running it again.
+func splitPlanCollection(ctx context.Context, db database.Database) (*Report, error) {
+	report := &Report{Migration: "split-plan-collection"}
+	source := db.Collection(legacyPlanCollection)
+
+	cursor, err := source.Find(ctx, bson.M{})
+	if err != nil {
+		return report, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc bson.M
+		if err := cursor.Decode(&doc); err != nil {
+			return report, err
+		}
+		id, _ := doc["_id"].(primitive.ObjectID)
+
+		target := splitTarget(doc)
+		if target == "" {
+			kind, _ := doc["type"].(string)
+			report.Issues = append(report.Issues, Issue{
+				Collection: legacyPlanCollection,
+				DocumentID: id,
+				Field:      "type",
+				Value:      kind,
+				Reason:     "unknown document type, left in place",
+			})
+			continue
+		}
+
+		fields := bson.M{}
+		for key, value := range doc {
+			if key != "_id" {
+				fields[key] = value
+			}
+		}
+		_, err := db.Collection(target).UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": fields}, options.Update().SetUpsert(true))
+		if err != nil {
+			return report, err
+		}
+		if _, err := source.DeleteOne(ctx, bson.M{"_id": id}); err != nil {
+			return report, err
+		}
+		report.Updated++
+	}
+
+	return report, nil
+}
+
+// splitTarget picks the collection a legacy document belongs in. Comments
+// were stored without a type and are recognized by their commenter field.
+func splitTarget(doc bson.M) string {
+	switch doc["type"] {
+	case "plan":
+		return domain.CollectionPlan
+	case "report":
+		return domain.CollectionReport
+	case "announcement":
+		return domain.CollectionAnnouncement
+	case nil, "":
+		if _, ok := doc["commenter"]; ok {
+			return domain.CollectionComment
+		}
+	}
+	return ""
+}
@@ -0,0 +1,120 @@
+package migration
+
+import (
+	"context"
+	"plan/database"
+	"plan/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func init() {
+	register(Migration{
+		Name:        "supervisor-ids",
+		Description: "resolve to_whom and supervisor_name to supervisor_id on users, plans and reports",
+		Run:         migrateSupervisorIDs,
+	})
+}
+
+func migrateSupervisorIDs(ctx context.Context, db database.Database) (*Report, error) {
+	report := &Report{Migration: "supervisor-ids"}
+
+	idsByName, err := userIDsByName(ctx, db)
+	if err != nil {
+		return report, err
+	}
+
+	targets := []struct {
+		collection string
+		nameField  string
+	}{
+		{domain.CollectionStaff, "to_whom"},
+	}
+	for _, collection := range planCollections {
+		targets = append(targets, struct {
+			collection string
+			nameField  string
+		}{collection, "supervisor_name"})
+	}
+
+	for _, target := range targets {
+		if err := resolveSupervisorNames(ctx, db, target.collection, target.nameField, idsByName, report); err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+func userIDsByName(ctx context.Context, db database.Database) (map[string][]primitive.ObjectID, error) {
+	opts := options.Find().SetProjection(bson.M{"_id": 1, "full_name": 1})
+	cursor, err := db.Collection(domain.CollectionStaff).Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	idsByName := map[string][]primitive.ObjectID{}
+	for cursor.Next(ctx) {
+		var user struct {
+			ID       primitive.ObjectID `bson:"_id"`
+			FullName string             `bson:"full_name"`
+		}
+		if err := cursor.Decode(&user); err != nil {
+			return nil, err
+		}
+		idsByName[user.FullName] = append(idsByName[user.FullName], user.ID)
+	}
+
+	return idsByName, nil
+}
+
+func resolveSupervisorNames(ctx context.Context, db database.Database, collectionName, nameField string, idsByName map[string][]primitive.ObjectID, report *Report) error {
+	collection := db.Collection(collectionName)
+
+	// Documents that already carry an ID were migrated by an earlier run.
+	filter := bson.M{
+		"supervisor_id": bson.M{"$exists": false},
+		nameField:       bson.M{"$nin": bson.A{"", nil}},
+	}
+	opts := options.Find().SetProjection(bson.M{"_id": 1, nameField: 1})
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc bson.M
+		if err := cursor.Decode(&doc); err != nil {
+			return err
+		}
+		id, _ := doc["_id"].(primitive.ObjectID)
+		name, _ := doc[nameField].(string)
+
+		issue := Issue{Collection: collectionName, DocumentID: id, Field: nameField, Value: name}
+		candidates := idsByName[name]
+		switch len(candidates) {
+		case 0:
+			issue.Reason = "unresolved: no user has this name"
+			report.Issues = append(report.Issues, issue)
+			continue
+		case 1:
+		default:
+			issue.Reason = "ambiguous: more than one user has this name"
+			report.Issues = append(report.Issues, issue)
+			continue
+		}
+
+		update := bson.M{"$set": bson.M{"supervisor_id": candidates[0]}}
+		if _, err := collection.UpdateOne(ctx, bson.M{"_id": id}, update); err != nil {
+			return err
+		}
+		report.Updated++
+	}
+
+	return nil
+}
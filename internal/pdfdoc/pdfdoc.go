@@ -0,0 +1,190 @@
+// Package pdfdoc renders printable documents: a title block, a grid of
+// labelled fields, sections of text and tables, and signature lines.
+package pdfdoc
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// Document is the content of a PDF document.
+type Document struct {
+	Title      string
+	Subtitle   string
+	Fields     []Field
+	Sections   []Section
+	Signatures []string // Roles signing the document, e.g. "Owner"
+	Footer     string
+}
+
+// Field is a labelled value of the document header.
+type Field struct {
+	Label string
+	Value string
+}
+
+// Section is a titled block of text, a table, or both.
+type Section struct {
+	Title string
+	Text  string
+	Table *Table
+}
+
+// Table is a grid of text. Widths are the shares of the page width taken by
+// each column; columns share it equally when Widths is nil.
+type Table struct {
+	Columns []string
+	Widths  []float64
+	Rows    [][]string
+}
+
+const lineHeight = 5.0
+
+// Renderer lays documents out on A4 pages. The built-in fonts only cover
+// Western European text; FontPath names a UTF-8 TrueType font to use instead,
+// such as one covering Ethiopic.
+type Renderer struct {
+	FontPath string
+}
+
+// Render returns the document as a PDF file.
+func (r Renderer) Render(doc *Document) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle(doc.Title, true)
+	pdf.SetMargins(15, 15, 15)
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AliasNbPages("")
+
+	family, tr := "Helvetica", pdf.UnicodeTranslatorFromDescriptor("")
+	if r.FontPath != "" {
+		pdf.AddUTF8Font("document", "", r.FontPath)
+		pdf.AddUTF8Font("document", "B", r.FontPath)
+		family, tr = "document", func(s string) string { return s }
+	}
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont(family, "", 8)
+		pdf.SetTextColor(110, 110, 110)
+		pdf.CellFormat(0, 4, tr(doc.Footer), "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 4, fmt.Sprintf("Page %d of {nb}", pdf.PageNo()), "", 0, "R", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+	})
+	pdf.AddPage()
+
+	pageWidth, _ := pdf.GetPageSize()
+	left, _, right, _ := pdf.GetMargins()
+	width := pageWidth - left - right
+
+	pdf.SetFont(family, "B", 16)
+	pdf.MultiCell(0, 8, tr(doc.Title), "", "C", false)
+	if doc.Subtitle != "" {
+		pdf.SetFont(family, "", 11)
+		pdf.MultiCell(0, 6, tr(doc.Subtitle), "", "C", false)
+	}
+	pdf.Ln(4)
+
+	labelWidth := 45.0
+	for _, field := range doc.Fields {
+		pdf.SetFont(family, "B", 10)
+		pdf.CellFormat(labelWidth, lineHeight+1, tr(field.Label), "", 0, "L", false, 0, "")
+		pdf.SetFont(family, "", 10)
+		pdf.MultiCell(width-labelWidth, lineHeight+1, tr(field.Value), "", "L", false)
+	}
+
+	for _, section := range doc.Sections {
+		pdf.Ln(4)
+		pdf.SetFont(family, "B", 12)
+		pdf.CellFormat(0, 7, tr(section.Title), "B", 1, "L", false, 0, "")
+		pdf.Ln(2)
+		if section.Text != "" {
+			pdf.SetFont(family, "", 10)
+			pdf.MultiCell(0, lineHeight, tr(section.Text), "", "L", false)
+		}
+		if section.Table != nil {
+			if section.Text != "" {
+				pdf.Ln(2)
+			}
+			drawTable(pdf, section.Table, width, family, tr)
+		}
+	}
+
+	if len(doc.Signatures) > 0 {
+		pdf.Ln(12)
+		pdf.SetFont(family, "", 10)
+		for _, signer := range doc.Signatures {
+			if _, pageHeight := pdf.GetPageSize(); pdf.GetY()+14 > pageHeight-15 {
+				pdf.AddPage()
+			}
+			pdf.CellFormat(width*0.4, 10, tr(signer)+": ______________________", "", 0, "L", false, 0, "")
+			pdf.CellFormat(width*0.35, 10, tr("Signature: ________________"), "", 0, "L", false, 0, "")
+			pdf.CellFormat(width*0.25, 10, tr("Date: __________"), "", 1, "L", false, 0, "")
+			pdf.Ln(4)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func drawTable(pdf *fpdf.Fpdf, table *Table, width float64, family string, tr func(string) string) {
+	widths := make([]float64, len(table.Columns))
+	for i := range widths {
+		if table.Widths != nil {
+			widths[i] = table.Widths[i] * width
+		} else {
+			widths[i] = width / float64(len(widths))
+		}
+	}
+
+	pdf.SetFont(family, "B", 9)
+	pdf.SetFillColor(230, 230, 230)
+	drawRow(pdf, table.Columns, widths, tr, true)
+
+	pdf.SetFont(family, "", 9)
+	for _, row := range table.Rows {
+		drawRow(pdf, row, widths, tr, false)
+	}
+}
+
+// drawRow draws a row of wrapped cells, as tall as its tallest cell. Rows
+// that do not fit on the page start a new one.
+func drawRow(pdf *fpdf.Fpdf, cells []string, widths []float64, tr func(string) string, fill bool) {
+	lines := 1
+	for i, cell := range cells {
+		if i < len(widths) {
+			if n := len(pdf.SplitText(tr(cell), widths[i]-2)); n > lines {
+				lines = n
+			}
+		}
+	}
+	height := float64(lines)*lineHeight + 2
+
+	_, pageHeight := pdf.GetPageSize()
+	_, _, _, bottom := pdf.GetMargins()
+	if pdf.GetY()+height > pageHeight-bottom {
+		pdf.AddPage()
+	}
+
+	left, y := pdf.GetXY()
+	x := left
+	style := "D"
+	if fill {
+		style = "FD"
+	}
+	for i, cell := range cells {
+		if i >= len(widths) {
+			break
+		}
+		pdf.Rect(x, y, widths[i], height, style)
+		pdf.SetXY(x+1, y+1)
+		pdf.MultiCell(widths[i]-2, lineHeight, tr(cell), "", "L", false)
+		x += widths[i]
+	}
+	pdf.SetXY(left, y+height)
+}
@@ -0,0 +1,92 @@
+package tokenutil
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"strings"
+	"testing"
+	"time"
+
+	"plan/domain"
+
+	jwt "github.com/golang-jwt/jwt/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const testSecret = "access-secret"
+
+func TestCreateAndVerifyToken(t *testing.T) {
+	user := &domain.User{ID: primitive.NewObjectID(), Full_Name: "Abebe Kebede", Role: domain.RoleDirector}
+
+	tests := []struct {
+		name   string
+		create func(*domain.User, string, int) (string, *domain.JwtCustomClaims, error)
+		kind   string
+	}{
+		{"access", CreateAccessToken, domain.AccessTokenKind},
+		{"refresh", CreateRefreshToken, domain.RefreshTokenKind},
+	}
+	for _, tt := range tests {
+		token, issued, err := tt.create(user, testSecret, 1)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		claims, err := VerifyToken(token, testSecret)
+		if err != nil {
+			t.Fatalf("%s: VerifyToken: %v", tt.name, err)
+		}
+		if claims.Kind != tt.kind || claims.UserID != user.ID || claims.Role != user.Role {
+			t.Errorf("%s: claims = %+v", tt.name, claims)
+		}
+		if claims.Id == "" || claims.Id != issued.Id {
+			t.Errorf("%s: jti = %q, issued %q", tt.name, claims.Id, issued.Id)
+		}
+	}
+}
+
+func TestVerifyTokenRejects(t *testing.T) {
+	claims := func(expiresAt time.Time) *domain.JwtCustomClaims {
+		return &domain.JwtCustomClaims{
+			UserID: primitive.NewObjectID(),
+			Kind:   domain.AccessTokenKind,
+			StandardClaims: jwt.StandardClaims{
+				Id:        primitive.NewObjectID().Hex(),
+				IssuedAt:  time.Now().Unix(),
+				ExpiresAt: expiresAt.Unix(),
+			},
+		}
+	}
+	sign := func(method jwt.SigningMethod, key interface{}, c *domain.JwtCustomClaims) string {
+		token, err := jwt.NewWithClaims(method, c).SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid := sign(jwt.SigningMethodHS256, []byte(testSecret), claims(time.Now().Add(time.Hour)))
+	parts := strings.Split(valid, ".")
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"wrong secret", sign(jwt.SigningMethodHS256, []byte("other-secret"), claims(time.Now().Add(time.Hour)))},
+		{"expired", sign(jwt.SigningMethodHS256, []byte(testSecret), claims(time.Now().Add(-time.Minute)))},
+		{"alg none", sign(jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, claims(time.Now().Add(time.Hour)))},
+		{"RSA signed", sign(jwt.SigningMethodRS256, rsaKey, claims(time.Now().Add(time.Hour)))},
+		{"tampered payload", parts[0] + "." + parts[1] + "x." + parts[2]},
+		{"no signature", parts[0] + "." + parts[1] + "."},
+		{"garbage", "not-a-token"},
+		{"empty", ""},
+	}
+	for _, tt := range tests {
+		if claims, err := VerifyToken(tt.token, testSecret); err == nil {
+			t.Errorf("%s: VerifyToken accepted the token: %+v", tt.name, claims)
+		}
+	}
+}
@@ -1,45 +1,47 @@
 package tokenutil
 
-// import (
-// 	"time"
-// 	"blog/domain"
-// 	jwt "github.com/golang-jwt/jwt/v4"
-// )
+import (
+	"plan/domain"
+	"time"
 
-// func CreateAccessToken(user *domain.AuthSignup, secret string, expiry int) (accessToken string, err error) {
-// 	exp := time.Now().Add(time.Hour * time.Duration(expiry)).Unix()
-// 	claims := &domain.JwtCustomClaims{
-// 		UserID: user.UserID,
-// 		Email:   user.Email,
-// 		Username:   user.Username,
-// 		Role:     user.Role,
-// 		StandardClaims: jwt.StandardClaims{
-// 			ExpiresAt: exp,
-// 		},
-// 	}
-// 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-// 	t, err := token.SignedString([]byte(secret))
-// 	if err != nil {
-// 		return "", err
-// 	}
-// 	return t, err
-// }
+	jwt "github.com/golang-jwt/jwt/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
 
-// func CreateRefreshToken(user *domain.AuthSignup, secret string, expiry int) (refreshToken string, err error) {
-// 	claimsRefresh := &domain.JwtCustomClaims{
-// 		UserID: user.UserID,
-// 		Email:   user.Email,
-// 		Username: user.Username,
-// 		Role:     user.Role,
-// 		StandardClaims: jwt.StandardClaims{
-// 			ExpiresAt: time.Now().Add(time.Hour * time.Duration(expiry)).Unix(),
-// 		},
-// 	}
-// 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claimsRefresh)
-// 	rt, err := token.SignedString([]byte(secret))
-// 	if err != nil {
-// 		return "", err
-// 	}
-// 	return rt, err
-// }
+// CreateAccessToken signs a short-lived access token for the user.
+func CreateAccessToken(user *domain.User, secret string, expiry int) (string, *domain.JwtCustomClaims, error) {
+	return createToken(user, domain.AccessTokenKind, secret, expiry)
+}
 
+// CreateRefreshToken signs a refresh token for the user. The returned claims
+// carry the jti that has to be stored server-side so the token can be rotated.
+func CreateRefreshToken(user *domain.User, secret string, expiry int) (string, *domain.JwtCustomClaims, error) {
+	return createToken(user, domain.RefreshTokenKind, secret, expiry)
+}
+
+func createToken(user *domain.User, kind string, secret string, expiry int) (string, *domain.JwtCustomClaims, error) {
+	now := time.Now()
+	claims := &domain.JwtCustomClaims{
+		UserID:       user.ID,
+		Full_Name:    user.Full_Name,
+		Email:        user.Email,
+		Role:         user.Role,
+		To_whom:      user.To_whom,
+		SupervisorID: user.SupervisorID,
+		Status:       user.Verify,
+		Kind:         kind,
+		Calendar:     user.Calendar,
+		StandardClaims: jwt.StandardClaims{
+			Id:        primitive.NewObjectID().Hex(),
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(time.Hour * time.Duration(expiry)).Unix(),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	t, err := token.SignedString([]byte(secret))
+	if err != nil {
+		return "", nil, err
+	}
+	return t, claims, nil
+}
@@ -48,46 +48,34 @@ func GenerateOTP() string {
 }
 
 // A function that checks if a the logged in user can manipulate the target user.
+// Users may view their own account, supervisors may manipulate the users that
+// report to them and the planning office may manipulate everyone.
 func CanManipulateUser(claims *domain.JwtCustomClaims, user *domain.User, manip string) *domain.Error {
-	// If the user is a regular user, they can only manipulate their own account.
-	if claims.Role == "user" {
-		if user.ID != claims.UserID {
-			var message string
-			if manip == "add" {
-				message = "A User cannot add a new user"
-			} else {
-				message = "A User cannot " + manip + " another user"
-			}
-
-			return &domain.Error{
-				Err:        errors.New("unauthorized"),
-				StatusCode: http.StatusForbidden,
-				Message:    message,
-			}
-		}
-
+	// The planning office sits at the top of the hierarchy.
+	if claims.Role == domain.RolePlanningOffice {
 		return nil
 	}
 
-	// If the user is an admin, they can manipulate all users except root user and other admin users.
-	if claims.Role == "admin" {
-		if user.Role == "root" {
-			return &domain.Error{
-				Err:        errors.New("forbidden"),
-				StatusCode: http.StatusForbidden,
-				Message:    "Cannot " + manip + " root user",
-			}
+	if user.ID == claims.UserID {
+		if manip == "view" {
+			return nil
 		}
 
-		if user.Role == "admin" && claims.UserID != user.ID {
-			return &domain.Error{
-				Err:        errors.New("unauthorized"),
-				StatusCode: http.StatusForbidden,
-				Message:    "Admin cannot " + manip + " another admin user",
-			}
+		return &domain.Error{
+			Err:        errors.New("forbidden"),
+			StatusCode: http.StatusForbidden,
+			Message:    "A User cannot " + manip + " their own account",
+		}
+	}
+
+	// Otherwise only the direct supervisor may act on the user.
+	if user.SupervisorID != claims.UserID {
+		return &domain.Error{
+			Err:        errors.New("unauthorized"),
+			StatusCode: http.StatusForbidden,
+			Message:    "Only the supervisor of a user can " + manip + " them",
 		}
 	}
 
-	// If the user is a root user, they can manipulate all users.
 	return nil
 }
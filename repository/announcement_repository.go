@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"plan/database"
+	"plan/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type announcementRepository struct {
+	database   database.Database
+	collection string
+}
+
+func NewAnnouncementRepository(db database.Database, collection string) domain.AnnouncementRepository {
+	return &announcementRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (ar *announcementRepository) DeleteAnnouncement(ctx context.Context, id primitive.ObjectID) error {
+	collection := ar.database.Collection(ar.collection)
+
+	// Delete the document with the given ID
+	result, err := collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if result == 0 {
+		return errors.New("announcement not found")
+	}
+
+	return nil
+}
+
+func (ar *announcementRepository) GetAllAnnouncements(ctx context.Context) ([]domain.Announcement, error) {
+	collection := ar.database.Collection(ar.collection)
+
+	var announcements []domain.Announcement
+	cursor, err := collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var announcement domain.Announcement
+		if err := cursor.Decode(&announcement); err != nil {
+			return nil, err
+		}
+		announcements = append(announcements, announcement)
+	}
+
+	return announcements, nil
+}
+
+func (ar *announcementRepository) CreateAnnouncement(ctx context.Context, announcement *domain.Announcement) error {
+	collection := ar.database.Collection(ar.collection)
+	_, err := collection.InsertOne(ctx, announcement)
+	return err
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"plan/database"
+	"plan/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type approvalPolicyRepository struct {
+	database   database.Database
+	collection string
+}
+
+func NewApprovalPolicyRepository(db database.Database, collection string) domain.ApprovalPolicyRepository {
+	return &approvalPolicyRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (ar *approvalPolicyRepository) ListPolicies(ctx context.Context) ([]domain.ApprovalPolicy, error) {
+	cursor, err := ar.database.Collection(ar.collection).Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var policies []domain.ApprovalPolicy
+	if err := cursor.All(ctx, &policies); err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+func (ar *approvalPolicyRepository) GetPolicy(ctx context.Context, ownerRole string) (*domain.ApprovalPolicy, error) {
+	var policy domain.ApprovalPolicy
+	err := ar.database.Collection(ar.collection).FindOne(ctx, bson.M{"owner_role": ownerRole}).Decode(&policy)
+	if err != nil {
+		return nil, errors.New("approval policy not found")
+	}
+	return &policy, nil
+}
+
+// SavePolicy inserts the policy or replaces the one already stored for its
+// owner role.
+func (ar *approvalPolicyRepository) SavePolicy(ctx context.Context, policy *domain.ApprovalPolicy) error {
+	policy.UpdatedAt = time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"final_approver_role": policy.FinalApproverRole,
+			"updated_at":          policy.UpdatedAt,
+		},
+	}
+	_, err := ar.database.Collection(ar.collection).UpdateOne(ctx, bson.M{"owner_role": policy.OwnerRole}, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (ar *approvalPolicyRepository) DeletePolicy(ctx context.Context, ownerRole string) error {
+	result, err := ar.database.Collection(ar.collection).DeleteOne(ctx, bson.M{"owner_role": ownerRole})
+	if err != nil {
+		return err
+	}
+	if result == 0 {
+		return errors.New("approval policy not found")
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"plan/database"
+	"plan/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type commentRepository struct {
+	database   database.Database
+	collection string
+}
+
+func NewCommentRepository(db database.Database, collection string) domain.CommentRepository {
+	return &commentRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (cr *commentRepository) CreateComment(ctx context.Context, comment *domain.Comment) error {
+	_, err := cr.database.Collection(cr.collection).InsertOne(ctx, comment)
+	return err
+}
+
+// FetchSupervisorComments lists the comments left on the plans.
+func (cr *commentRepository) FetchSupervisorComments(ctx context.Context, planIDs []primitive.ObjectID) ([]domain.Comment, error) {
+	filter := bson.M{"plan_id": bson.M{"$in": planIDs}}
+
+	cursor, err := cr.database.Collection(cr.collection).Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	defer cursor.Close(ctx)
+
+	var comments []domain.Comment
+	if err := cursor.All(ctx, &comments); err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+func (cr *commentRepository) FetchCommentsByPlanID(ctx context.Context, planID primitive.ObjectID) ([]domain.Comment, error) {
+	// Filter to match the specified PlanID
+	filter := bson.M{"plan_id": planID}
+
+	// Find comments matching the PlanID
+	cursor, err := cr.database.Collection(cr.collection).Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	defer cursor.Close(ctx)
+
+	var comments []domain.Comment
+	if err := cursor.All(ctx, &comments); err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"plan/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Queries shared by the plan and report dashboards and exports.
+
+// statusFacet counts documents by status.
+func statusFacet() bson.A {
+	return bson.A{
+		bson.M{"$group": bson.M{"_id": "$status", "count": bson.M{"$sum": 1}}},
+		bson.M{"$project": bson.M{"_id": 0, "status": "$_id", "count": 1}},
+		bson.M{"$sort": bson.M{"status": 1}},
+	}
+}
+
+// quarterFacet counts documents by fiscal year and quarter.
+func quarterFacet() bson.A {
+	return bson.A{
+		bson.M{"$match": bson.M{"fiscal_year": bson.M{"$gt": 0}}},
+		bson.M{"$group": bson.M{
+			"_id":   bson.M{"fiscal_year": "$fiscal_year", "quarter": "$quarter"},
+			"count": bson.M{"$sum": 1},
+		}},
+		bson.M{"$project": bson.M{"_id": 0, "fiscal_year": "$_id.fiscal_year", "quarter": "$_id.quarter", "count": 1}},
+		bson.M{"$sort": bson.D{{Key: "fiscal_year", Value: 1}, {Key: "quarter", Value: 1}}},
+	}
+}
+
+// turnaroundFacet measures the hours from the first submission of approved
+// documents to their latest approval, from the transition log.
+func turnaroundFacet() bson.A {
+	transitionTime := func(status string, accumulator string) bson.M {
+		return bson.M{accumulator: bson.M{"$map": bson.M{
+			"input": bson.M{"$filter": bson.M{
+				"input": "$transitions",
+				"cond":  bson.M{"$eq": bson.A{"$$this.to", status}},
+			}},
+			"in": "$$this.created_at",
+		}}}
+	}
+	hours := bson.M{"$divide": bson.A{bson.M{"$subtract": bson.A{"$approved_at", "$submitted_at"}}, 3600000}}
+
+	return bson.A{
+		bson.M{"$match": bson.M{"status": bson.M{"$in": bson.A{domain.StatusApproved, domain.StatusClosed}}}},
+		bson.M{"$lookup": bson.M{
+			"from":         domain.CollectionTransition,
+			"localField":   "_id",
+			"foreignField": "document_id",
+			"as":           "transitions",
+		}},
+		bson.M{"$project": bson.M{
+			"submitted_at": transitionTime(domain.StatusSubmitted, "$min"),
+			"approved_at":  transitionTime(domain.StatusApproved, "$max"),
+		}},
+		bson.M{"$match": bson.M{"submitted_at": bson.M{"$ne": nil}, "approved_at": bson.M{"$ne": nil}}},
+		bson.M{"$group": bson.M{
+			"_id":           nil,
+			"approved":      bson.M{"$sum": 1},
+			"average_hours": bson.M{"$avg": hours},
+			"min_hours":     bson.M{"$min": hours},
+			"max_hours":     bson.M{"$max": hours},
+		}},
+	}
+}
+
+// countFacet counts the documents reaching it.
+func countFacet() bson.A {
+	return bson.A{bson.M{"$count": "count"}}
+}
+
+type facetCount struct {
+	Count int `bson:"count"`
+}
+
+// countOf reads the result of a countFacet.
+func countOf(counts []facetCount) int {
+	if len(counts) == 0 {
+		return 0
+	}
+	return counts[0].Count
+}
+
+// exportQuery turns an export filter into a query. ownerField names the field
+// holding the owner of a document.
+func exportQuery(filter domain.ExportFilter, ownerField string) bson.M {
+	query := bson.M{}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+	if filter.FiscalYear != 0 {
+		query["fiscal_year"] = filter.FiscalYear
+	}
+	if filter.Quarter != 0 {
+		query["quarter"] = filter.Quarter
+	}
+
+	if filter.DepartmentID != nil {
+		owners := bson.A{}
+		for _, id := range filter.OwnerIDs {
+			owners = append(owners, id)
+		}
+		query[ownerField] = bson.M{"$in": owners}
+	} else if filter.OwnerID != nil {
+		query[ownerField] = *filter.OwnerID
+	}
+	return query
+}
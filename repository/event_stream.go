@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"plan/database"
+	"plan/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// eventRetention is how long published events stay in their collection.
+	// They only have to outlive a change stream reconnecting.
+	eventRetention = time.Hour
+
+	eventStreamRetryDelay = 5 * time.Second
+)
+
+// EventStream is an EventHub shared by every replica of the server. Events
+// are published by inserting them into a collection, and each replica's Run
+// relays the inserted ones to its own subscribers through a change stream.
+// Events published in a transaction are only relayed once it commits.
+// Change streams need a replica set.
+type EventStream struct {
+	database   database.Database
+	collection string
+	local      domain.EventHub
+}
+
+// NewEventStream returns an EventStream relaying events to the subscribers
+// of the local hub.
+func NewEventStream(db database.Database, collection string, local domain.EventHub) *EventStream {
+	return &EventStream{
+		database:   db,
+		collection: collection,
+		local:      local,
+	}
+}
+
+func (es *EventStream) Publish(ctx context.Context, events ...domain.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	documents := make([]interface{}, len(events))
+	for i := range events {
+		documents[i] = events[i]
+	}
+	_, err := es.database.Collection(es.collection).InsertMany(ctx, documents)
+	return err
+}
+
+func (es *EventStream) Subscribe(userID primitive.ObjectID) (<-chan domain.Event, func()) {
+	return es.local.Subscribe(userID)
+}
+
+// Run relays published events until ctx is done, reconnecting the change
+// stream when it breaks.
+func (es *EventStream) Run(ctx context.Context) {
+	go es.expire(ctx)
+
+	var resumeToken bson.Raw
+	for {
+		err := es.watch(ctx, &resumeToken)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Println("event stream interrupted:", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(eventStreamRetryDelay):
+		}
+	}
+}
+
+// watch relays inserted events, resuming after the last relayed one. A
+// token the server no longer knows is dropped, so the next try starts from
+// now instead of failing forever.
+func (es *EventStream) watch(ctx context.Context, resumeToken *bson.Raw) error {
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: bson.M{"operationType": "insert"}}}}
+	opts := options.ChangeStream()
+	if *resumeToken != nil {
+		opts.SetResumeAfter(*resumeToken)
+	}
+
+	stream, err := es.database.Collection(es.collection).Watch(ctx, pipeline, opts)
+	if err != nil {
+		*resumeToken = nil
+		return err
+	}
+	defer stream.Close(context.Background())
+
+	for stream.Next(ctx) {
+		var change struct {
+			FullDocument domain.Event `bson:"fullDocument"`
+		}
+		if err := stream.Decode(&change); err != nil {
+			return err
+		}
+		if err := es.local.Publish(ctx, change.FullDocument); err != nil {
+			return err
+		}
+		*resumeToken = stream.ResumeToken()
+	}
+	return stream.Err()
+}
+
+// expire deletes events older than eventRetention.
+func (es *EventStream) expire(ctx context.Context) {
+	ticker := time.NewTicker(eventRetention / 4)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		cutoff := time.Now().Add(-eventRetention)
+		if _, err := es.database.Collection(es.collection).DeleteMany(ctx, bson.M{"created_at": bson.M{"$lt": cutoff}}); err != nil {
+			log.Println("failed to expire events:", err)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"plan/database"
+	"plan/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type fiscalYearRepository struct {
+	database   database.Database
+	collection string
+}
+
+func NewFiscalYearRepository(db database.Database, collection string) domain.FiscalYearRepository {
+	return &fiscalYearRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (fr *fiscalYearRepository) CreateFiscalYear(ctx context.Context, year *domain.FiscalYear) error {
+	year.ID = primitive.NewObjectID()
+	year.CreatedAt = time.Now()
+	year.UpdatedAt = year.CreatedAt
+	_, err := fr.database.Collection(fr.collection).InsertOne(ctx, year)
+	return err
+}
+
+func (fr *fiscalYearRepository) UpdateFiscalYear(ctx context.Context, year *domain.FiscalYear) error {
+	update := bson.M{
+		"$set": bson.M{
+			"calendar":   year.Calendar,
+			"start_date": year.StartDate,
+			"end_date":   year.EndDate,
+			"periods":    year.Periods,
+			"updated_at": time.Now(),
+		},
+	}
+
+	result, err := fr.database.Collection(fr.collection).UpdateOne(ctx, bson.M{"year": year.Year}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("fiscal year not found")
+	}
+	return nil
+}
+
+func (fr *fiscalYearRepository) DeleteFiscalYear(ctx context.Context, year int) error {
+	result, err := fr.database.Collection(fr.collection).DeleteOne(ctx, bson.M{"year": year})
+	if err != nil {
+		return err
+	}
+	if result == 0 {
+		return errors.New("fiscal year not found")
+	}
+	return nil
+}
+
+func (fr *fiscalYearRepository) GetFiscalYear(ctx context.Context, year int) (*domain.FiscalYear, error) {
+	var fiscalYear domain.FiscalYear
+	err := fr.database.Collection(fr.collection).FindOne(ctx, bson.M{"year": year}).Decode(&fiscalYear)
+	if err != nil {
+		return nil, errors.New("fiscal year not found")
+	}
+	return &fiscalYear, nil
+}
+
+// FindByDate returns the fiscal year covering date.
+func (fr *fiscalYearRepository) FindByDate(ctx context.Context, date time.Time) (*domain.FiscalYear, error) {
+	filter := bson.M{
+		"start_date": bson.M{"$lte": date},
+		"end_date":   bson.M{"$gt": date},
+	}
+
+	var fiscalYear domain.FiscalYear
+	err := fr.database.Collection(fr.collection).FindOne(ctx, filter).Decode(&fiscalYear)
+	if err != nil {
+		return nil, errors.New("fiscal year not found")
+	}
+	return &fiscalYear, nil
+}
+
+// FindOverlapping lists the fiscal years sharing a day with [start, end).
+func (fr *fiscalYearRepository) FindOverlapping(ctx context.Context, start, end time.Time) ([]domain.FiscalYear, error) {
+	filter := bson.M{
+		"start_date": bson.M{"$lt": end},
+		"end_date":   bson.M{"$gt": start},
+	}
+
+	cursor, err := fr.database.Collection(fr.collection).Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var years []domain.FiscalYear
+	if err := cursor.All(ctx, &years); err != nil {
+		return nil, err
+	}
+	return years, nil
+}
+
+func (fr *fiscalYearRepository) ListFiscalYears(ctx context.Context) ([]domain.FiscalYear, error) {
+	cursor, err := fr.database.Collection(fr.collection).Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"year": -1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	years := []domain.FiscalYear{}
+	if err := cursor.All(ctx, &years); err != nil {
+		return nil, err
+	}
+	return years, nil
+}
+
+func (fr *fiscalYearRepository) SetPeriodOpen(ctx context.Context, year, quarter int, open bool) error {
+	filter := bson.M{"year": year, "periods.quarter": quarter}
+	update := bson.M{"$set": bson.M{"periods.$.open": open, "updated_at": time.Now()}}
+
+	result, err := fr.database.Collection(fr.collection).UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("period not found")
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"plan/database"
+	"plan/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// indexes are the indexes each collection needs, created at startup.
+var indexes = map[string][]mongo.IndexModel{
+	// Records are dropped once the tokens they cover have expired.
+	domain.CollectionRefreshToken: {
+		{Keys: bson.D{{Key: "token_id", Value: 1}}},
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	},
+	domain.CollectionPillar: {
+		{Keys: bson.D{{Key: "fiscal_year", Value: 1}, {Key: "code", Value: 1}}, Options: options.Index().SetUnique(true)},
+	},
+	// One report per plan and period. Archived reports and those from before
+	// fiscal periods are left out; $in in the filter needs MongoDB 6.0.
+	domain.CollectionReport: {
+		{
+			Keys: bson.D{{Key: "plan_id", Value: 1}, {Key: "fiscal_year", Value: 1}, {Key: "quarter", Value: 1}},
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{
+				"fiscal_year": bson.M{"$gt": 0},
+				"status": bson.M{"$in": bson.A{
+					domain.StatusDraft, domain.StatusSubmitted, domain.StatusUnderReview,
+					domain.StatusRevisionRequested, domain.StatusApproved, domain.StatusClosed,
+				}},
+			}),
+		},
+	},
+	domain.CollectionRevision: {
+		{Keys: bson.D{{Key: "document_id", Value: 1}, {Key: "number", Value: 1}}, Options: options.Index().SetUnique(true)},
+	},
+	domain.CollectionRoleRule: {
+		{Keys: bson.D{{Key: "role", Value: 1}}, Options: options.Index().SetUnique(true)},
+	},
+	domain.CollectionRevokedToken: {
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	},
+}
+
+// EnsureIndexes creates the indexes that are missing. Existing indexes are
+// left alone.
+func EnsureIndexes(ctx context.Context, db database.Database) error {
+	for collection, models := range indexes {
+		if err := db.Collection(collection).CreateIndexes(ctx, models); err != nil {
+			return fmt.Errorf("creating indexes on %s: %w", collection, err)
+		}
+	}
+	return nil
+}
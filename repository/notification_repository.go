@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"plan/database"
+	"plan/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type notificationRepository struct {
+	database   database.Database
+	collection string
+}
+
+func NewNotificationRepository(db database.Database, collection string) domain.NotificationRepository {
+	return &notificationRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+// Create inserts the notifications. Keyed ones are upserted on the recipient
+// and key, so repeating an event, such as a reminder, does not repeat them.
+func (nr *notificationRepository) Create(ctx context.Context, notifications []domain.Notification) ([]domain.Notification, error) {
+	collection := nr.database.Collection(nr.collection)
+	now := time.Now()
+
+	var created []domain.Notification
+	var documents []interface{}
+	for i := range notifications {
+		notification := notifications[i]
+		if notification.ID.IsZero() {
+			notification.ID = primitive.NewObjectID()
+		}
+		notification.Read = false
+		notification.ReadAt = nil
+		notification.CreatedAt = now
+
+		if notification.Key == "" {
+			created = append(created, notification)
+			documents = append(documents, notification)
+			continue
+		}
+		result, err := collection.UpdateOne(ctx,
+			bson.M{"recipient_id": notification.RecipientID, "key": notification.Key},
+			bson.M{"$setOnInsert": notification},
+			options.Update().SetUpsert(true),
+		)
+		if err != nil {
+			return nil, err
+		}
+		if result.UpsertedCount > 0 {
+			created = append(created, notification)
+		}
+	}
+
+	if len(documents) > 0 {
+		if _, err := collection.InsertMany(ctx, documents); err != nil {
+			return nil, err
+		}
+	}
+	return created, nil
+}
+
+// List returns the recipient's newest notifications first.
+func (nr *notificationRepository) List(ctx context.Context, recipientID primitive.ObjectID, unreadOnly bool, limit int64) ([]domain.Notification, error) {
+	filter := bson.M{"recipient_id": recipientID}
+	if unreadOnly {
+		filter["read"] = false
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).SetLimit(limit)
+
+	cursor, err := nr.database.Collection(nr.collection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	notifications := []domain.Notification{}
+	if err := cursor.All(ctx, &notifications); err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+func (nr *notificationRepository) CountUnread(ctx context.Context, recipientID primitive.ObjectID) (int64, error) {
+	return nr.database.Collection(nr.collection).CountDocuments(ctx, bson.M{"recipient_id": recipientID, "read": false})
+}
+
+func (nr *notificationRepository) SetRead(ctx context.Context, recipientID, id primitive.ObjectID, read bool, at time.Time) error {
+	update := bson.M{"$set": bson.M{"read": true, "read_at": at}}
+	if !read {
+		update = bson.M{"$set": bson.M{"read": false}, "$unset": bson.M{"read_at": ""}}
+	}
+
+	result, err := nr.database.Collection(nr.collection).UpdateOne(ctx, bson.M{"_id": id, "recipient_id": recipientID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrNotificationNotFound
+	}
+	return nil
+}
+
+func (nr *notificationRepository) MarkAllRead(ctx context.Context, recipientID primitive.ObjectID, at time.Time) (int64, error) {
+	result, err := nr.database.Collection(nr.collection).UpdateMany(ctx,
+		bson.M{"recipient_id": recipientID, "read": false},
+		bson.M{"$set": bson.M{"read": true, "read_at": at}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"plan/database"
+	"plan/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type orgUnitRepository struct {
+	database   database.Database
+	collection string
+}
+
+func NewOrgUnitRepository(db database.Database, collection string) domain.OrgUnitRepository {
+	return &orgUnitRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (or *orgUnitRepository) CreateUnit(ctx context.Context, unit *domain.OrgUnit) error {
+	unit.ID = primitive.NewObjectID()
+	unit.CreatedAt = time.Now()
+	unit.UpdatedAt = unit.CreatedAt
+	_, err := or.database.Collection(or.collection).InsertOne(ctx, unit)
+	return err
+}
+
+func (or *orgUnitRepository) UpdateUnit(ctx context.Context, unit *domain.OrgUnit) error {
+	update := bson.M{
+		"$set": bson.M{
+			"name":       unit.Name,
+			"kind":       unit.Kind,
+			"parent_id":  unit.ParentID,
+			"head_id":    unit.HeadID,
+			"updated_at": time.Now(),
+		},
+	}
+
+	result, err := or.database.Collection(or.collection).UpdateOne(ctx, bson.M{"_id": unit.ID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("org unit not found")
+	}
+	return nil
+}
+
+func (or *orgUnitRepository) DeleteUnit(ctx context.Context, unitID primitive.ObjectID) error {
+	result, err := or.database.Collection(or.collection).DeleteOne(ctx, bson.M{"_id": unitID})
+	if err != nil {
+		return err
+	}
+	if result == 0 {
+		return errors.New("org unit not found")
+	}
+	return nil
+}
+
+func (or *orgUnitRepository) GetUnitByID(ctx context.Context, unitID primitive.ObjectID) (*domain.OrgUnit, error) {
+	var unit domain.OrgUnit
+	err := or.database.Collection(or.collection).FindOne(ctx, bson.M{"_id": unitID}).Decode(&unit)
+	if err != nil {
+		return nil, errors.New("org unit not found")
+	}
+	return &unit, nil
+}
+
+func (or *orgUnitRepository) ListUnits(ctx context.Context) ([]domain.OrgUnit, error) {
+	cursor, err := or.database.Collection(or.collection).Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"name": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var units []domain.OrgUnit
+	if err := cursor.All(ctx, &units); err != nil {
+		return nil, err
+	}
+	return units, nil
+}
+
+func (or *orgUnitRepository) CountChildren(ctx context.Context, unitID primitive.ObjectID) (int64, error) {
+	return or.database.Collection(or.collection).CountDocuments(ctx, bson.M{"parent_id": unitID})
+}
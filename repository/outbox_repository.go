@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"plan/database"
+	"plan/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// claimTries bounds how often ClaimDue looks for another message when other
+// workers keep claiming the one it found first.
+const claimTries = 5
+
+type outboxRepository struct {
+	database   database.Database
+	collection string
+}
+
+func NewOutboxRepository(db database.Database, collection string) domain.OutboxRepository {
+	return &outboxRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (or *outboxRepository) Enqueue(ctx context.Context, message *domain.OutboxMessage) error {
+	now := time.Now()
+	if message.ID.IsZero() {
+		message.ID = primitive.NewObjectID()
+	}
+	message.Status = domain.OutboxPending
+	message.CreatedAt = now
+	if message.NextAttemptAt.IsZero() {
+		message.NextAttemptAt = now
+	}
+
+	_, err := or.database.Collection(or.collection).InsertOne(ctx, message)
+	return err
+}
+
+// ClaimDue leases a message by moving its next attempt past the lease. The
+// update only matches while the message is still due, so two workers never
+// claim the same attempt.
+func (or *outboxRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*domain.OutboxMessage, error) {
+	collection := or.database.Collection(or.collection)
+	due := bson.M{"status": domain.OutboxPending, "next_attempt_at": bson.M{"$lte": now}}
+	opts := options.Find().SetSort(bson.M{"next_attempt_at": 1}).SetLimit(1)
+
+	for try := 0; try < claimTries; try++ {
+		cursor, err := collection.Find(ctx, due, opts)
+		if err != nil {
+			return nil, err
+		}
+		var messages []domain.OutboxMessage
+		err = cursor.All(ctx, &messages)
+		cursor.Close(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(messages) == 0 {
+			return nil, nil
+		}
+
+		message := messages[0]
+		leasedUntil := now.Add(lease)
+		result, err := collection.UpdateOne(ctx,
+			bson.M{"_id": message.ID, "status": domain.OutboxPending, "next_attempt_at": message.NextAttemptAt},
+			bson.M{"$set": bson.M{"next_attempt_at": leasedUntil}, "$inc": bson.M{"attempts": 1}},
+		)
+		if err != nil {
+			return nil, err
+		}
+		if result.MatchedCount == 1 {
+			message.NextAttemptAt = leasedUntil
+			message.Attempts++
+			return &message, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (or *outboxRepository) MarkSent(ctx context.Context, id primitive.ObjectID, sentAt time.Time) error {
+	_, err := or.database.Collection(or.collection).UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"status": domain.OutboxSent, "sent_at": sentAt}, "$unset": bson.M{"last_error": ""}},
+	)
+	return err
+}
+
+func (or *outboxRepository) MarkFailed(ctx context.Context, id primitive.ObjectID, lastError string, next *time.Time) error {
+	set := bson.M{"last_error": lastError}
+	if next != nil {
+		set["next_attempt_at"] = *next
+	} else {
+		set["status"] = domain.OutboxDead
+	}
+
+	_, err := or.database.Collection(or.collection).UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": set})
+	return err
+}
+
+func (or *outboxRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*domain.OutboxMessage, error) {
+	var message domain.OutboxMessage
+	err := or.database.Collection(or.collection).FindOne(ctx, bson.M{"_id": id}).Decode(&message)
+	if err != nil {
+		return nil, domain.ErrOutboxNotFound
+	}
+	return &message, nil
+}
+
+// List returns the newest messages first, of one status or of all when
+// status is empty.
+func (or *outboxRepository) List(ctx context.Context, status string, limit int64) ([]domain.OutboxMessage, error) {
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+	opts := options.Find().SetSort(bson.M{"created_at": -1}).SetLimit(limit)
+
+	cursor, err := or.database.Collection(or.collection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	messages := []domain.OutboxMessage{}
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+func (or *outboxRepository) Requeue(ctx context.Context, id primitive.ObjectID, now time.Time) error {
+	result, err := or.database.Collection(or.collection).UpdateOne(ctx,
+		bson.M{"_id": id, "status": domain.OutboxDead},
+		bson.M{"$set": bson.M{"status": domain.OutboxPending, "attempts": 0, "next_attempt_at": now}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrOutboxNotDead
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"plan/database"
+	"plan/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type fakeDatabase struct {
+	database.Database
+	collection *fakeOutboxCollection
+}
+
+func (d *fakeDatabase) Collection(string) database.Collection {
+	return d.collection
+}
+
+// fakeOutboxCollection finds the same due message every time and matches
+// claims as told by matches, standing in for other workers winning them.
+type fakeOutboxCollection struct {
+	database.Collection
+	due     []domain.OutboxMessage
+	matches []int64
+	finds   int
+	filters []bson.M
+	updates []bson.M
+}
+
+func (c *fakeOutboxCollection) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (database.Cursor, error) {
+	c.finds++
+	return &fakeCursor{messages: c.due}, nil
+}
+
+func (c *fakeOutboxCollection) UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	c.filters = append(c.filters, filter.(bson.M))
+	c.updates = append(c.updates, update.(bson.M))
+	matched := c.matches[0]
+	c.matches = c.matches[1:]
+	return &mongo.UpdateResult{MatchedCount: matched, ModifiedCount: matched}, nil
+}
+
+type fakeCursor struct {
+	database.Cursor
+	messages []domain.OutboxMessage
+}
+
+func (c *fakeCursor) All(ctx context.Context, result interface{}) error {
+	*result.(*[]domain.OutboxMessage) = append([]domain.OutboxMessage(nil), c.messages...)
+	return nil
+}
+
+func (c *fakeCursor) Close(ctx context.Context) error {
+	return nil
+}
+
+func TestOutboxClaimDue(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	due := domain.OutboxMessage{
+		ID:            primitive.NewObjectID(),
+		Kind:          domain.OutboxMail,
+		Status:        domain.OutboxPending,
+		Attempts:      2,
+		NextAttemptAt: now.Add(-time.Minute),
+	}
+
+	tests := []struct {
+		name    string
+		due     []domain.OutboxMessage
+		matches []int64
+		claimed bool
+		finds   int
+	}{
+		{"nothing due", nil, nil, false, 1},
+		{"claimed", []domain.OutboxMessage{due}, []int64{1}, true, 1},
+		{"claimed after losing a race", []domain.OutboxMessage{due}, []int64{0, 0, 1}, true, 3},
+		{"always lost", []domain.OutboxMessage{due}, []int64{0, 0, 0, 0, 0}, false, claimTries},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collection := &fakeOutboxCollection{due: tt.due, matches: tt.matches}
+			outbox := NewOutboxRepository(&fakeDatabase{collection: collection}, domain.CollectionOutbox)
+
+			message, err := outbox.ClaimDue(context.Background(), now, 2*time.Minute)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (message != nil) != tt.claimed {
+				t.Fatalf("claimed = %v, want %v", message != nil, tt.claimed)
+			}
+			if collection.finds != tt.finds {
+				t.Errorf("looked for due messages %d times, want %d", collection.finds, tt.finds)
+			}
+			if !tt.claimed {
+				return
+			}
+
+			if message.Attempts != due.Attempts+1 || !message.NextAttemptAt.Equal(now.Add(2*time.Minute)) {
+				t.Errorf("claimed message has %d attempts, next at %v", message.Attempts, message.NextAttemptAt)
+			}
+
+			// Each claim only matches the attempt it found, still pending.
+			for i, filter := range collection.filters {
+				want := bson.M{"_id": due.ID, "status": domain.OutboxPending, "next_attempt_at": due.NextAttemptAt}
+				if !reflect.DeepEqual(filter, want) {
+					t.Errorf("claim %d filter = %v, want %v", i, filter, want)
+				}
+			}
+			want := bson.M{"$set": bson.M{"next_attempt_at": now.Add(2 * time.Minute)}, "$inc": bson.M{"attempts": 1}}
+			if got := collection.updates[len(collection.updates)-1]; !reflect.DeepEqual(got, want) {
+				t.Errorf("claim update = %v, want %v", got, want)
+			}
+		})
+	}
+}
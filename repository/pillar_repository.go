@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"plan/database"
+	"plan/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type pillarRepository struct {
+	database   database.Database
+	collection string
+}
+
+func NewPillarRepository(db database.Database, collection string) domain.PillarRepository {
+	return &pillarRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (pr *pillarRepository) CreatePillar(ctx context.Context, pillar *domain.Pillar) error {
+	pillar.ID = primitive.NewObjectID()
+	pillar.CreatedAt = time.Now()
+	pillar.UpdatedAt = pillar.CreatedAt
+	_, err := pr.database.Collection(pr.collection).InsertOne(ctx, pillar)
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrDuplicatePillar
+	}
+	return err
+}
+
+func (pr *pillarRepository) UpdatePillar(ctx context.Context, pillar *domain.Pillar) error {
+	update := bson.M{
+		"$set": bson.M{
+			"fiscal_year": pillar.FiscalYear,
+			"code":        pillar.Code,
+			"name":        pillar.Name,
+			"description": pillar.Description,
+			"updated_at":  time.Now(),
+		},
+	}
+
+	result, err := pr.database.Collection(pr.collection).UpdateOne(ctx, bson.M{"_id": pillar.ID}, update)
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrDuplicatePillar
+	}
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrPillarNotFound
+	}
+	return nil
+}
+
+func (pr *pillarRepository) DeletePillar(ctx context.Context, pillarID primitive.ObjectID) error {
+	result, err := pr.database.Collection(pr.collection).DeleteOne(ctx, bson.M{"_id": pillarID})
+	if err != nil {
+		return err
+	}
+	if result == 0 {
+		return domain.ErrPillarNotFound
+	}
+	return nil
+}
+
+func (pr *pillarRepository) GetPillarByID(ctx context.Context, pillarID primitive.ObjectID) (*domain.Pillar, error) {
+	var pillar domain.Pillar
+	err := pr.database.Collection(pr.collection).FindOne(ctx, bson.M{"_id": pillarID}).Decode(&pillar)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, domain.ErrPillarNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &pillar, nil
+}
+
+func (pr *pillarRepository) GetPillarByCode(ctx context.Context, fiscalYear int, code string) (*domain.Pillar, error) {
+	var pillar domain.Pillar
+	err := pr.database.Collection(pr.collection).FindOne(ctx, bson.M{"fiscal_year": fiscalYear, "code": code}).Decode(&pillar)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, domain.ErrPillarNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &pillar, nil
+}
+
+// ListPillars lists the pillars of the fiscal year, or of every year when
+// fiscalYear is 0.
+func (pr *pillarRepository) ListPillars(ctx context.Context, fiscalYear int) ([]domain.Pillar, error) {
+	filter := bson.M{}
+	if fiscalYear != 0 {
+		filter["fiscal_year"] = fiscalYear
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "fiscal_year", Value: -1}, {Key: "code", Value: 1}})
+	cursor, err := pr.database.Collection(pr.collection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	pillars := []domain.Pillar{}
+	if err := cursor.All(ctx, &pillars); err != nil {
+		return nil, err
+	}
+	return pillars, nil
+}
@@ -28,7 +28,7 @@ func NewPlanRepository(db database.Database, collection string) domain.PlanRepos
 	}
 }
 
-func (repo *planRepository) FindByOwnerID(ctx context.Context, ownerID primitive.ObjectID, datatype string) ([]domain.Plan, error) {
+func (repo *planRepository) FindByOwnerID(ctx context.Context, ownerID primitive.ObjectID) ([]domain.Plan, error) {
 	var plans []domain.Plan
 	cursor, err := repo.database.Collection(repo.collection).Find(ctx, bson.M{"owner_id": ownerID}, options.Find())
 	if err != nil {
@@ -40,133 +40,82 @@ func (repo *planRepository) FindByOwnerID(ctx context.Context, ownerID primitive
 	return plans, nil
 }
 
-func (repo *planRepository) FindByUserID(ctx context.Context, userID primitive.ObjectID, datatype string) ([]domain.Report, error) {
-	var reports []domain.Report
-	cursor, err := repo.database.Collection(repo.collection).Find(ctx, bson.M{"report_user_id": userID}, options.Find())
-	if err != nil {
-		return nil, err
-	}
-	if err = cursor.All(ctx, &reports); err != nil {
-		return nil, err
-	}
-	return reports, nil
-}
-func (repo *planRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
-	collection := repo.database.Collection(repo.collection)
-
-	// Delete the document with the given ID
-	result, err := collection.DeleteOne(ctx, bson.M{"_id": id})
-	if err != nil {
-		return err
-	}
-
-	if result == 0 {
-		return errors.New("announcement not found")
-	}
-
-	return nil
-}
-func (rr *planRepository) GetAllAnnouncements(ctx context.Context) ([]domain.Announcement, error) {
-	collection := rr.database.Collection(rr.collection)
-
-	var announcements []domain.Announcement
-	cursor, err := collection.Find(ctx, bson.M{"type": "announcement"})
-	if err != nil {
-		return nil, err
-	}
-	defer cursor.Close(ctx)
-
-	for cursor.Next(ctx) {
-		var announcement domain.Announcement
-		if err := cursor.Decode(&announcement); err != nil {
-			return nil, err
-		}
-		announcements = append(announcements, announcement)
-	}
-
-	return announcements, nil
-}
-
-func (rr *planRepository) CreateAnnouncement(ctx context.Context, announcement *domain.Announcement) error {
-	collection := rr.database.Collection(rr.collection)
-	_, err := collection.InsertOne(ctx, announcement)
-	return err
-}
-func (rr *planRepository) UpdateReport(ctx context.Context, reportID primitive.ObjectID, updatedReport *domain.Report) error {
-	collection := rr.database.Collection(rr.collection)
-
-	filter := bson.M{"_id": reportID}
-	update := bson.M{
-		"$set": bson.M{
-			"report_title":      updatedReport.ReportTitle,
-			"acomplished_value": updatedReport.AccomplishedValue,
-			"report_details":    updatedReport.ReportDetails,
-			"type":              updatedReport.Type,
-			"supervisor_name":   updatedReport.SupervisorName,
-			"report_status":     updatedReport.Status, // Always "Pending"
-			"updated_at":        time.Now(),
-			"comment":           "",
-		},
-	}
-
-	result, err := collection.UpdateOne(ctx, filter, update)
-	if err != nil {
-		return err
-	}
-	if result.MatchedCount == 0 {
-		return errors.New("report not found")
-	}
-
-	return nil
-}
-
-func (pr *planRepository) UpdatePlan(ctx context.Context, planID primitive.ObjectID, updatedPlan *domain.Plan) error {
+// UpdatePlan stores the edited plan. The update only applies while the plan
+// is still in status from, so a concurrent transition cannot be overwritten.
+func (pr *planRepository) UpdatePlan(ctx context.Context, planID primitive.ObjectID, from string, updatedPlan *domain.Plan) error {
 	collection := pr.database.Collection(pr.collection)
 
-	filter := bson.M{"_id": planID}
+	filter := bson.M{"_id": planID, "status": from}
 	update := bson.M{
 		"$set": bson.M{
 			"title":           updatedPlan.Title,
 			"description":     updatedPlan.Description,
 			"priority":        updatedPlan.Priority,
 			"which_quarter":   updatedPlan.WhichQuarter,
+			"fiscal_year":     updatedPlan.FiscalYear,
+			"quarter":         updatedPlan.Quarter,
 			"quantify":        updatedPlan.Quantify,
 			"aligned_pillary": updatedPlan.AlignedPillary,
+			"superior_plan":   updatedPlan.SuperiorPlan,
+			"pillar_id":       updatedPlan.PillarID,
 			"start_date":      updatedPlan.StartDate,
 			"end_date":        updatedPlan.EndDate,
 			"type":            updatedPlan.Type,
-			"status":          updatedPlan.Status, // Always "Pending"
+			"status":          updatedPlan.Status,
 			"updated_at":      time.Now(),
 			"comment":         "",
+			"approval_chain":  updatedPlan.ApprovalChain, // Restarted on every edit
 		},
 	}
+	unset := bson.M{}
+	if updatedPlan.CurrentApproverID != nil {
+		update["$set"].(bson.M)["current_approver_id"] = updatedPlan.CurrentApproverID
+	} else {
+		unset["current_approver_id"] = ""
+	}
+	if updatedPlan.SupervisorPlanID != nil {
+		update["$set"].(bson.M)["supervisor_plan_id"] = updatedPlan.SupervisorPlanID
+	} else {
+		unset["supervisor_plan_id"] = ""
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
 
 	result, err := collection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return err
 	}
 	if result.MatchedCount == 0 {
-		return errors.New("plan not found")
+		return fmt.Errorf("%w: plan is no longer %s", domain.ErrInvalidTransition, from)
 	}
 
 	return nil
 }
 
-func (rr *planRepository) UpdateReportStatus(ctx context.Context, reportID primitive.ObjectID, supervisorName, status, comment string) error {
-	collection := rr.database.Collection(rr.collection)
+// UpdateApprovalState stores the plan's status and approval chain after a
+// decision. The update only applies while the plan is still waiting on
+// approverID, so two concurrent decisions cannot both succeed; the loser gets
+// domain.ErrInvalidTransition.
+func (pr *planRepository) UpdateApprovalState(ctx context.Context, plan *domain.Plan, approverID primitive.ObjectID) error {
+	collection := pr.database.Collection(pr.collection)
 
-	// Ensure the supervisor is authorized
 	filter := bson.M{
-		"_id":             reportID,
-		"supervisor_name": supervisorName,
+		"_id":                 plan.ID,
+		"current_approver_id": approverID,
 	}
 
-	update := bson.M{
-		"$set": bson.M{
-			"report_status": status,
-			"comment":       comment,
-			"updated_at":    time.Now(),
-		},
+	set := bson.M{
+		"status":         plan.Status,
+		"comment":        plan.Comment,
+		"approval_chain": plan.ApprovalChain,
+		"updated_at":     time.Now(),
+	}
+	update := bson.M{"$set": set}
+	if plan.CurrentApproverID != nil {
+		set["current_approver_id"] = plan.CurrentApproverID
+	} else {
+		update["$unset"] = bson.M{"current_approver_id": ""}
 	}
 
 	result, err := collection.UpdateOne(ctx, filter, update)
@@ -175,72 +124,73 @@ func (rr *planRepository) UpdateReportStatus(ctx context.Context, reportID primi
 	}
 
 	if result.MatchedCount == 0 {
-		return fmt.Errorf("report not found")
+		return fmt.Errorf("%w: plan is no longer waiting on this approver", domain.ErrInvalidTransition)
 	}
 
 	return nil
 }
 
-func (pr *planRepository) UpdatePlanStatus(ctx context.Context, planID primitive.ObjectID, supervisorName, status, comment string) error {
-	collection := pr.database.Collection(pr.collection)
-
-	// Ensure the supervisor is authorized
-	filter := bson.M{
-		"_id":             planID,
-		"supervisor_name": supervisorName,
-	}
-
+// UpdateKPIActual stores the actual value and achievement of the plan's KPI.
+func (pr *planRepository) UpdateKPIActual(ctx context.Context, planID primitive.ObjectID, actual, achievement float64) error {
 	update := bson.M{
 		"$set": bson.M{
-			"status":     status,
-			"comment":    comment,
-			"updated_at": time.Now(),
+			"quantify.actual":      actual,
+			"quantify.achievement": achievement,
+			"updated_at":           time.Now(),
 		},
 	}
 
-	result, err := collection.UpdateOne(ctx, filter, update)
+	result, err := pr.database.Collection(pr.collection).UpdateOne(ctx, bson.M{"_id": planID}, update)
 	if err != nil {
 		return err
 	}
-
 	if result.MatchedCount == 0 {
-		return fmt.Errorf("plan not found")
+		return errors.New("plan not found")
 	}
 
 	return nil
 }
 
-func (rr *planRepository) GetReportsBySupervisorAndStatus(ctx context.Context, supervisorName, reportStatus string) ([]domain.Report, error) {
-	collection := rr.database.Collection(rr.collection)
-
-	// Filter by report_status and supervisor_name
-	filter := bson.M{
-		"report_status":   reportStatus,
-		"supervisor_name": supervisorName,
+// awaitingApprovalFilter matches plans waiting on the approver. Plans created
+// before approval chains existed are matched on their supervisor.
+func awaitingApprovalFilter(approverID primitive.ObjectID) bson.M {
+	return bson.M{
+		"$or": bson.A{
+			bson.M{"current_approver_id": approverID},
+			bson.M{"supervisor_id": approverID, "approval_chain": bson.M{"$exists": false}},
+		},
 	}
+}
 
-	cursor, err := collection.Find(ctx, filter)
-	if err != nil {
-		return nil, err
+// statusFilter matches the requested status. Both pending states are matched
+// together since reviewers queue them alike.
+func statusFilter(status string) interface{} {
+	if domain.IsPending(status) {
+		return bson.M{"$in": domain.PendingStatuses}
 	}
-	defer cursor.Close(ctx)
+	return status
+}
 
-	var reports []domain.Report
-	if err = cursor.All(ctx, &reports); err != nil {
-		return nil, err
+// involvedApproverFilter matches plans the user approves at any level.
+func involvedApproverFilter(approverID primitive.ObjectID) bson.M {
+	return bson.M{
+		"$or": bson.A{
+			bson.M{"approval_chain.approver_id": approverID},
+			bson.M{"supervisor_id": approverID},
+		},
 	}
-
-	return reports, nil
 }
 
-func (pr *planRepository) GetPlansBySupervisorAndStatus(ctx context.Context, supervisorName, status string) ([]domain.Plan, error) {
+func (pr *planRepository) GetPlansBySupervisorAndStatus(ctx context.Context, supervisorID primitive.ObjectID, status string) ([]domain.Plan, error) {
 	collection := pr.database.Collection(pr.collection)
 
-	// Filter by status and supervisor name
-	filter := bson.M{
-		"status":          status,
-		"supervisor_name": supervisorName,
+	// Pending plans are listed to the approver they wait on, decided ones to
+	// every approver in the chain.
+	filter := involvedApproverFilter(supervisorID)
+	if domain.IsPending(status) {
+		filter = awaitingApprovalFilter(supervisorID)
 	}
+	filter["status"] = statusFilter(status)
 
 	cursor, err := collection.Find(ctx, filter)
 	if err != nil {
@@ -264,10 +214,10 @@ func (pr *planRepository) CreatePlan(c context.Context, plan *domain.Plan) error
 	_, err := collection.InsertOne(c, plan)
 	return err
 }
-func (pr *planRepository) GetPlanTitlesByOwnerName(ctx context.Context, ownerName string) ([]string, error) {
+func (pr *planRepository) GetPlanTitlesByOwnerID(ctx context.Context, ownerID primitive.ObjectID) ([]string, error) {
 	collection := pr.database.Collection(pr.collection)
 
-	filter := bson.M{"owner_name": ownerName}
+	filter := bson.M{"owner_id": ownerID}
 	projection := bson.M{"title": 1, "_id": 0} // Only fetch the "title" field
 
 	findOptions := options.Find().SetProjection(projection)
@@ -298,7 +248,6 @@ func (pr *planRepository) GetPlansByStatusAndOwner(ctx context.Context, userID p
 	filter := bson.M{
 		"owner_id": userID,
 		"status":   status,
-		"type":     "plan",
 	}
 
 	cursor, err := collection.Find(ctx, filter)
@@ -314,44 +263,96 @@ func (pr *planRepository) GetPlansByStatusAndOwner(ctx context.Context, userID p
 
 	return plans, nil
 }
-func (rr *planRepository) SubmitReport(ctx context.Context, report *domain.Report) error {
-	report.Status = "Pending"
-	collection := rr.database.Collection(rr.collection)
-	report.ID = primitive.NewObjectID()
 
-	_, err := collection.InsertOne(ctx, report)
-	return err
+func (pr *planRepository) GetAllPlansByUser(ctx context.Context, userID primitive.ObjectID) ([]domain.Plan, error) {
+	filter := bson.M{
+		"owner_id": userID,
+		"status":   domain.StatusApproved,
+	}
+
+	cursor, err := pr.database.Collection(pr.collection).Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var plans []domain.Plan
+	if err := cursor.All(ctx, &plans); err != nil {
+		return nil, err
+	}
 
+	return plans, nil
 }
 
-func (rr *planRepository) GetFilteredReports(ctx context.Context, userID primitive.ObjectID, status string) ([]domain.Report, error) {
+// GetAlignablePlans lists the owner's approved plans, which are the plans the
+// owner's staff can align their own plans to.
+func (pr *planRepository) GetAlignablePlans(ctx context.Context, ownerID primitive.ObjectID) ([]domain.PlanRef, error) {
 	filter := bson.M{
-		"report_user_id": userID,
-		"status":         status,
-		"type":           "report",
+		"owner_id": ownerID,
+		"status":   domain.StatusApproved,
 	}
+	projection := bson.M{"title": 1, "owner_name": 1, "aligned_pillary": 1}
 
-	var reports []domain.Report
-	cursor, err := rr.database.Collection(rr.collection).Find(ctx, filter, options.Find())
+	cursor, err := pr.database.Collection(pr.collection).Find(ctx, filter, options.Find().SetProjection(projection))
 	if err != nil {
 		return nil, err
 	}
+	defer cursor.Close(ctx)
 
+	plans := []domain.PlanRef{}
+	if err := cursor.All(ctx, &plans); err != nil {
+		return nil, err
+	}
+
+	return plans, nil
+}
+
+// FindCascade lists the plans that are not archived, oldest first, limited
+// to the pillar when pillarID is set and to the owners when ownerIDs is set.
+func (pr *planRepository) FindCascade(ctx context.Context, pillarID *primitive.ObjectID, ownerIDs []primitive.ObjectID) ([]domain.Plan, error) {
+	filter := bson.M{"status": bson.M{"$ne": domain.StatusArchived}}
+	if pillarID != nil {
+		filter["pillar_id"] = *pillarID
+	}
+	if ownerIDs != nil {
+		filter["owner_id"] = bson.M{"$in": ownerIDs}
+	}
+
+	cursor, err := pr.database.Collection(pr.collection).Find(ctx, filter, options.Find().SetSort(bson.M{"created_at": 1}))
+	if err != nil {
+		return nil, err
+	}
 	defer cursor.Close(ctx)
-	if err := cursor.All(ctx, &reports); err != nil {
+
+	var plans []domain.Plan
+	if err := cursor.All(ctx, &plans); err != nil {
 		return nil, err
 	}
 
-	return reports, nil
+	return plans, nil
 }
-func (pr *planRepository) GetAllPlansByUser(ctx context.Context, userID primitive.ObjectID) ([]domain.Plan, error) {
+
+func (pr *planRepository) CountByPillar(ctx context.Context, pillarID primitive.ObjectID) (int64, error) {
+	return pr.database.Collection(pr.collection).CountDocuments(ctx, bson.M{"pillar_id": pillarID})
+}
+
+func (pr *planRepository) CountByFiscalYear(ctx context.Context, year int) (int64, error) {
+	return pr.database.Collection(pr.collection).CountDocuments(ctx, bson.M{"fiscal_year": year})
+}
+
+// FindRunningPlans lists the approved plans running at some point of
+// [start, end). Without owners the plans of everyone are listed.
+func (pr *planRepository) FindRunningPlans(ctx context.Context, ownerIDs []primitive.ObjectID, start, end time.Time) ([]domain.Plan, error) {
 	filter := bson.M{
-		"owner_id": userID,
-		"type":     "plan",
-		"status":   "Approved", // Assuming status "Approved" is required
+		"status":     domain.StatusApproved,
+		"start_date": bson.M{"$lt": end},
+		"end_date":   bson.M{"$gte": start},
+	}
+	if ownerIDs != nil {
+		filter["owner_id"] = bson.M{"$in": ownerIDs}
 	}
 
-	cursor, err := pr.database.Collection(pr.collection).Find(ctx, filter)
+	cursor, err := pr.database.Collection(pr.collection).Find(ctx, filter, options.Find().SetSort(bson.M{"owner_name": 1}))
 	if err != nil {
 		return nil, err
 	}
@@ -361,19 +362,266 @@ func (pr *planRepository) GetAllPlansByUser(ctx context.Context, userID primitiv
 	if err := cursor.All(ctx, &plans); err != nil {
 		return nil, err
 	}
+	return plans, nil
+}
 
+// FindByIDs returns the plans with the given IDs. IDs with no plan are
+// skipped.
+func (pr *planRepository) FindByIDs(ctx context.Context, planIDs []primitive.ObjectID) ([]domain.Plan, error) {
+	cursor, err := pr.database.Collection(pr.collection).Find(ctx, bson.M{"_id": bson.M{"$in": planIDs}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var plans []domain.Plan
+	if err := cursor.All(ctx, &plans); err != nil {
+		return nil, err
+	}
 	return plans, nil
 }
 
-func (r *planRepository) CountItems(ctx context.Context, itemType string, toWhom string) (int, error) {
-	collection := r.database.Collection(r.collection) // Adjust `database` and `collection` initialization
+// RenamePillar keeps the pillar name stored on plans in step with the catalog.
+func (pr *planRepository) RenamePillar(ctx context.Context, pillarID primitive.ObjectID, name string) error {
+	_, err := pr.database.Collection(pr.collection).UpdateMany(ctx,
+		bson.M{"pillar_id": pillarID},
+		bson.M{"$set": bson.M{"aligned_pillary": name}},
+	)
+	return err
+}
 
-	// Create the filter
-	filter := bson.M{
-		"type":            itemType,
-		"supervisor_name": toWhom,
-		"status":          "Pending",
+// GetPillarStats counts the plans under each pillar and averages the
+// achievement of those being carried out. Archived plans are left out.
+func (pr *planRepository) GetPillarStats(ctx context.Context, pillarIDs []primitive.ObjectID) ([]domain.PillarStat, error) {
+	running := bson.M{"$in": bson.A{"$status", bson.A{domain.StatusApproved, domain.StatusClosed}}}
+	pipeline := bson.A{
+		bson.M{"$match": bson.M{
+			"pillar_id": bson.M{"$in": pillarIDs},
+			"status":    bson.M{"$ne": domain.StatusArchived},
+		}},
+		bson.M{"$group": bson.M{
+			"_id":            "$pillar_id",
+			"plans":          bson.M{"$sum": 1},
+			"approved_plans": bson.M{"$sum": bson.M{"$cond": bson.A{running, 1, 0}}},
+			"average_achievement": bson.M{"$avg": bson.M{
+				"$cond": bson.A{running, "$quantify.achievement", nil},
+			}},
+		}},
+	}
+
+	cursor, err := pr.database.Collection(pr.collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var stats []domain.PillarStat
+	if err := cursor.All(ctx, &stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// GetDashboard computes the plan statistics of a dashboard. Without owners
+// every plan is counted; with a fiscal year only the plans of that year.
+// Archived plans are left out.
+func (pr *planRepository) GetDashboard(ctx context.Context, ownerIDs []primitive.ObjectID, fiscalYear int, now time.Time) (*domain.PlanDashboard, error) {
+	match := bson.M{"status": bson.M{"$ne": domain.StatusArchived}}
+	if ownerIDs != nil {
+		match["owner_id"] = bson.M{"$in": ownerIDs}
+	}
+	if fiscalYear != 0 {
+		match["fiscal_year"] = fiscalYear
+	}
+
+	running := bson.M{"$match": bson.M{"status": bson.M{"$in": bson.A{domain.StatusApproved, domain.StatusClosed}}}}
+	overdue := bson.M{"$match": bson.M{
+		"status":               domain.StatusApproved,
+		"end_date":             bson.M{"$lt": now},
+		"quantify.achievement": bson.M{"$lt": 100},
+	}}
+	achievement := bson.M{"$avg": bson.M{
+		"$cond": bson.A{
+			bson.M{"$in": bson.A{"$status", bson.A{domain.StatusApproved, domain.StatusClosed}}},
+			"$quantify.achievement",
+			nil,
+		},
+	}}
+
+	isDepartment := bson.M{"$eq": bson.A{"$$this.kind", domain.OrgUnitDepartment}}
+
+	pipeline := bson.A{
+		bson.M{"$match": match},
+		bson.M{"$facet": bson.M{
+			"total":      countFacet(),
+			"by_status":  statusFacet(),
+			"by_quarter": quarterFacet(),
+			"by_pillar": bson.A{
+				bson.M{"$match": bson.M{"pillar_id": bson.M{"$ne": nil}}},
+				bson.M{"$group": bson.M{
+					"_id":                 "$pillar_id",
+					"name":                bson.M{"$first": "$aligned_pillary"},
+					"plans":               bson.M{"$sum": 1},
+					"average_achievement": achievement,
+				}},
+				bson.M{"$sort": bson.M{"name": 1}},
+			},
+			"by_department": bson.A{
+				bson.M{"$lookup": bson.M{
+					"from":         domain.CollectionStaff,
+					"localField":   "owner_id",
+					"foreignField": "_id",
+					"as":           "owner",
+				}},
+				bson.M{"$lookup": bson.M{
+					"from":         domain.CollectionOrgUnit,
+					"localField":   "owner.org_unit_id",
+					"foreignField": "_id",
+					"as":           "unit",
+				}},
+				// The department is the owner's unit or its nearest
+				// department ancestor.
+				bson.M{"$graphLookup": bson.M{
+					"from":             domain.CollectionOrgUnit,
+					"startWith":        bson.M{"$first": "$unit.parent_id"},
+					"connectFromField": "parent_id",
+					"connectToField":   "_id",
+					"as":               "ancestors",
+					"depthField":       "depth",
+				}},
+				bson.M{"$addFields": bson.M{"department": bson.M{"$ifNull": bson.A{
+					bson.M{"$first": bson.M{"$filter": bson.M{"input": "$unit", "cond": isDepartment}}},
+					bson.M{"$reduce": bson.M{
+						"input":        bson.M{"$filter": bson.M{"input": "$ancestors", "cond": isDepartment}},
+						"initialValue": nil,
+						"in": bson.M{"$cond": bson.A{
+							bson.M{"$or": bson.A{
+								bson.M{"$eq": bson.A{"$$value", nil}},
+								bson.M{"$lt": bson.A{"$$this.depth", "$$value.depth"}},
+							}},
+							"$$this",
+							"$$value",
+						}},
+					}},
+				}}}},
+				bson.M{"$group": bson.M{
+					"_id":                 bson.M{"$ifNull": bson.A{"$department._id", primitive.NilObjectID}},
+					"name":                bson.M{"$first": bson.M{"$ifNull": bson.A{"$department.name", ""}}},
+					"plans":               bson.M{"$sum": 1},
+					"average_achievement": achievement,
+				}},
+				bson.M{"$sort": bson.M{"name": 1}},
+			},
+			"achievement": bson.A{
+				running,
+				bson.M{"$group": bson.M{"_id": nil, "average": bson.M{"$avg": "$quantify.achievement"}}},
+			},
+			"overdue": append(bson.A{overdue}, countFacet()...),
+			"overdue_plans": bson.A{
+				overdue,
+				bson.M{"$sort": bson.M{"end_date": 1}},
+				bson.M{"$limit": 20},
+				bson.M{"$project": bson.M{
+					"title":       1,
+					"owner_id":    1,
+					"owner_name":  1,
+					"end_date":    1,
+					"achievement": "$quantify.achievement",
+				}},
+			},
+			"turnaround": turnaroundFacet(),
+		}},
+	}
+
+	cursor, err := pr.database.Collection(pr.collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Total        []facetCount          `bson:"total"`
+		ByStatus     []domain.StatusCount  `bson:"by_status"`
+		ByQuarter    []domain.QuarterCount `bson:"by_quarter"`
+		ByPillar     []domain.GroupStat    `bson:"by_pillar"`
+		ByDepartment []domain.GroupStat    `bson:"by_department"`
+		Achievement  []struct {
+			Average float64 `bson:"average"`
+		} `bson:"achievement"`
+		Overdue      []facetCount            `bson:"overdue"`
+		OverduePlans []domain.OverduePlan    `bson:"overdue_plans"`
+		Turnaround   []domain.TurnaroundStat `bson:"turnaround"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	dashboard := &domain.PlanDashboard{
+		ByStatus:     []domain.StatusCount{},
+		ByQuarter:    []domain.QuarterCount{},
+		ByPillar:     []domain.GroupStat{},
+		ByDepartment: []domain.GroupStat{},
+		OverduePlans: []domain.OverduePlan{},
+	}
+	if len(results) == 0 {
+		return dashboard, nil
+	}
+	result := results[0]
+	dashboard.Total = countOf(result.Total)
+	dashboard.Overdue = countOf(result.Overdue)
+	if result.ByStatus != nil {
+		dashboard.ByStatus = result.ByStatus
+	}
+	if result.ByQuarter != nil {
+		dashboard.ByQuarter = result.ByQuarter
+	}
+	if result.ByPillar != nil {
+		dashboard.ByPillar = result.ByPillar
+	}
+	if result.ByDepartment != nil {
+		dashboard.ByDepartment = result.ByDepartment
+	}
+	if result.OverduePlans != nil {
+		dashboard.OverduePlans = result.OverduePlans
+	}
+	if len(result.Achievement) > 0 {
+		dashboard.AverageAchievement = result.Achievement[0].Average
+	}
+	if len(result.Turnaround) > 0 {
+		dashboard.Turnaround = result.Turnaround[0]
+	}
+	return dashboard, nil
+}
+
+// StreamPlans calls fn with each plan matching the filter, sorted by owner
+// and start date, without loading them all at once.
+func (pr *planRepository) StreamPlans(ctx context.Context, filter domain.ExportFilter, fn func(*domain.Plan) error) error {
+	opts := options.Find().SetSort(bson.D{{Key: "owner_name", Value: 1}, {Key: "start_date", Value: 1}})
+	cursor, err := pr.database.Collection(pr.collection).Find(ctx, exportQuery(filter, "owner_id"), opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var plan domain.Plan
+		if err := cursor.Decode(&plan); err != nil {
+			return err
+		}
+		if err := fn(&plan); err != nil {
+			return err
+		}
 	}
+	return ctx.Err()
+}
+
+// CountAwaitingApproval counts the plans waiting on the approver's decision.
+func (r *planRepository) CountAwaitingApproval(ctx context.Context, approverID primitive.ObjectID) (int, error) {
+	collection := r.database.Collection(r.collection)
+
+	// Create the filter
+	filter := awaitingApprovalFilter(approverID)
+	filter["status"] = bson.M{"$in": domain.PendingStatuses}
 
 	// Count the documents that match the filter
 	count, err := collection.CountDocuments(ctx, filter)
@@ -384,8 +632,6 @@ func (r *planRepository) CountItems(ctx context.Context, itemType string, toWhom
 	return int(count), nil
 }
 
-// FetchByToWhom retrieves users whose To_whom matches the specified firstName.
-
 func (pr *planRepository) GetPlan(ctx context.Context, ownerID primitive.ObjectID) (*domain.Plan, error) {
 	filter := bson.M{
 		"owner_id": ownerID,
@@ -431,15 +677,11 @@ func (pr *planRepository) GetPlan(ctx context.Context, ownerID primitive.ObjectI
 // 	return nil
 // }
 
-func (pr *planRepository) GetSubmittedPlans(ctx context.Context, supervisor_name string) ([]domain.Plan, error) {
+func (pr *planRepository) GetSubmittedPlans(ctx context.Context, supervisorID primitive.ObjectID) ([]domain.Plan, error) {
 	var plans []domain.Plan
 
-	// Query for plans where `SupervisorPlanID` equals the user's ID
-	filter := bson.M{
-		"$or": []bson.M{
-			{"supervisor_name": supervisor_name},
-		},
-	}
+	// Query for plans the supervisor approves at any level
+	filter := involvedApproverFilter(supervisorID)
 
 	cursor, err := pr.database.Collection(pr.collection).Find(ctx, filter)
 	if err != nil {
@@ -470,86 +712,30 @@ func (pr *planRepository) GetPlanByID(ctx context.Context, planID primitive.Obje
 	return &plan, nil
 }
 
-func (pr *planRepository) UpdatePlanPacth(ctx context.Context, plan *domain.Plan) error {
-	filter := bson.M{"_id": plan.ID}
-	update := bson.M{"$set": plan}
-
-	// cursor, err := pr.database.Collection(pr.collection).Find(ctx, filter)
-
-	_, err := pr.database.Collection(pr.collection).UpdateOne(ctx, filter, update)
-	return err
-}
-
-// func (pr *planRepository) ForwardPlanToSupervisor(ctx context.Context, plan *domain.Plan) error {
-// 	if plan.SupervisorPlanID == nil {
-// 		return errors.New("no supervisor to forward to")
-// 	}
-
-// 	err := pr.UpdatePlan(ctx, plan)
-// 	if err != nil {
-// 		panic(err)
-// 	}
-
-// 	// Duplicate the plan for the supervisor and update its owner
-// 	// newPlan := *plan
-// 	// newPlan.ID = primitive.NewObjectID() // Create a new ID for the forwarded plan
-// 	// newPlan.OwnerID = *plan.SupervisorPlanID
-// 	// newPlan.Status = "Pending"
-// 	// newPlan.CreatedAt = time.Now()
-// 	// newPlan.UpdatedAt = time.Now()
-
-// 	// Insert the duplicated plan into the database
-// 	// cursor, err := pr.database.Collection(pr.collection).Find(ctx, filter)
-// 	// _, err = pr.database.Collection(pr.collection).InsertOne(ctx, newPlan)
-// 	return err
-// }
-
-func (cr *planRepository) CreateComment(ctx context.Context, comment *domain.Comment) error {
-	_, err := cr.database.Collection(cr.collection).InsertOne(ctx, comment)
-	return err
-}
-
-func (cr *planRepository) FetchSupervisorComments(ctx context.Context, userID primitive.ObjectID) ([]domain.Comment, error) {
-	filter := bson.M{
-		"commenter": "supervisor", // Assuming "supervisor" is stored as the commenter's username
-		"plan_id": bson.M{
-			"$in": bson.A{
-				bson.M{"owner_id": userID}, // Plans owned by the user
-			},
-		},
-	}
+// UpdateStatus moves a plan from one status to another. It fails with
+// domain.ErrInvalidTransition when the plan is no longer in from.
+func (pr *planRepository) UpdateStatus(ctx context.Context, id primitive.ObjectID, from, to string) error {
+	filter := bson.M{"_id": id, "status": from}
+	update := bson.M{"$set": bson.M{"status": to, "updated_at": time.Now()}}
 
-	cursor, err := cr.database.Collection(cr.collection).Find(ctx, filter)
+	result, err := pr.database.Collection(pr.collection).UpdateOne(ctx, filter, update)
 	if err != nil {
-		return nil, err
+		return err
 	}
-
-	defer cursor.Close(ctx)
-
-	var comments []domain.Comment
-	if err := cursor.All(ctx, &comments); err != nil {
-		return nil, err
+	if result.MatchedCount == 0 {
+		return domain.ErrInvalidTransition
 	}
 
-	return comments, nil
+	return nil
 }
 
-func (cr *planRepository) FetchCommentsByPlanID(ctx context.Context, planID primitive.ObjectID) ([]domain.Comment, error) {
-	// Filter to match the specified PlanID
-	filter := bson.M{"plan_id": planID}
-
-	// Find comments matching the PlanID
-	cursor, err := cr.database.Collection(cr.collection).Find(ctx, filter)
-	if err != nil {
-		return nil, err
-	}
-
-	defer cursor.Close(ctx)
+func (pr *planRepository) UpdatePlanPacth(ctx context.Context, plan *domain.Plan) error {
+	filter := bson.M{"_id": plan.ID}
+	update := bson.M{"$set": plan}
 
-	var comments []domain.Comment
-	if err := cursor.All(ctx, &comments); err != nil {
-		return nil, err
-	}
+	// cursor, err := pr.database.Collection(pr.collection).Find(ctx, filter)
 
-	return comments, nil
+	_, err := pr.database.Collection(pr.collection).UpdateOne(ctx, filter, update)
+	return err
 }
+
@@ -0,0 +1,389 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"plan/database"
+	"plan/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type reportRepository struct {
+	database   database.Database
+	collection string
+}
+
+func NewReportRepository(db database.Database, collection string) domain.ReportRepository {
+	return &reportRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (rr *reportRepository) FindByUserID(ctx context.Context, userID primitive.ObjectID) ([]domain.Report, error) {
+	var reports []domain.Report
+	cursor, err := rr.database.Collection(rr.collection).Find(ctx, bson.M{"report_user_id": userID}, options.Find())
+	if err != nil {
+		return nil, err
+	}
+	if err = cursor.All(ctx, &reports); err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+func (rr *reportRepository) CountByFiscalYear(ctx context.Context, year int) (int64, error) {
+	return rr.database.Collection(rr.collection).CountDocuments(ctx, bson.M{"fiscal_year": year})
+}
+
+// FindForPeriod returns the report on the plan for the period. Archived
+// reports are ignored.
+func (rr *reportRepository) FindForPeriod(ctx context.Context, planID primitive.ObjectID, fiscalYear, quarter int) (*domain.Report, error) {
+	filter := bson.M{
+		"plan_id":     planID,
+		"fiscal_year": fiscalYear,
+		"quarter":     quarter,
+		"status":      bson.M{"$ne": domain.StatusArchived},
+	}
+
+	var report domain.Report
+	err := rr.database.Collection(rr.collection).FindOne(ctx, filter).Decode(&report)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, domain.ErrReportNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// FindLatestApproved returns the approved report on the plan for its latest
+// period.
+func (rr *reportRepository) FindLatestApproved(ctx context.Context, planID primitive.ObjectID) (*domain.Report, error) {
+	filter := bson.M{"plan_id": planID, "status": domain.StatusApproved}
+	opts := options.Find().SetSort(bson.D{{Key: "fiscal_year", Value: -1}, {Key: "quarter", Value: -1}}).SetLimit(1)
+
+	cursor, err := rr.database.Collection(rr.collection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var reports []domain.Report
+	if err := cursor.All(ctx, &reports); err != nil {
+		return nil, err
+	}
+	if len(reports) == 0 {
+		return nil, errors.New("report not found")
+	}
+	return &reports[0], nil
+}
+
+// FindReportedPlanIDs returns which of the plans have a report for the
+// period. Archived reports are ignored.
+func (rr *reportRepository) FindReportedPlanIDs(ctx context.Context, planIDs []primitive.ObjectID, fiscalYear, quarter int) ([]primitive.ObjectID, error) {
+	filter := bson.M{
+		"plan_id":     bson.M{"$in": planIDs},
+		"fiscal_year": fiscalYear,
+		"quarter":     quarter,
+		"status":      bson.M{"$ne": domain.StatusArchived},
+	}
+
+	cursor, err := rr.database.Collection(rr.collection).Find(ctx, filter, options.Find().SetProjection(bson.M{"plan_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var reports []struct {
+		PlanID primitive.ObjectID `bson:"plan_id"`
+	}
+	if err := cursor.All(ctx, &reports); err != nil {
+		return nil, err
+	}
+
+	ids := make([]primitive.ObjectID, len(reports))
+	for i, report := range reports {
+		ids[i] = report.PlanID
+	}
+	return ids, nil
+}
+
+// FindApprovedForPeriod returns the approved reports of the users for the
+// period, oldest first.
+func (rr *reportRepository) FindApprovedForPeriod(ctx context.Context, userIDs []primitive.ObjectID, fiscalYear, quarter int) ([]domain.Report, error) {
+	filter := bson.M{
+		"report_user_id": bson.M{"$in": userIDs},
+		"fiscal_year":    fiscalYear,
+		"quarter":        quarter,
+		"status":         domain.StatusApproved,
+	}
+
+	cursor, err := rr.database.Collection(rr.collection).Find(ctx, filter, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var reports []domain.Report
+	if err := cursor.All(ctx, &reports); err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+// GetDashboard computes the report statistics of a dashboard. Without users
+// every report is counted; with a fiscal year only the reports of that year.
+// Archived reports are left out.
+func (rr *reportRepository) GetDashboard(ctx context.Context, userIDs []primitive.ObjectID, fiscalYear int) (*domain.ReportDashboard, error) {
+	match := bson.M{"status": bson.M{"$ne": domain.StatusArchived}}
+	if userIDs != nil {
+		match["report_user_id"] = bson.M{"$in": userIDs}
+	}
+	if fiscalYear != 0 {
+		match["fiscal_year"] = fiscalYear
+	}
+
+	pipeline := bson.A{
+		bson.M{"$match": match},
+		bson.M{"$facet": bson.M{
+			"total":      countFacet(),
+			"by_status":  statusFacet(),
+			"by_quarter": quarterFacet(),
+			"turnaround": turnaroundFacet(),
+		}},
+	}
+
+	cursor, err := rr.database.Collection(rr.collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Total      []facetCount            `bson:"total"`
+		ByStatus   []domain.StatusCount    `bson:"by_status"`
+		ByQuarter  []domain.QuarterCount   `bson:"by_quarter"`
+		Turnaround []domain.TurnaroundStat `bson:"turnaround"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	dashboard := &domain.ReportDashboard{
+		ByStatus:  []domain.StatusCount{},
+		ByQuarter: []domain.QuarterCount{},
+	}
+	if len(results) == 0 {
+		return dashboard, nil
+	}
+	result := results[0]
+	dashboard.Total = countOf(result.Total)
+	if result.ByStatus != nil {
+		dashboard.ByStatus = result.ByStatus
+	}
+	if result.ByQuarter != nil {
+		dashboard.ByQuarter = result.ByQuarter
+	}
+	if len(result.Turnaround) > 0 {
+		dashboard.Turnaround = result.Turnaround[0]
+	}
+	return dashboard, nil
+}
+
+// StreamReports calls fn with each report matching the filter, sorted by
+// period, without loading them all at once.
+func (rr *reportRepository) StreamReports(ctx context.Context, filter domain.ExportFilter, fn func(*domain.Report) error) error {
+	opts := options.Find().SetSort(bson.D{{Key: "fiscal_year", Value: 1}, {Key: "quarter", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := rr.database.Collection(rr.collection).Find(ctx, exportQuery(filter, "report_user_id"), opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var report domain.Report
+		if err := cursor.Decode(&report); err != nil {
+			return err
+		}
+		if err := fn(&report); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// UpdateReport stores the edited report. The update only applies while the
+// report is still in status from, so a concurrent transition cannot be
+// overwritten.
+func (rr *reportRepository) UpdateReport(ctx context.Context, reportID primitive.ObjectID, from string, updatedReport *domain.Report) error {
+	collection := rr.database.Collection(rr.collection)
+
+	filter := bson.M{"_id": reportID, "status": from}
+	update := bson.M{
+		"$set": bson.M{
+			"report_title":      updatedReport.ReportTitle,
+			"acomplished_value": updatedReport.AccomplishedValue,
+			"report_details":    updatedReport.ReportDetails,
+			"fiscal_year":       updatedReport.FiscalYear,
+			"quarter":           updatedReport.Quarter,
+			"type":              updatedReport.Type,
+			"supervisor_name":   updatedReport.SupervisorName,
+			"supervisor_id":     updatedReport.SupervisorID,
+			"status":            updatedReport.Status,
+			"updated_at":        time.Now(),
+			"comment":           "",
+		},
+	}
+
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrDuplicateReport
+	}
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("%w: report is no longer %s", domain.ErrInvalidTransition, from)
+	}
+
+	return nil
+}
+
+func (rr *reportRepository) UpdateReportStatus(ctx context.Context, reportID primitive.ObjectID, supervisorID primitive.ObjectID, status, comment string) error {
+	collection := rr.database.Collection(rr.collection)
+
+	// Ensure the supervisor is authorized and the report is still waiting
+	filter := bson.M{
+		"_id":           reportID,
+		"supervisor_id": supervisorID,
+		"status":        bson.M{"$in": domain.PendingStatuses},
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":     status,
+			"comment":    comment,
+			"updated_at": time.Now(),
+		},
+	}
+
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("report not found")
+	}
+
+	return nil
+}
+
+func (rr *reportRepository) GetReportsBySupervisorAndStatus(ctx context.Context, supervisorID primitive.ObjectID, reportStatus string) ([]domain.Report, error) {
+	collection := rr.database.Collection(rr.collection)
+
+	// Filter by status and supervisor_id
+	filter := bson.M{
+		"status":        statusFilter(reportStatus),
+		"supervisor_id": supervisorID,
+	}
+
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var reports []domain.Report
+	if err = cursor.All(ctx, &reports); err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+func (rr *reportRepository) SubmitReport(ctx context.Context, report *domain.Report) error {
+	collection := rr.database.Collection(rr.collection)
+	report.ID = primitive.NewObjectID()
+
+	_, err := collection.InsertOne(ctx, report)
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrDuplicateReport
+	}
+	return err
+
+}
+
+func (rr *reportRepository) GetFilteredReports(ctx context.Context, userID primitive.ObjectID, status string) ([]domain.Report, error) {
+	filter := bson.M{
+		"report_user_id": userID,
+		"status":         statusFilter(status),
+	}
+
+	var reports []domain.Report
+	cursor, err := rr.database.Collection(rr.collection).Find(ctx, filter, options.Find())
+	if err != nil {
+		return nil, err
+	}
+
+	defer cursor.Close(ctx)
+	if err := cursor.All(ctx, &reports); err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+func (rr *reportRepository) GetReportByID(ctx context.Context, reportID primitive.ObjectID) (*domain.Report, error) {
+	var report domain.Report
+
+	err := rr.database.Collection(rr.collection).FindOne(ctx, bson.M{"_id": reportID}).Decode(&report)
+	if err != nil {
+		if err.Error() == "mongo: no documents in result" {
+			return nil, errors.New("report not found")
+		}
+
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+// UpdateStatus moves a report from one status to another. It fails with
+// domain.ErrInvalidTransition when the report is no longer in from.
+func (rr *reportRepository) UpdateStatus(ctx context.Context, id primitive.ObjectID, from, to string) error {
+	filter := bson.M{"_id": id, "status": from}
+	update := bson.M{"$set": bson.M{"status": to, "updated_at": time.Now()}}
+
+	result, err := rr.database.Collection(rr.collection).UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrInvalidTransition
+	}
+
+	return nil
+}
+
+// CountPending counts the reports waiting on the supervisor's review.
+func (rr *reportRepository) CountPending(ctx context.Context, supervisorID primitive.ObjectID) (int, error) {
+	filter := bson.M{
+		"supervisor_id": supervisorID,
+		"status":        bson.M{"$in": domain.PendingStatuses},
+	}
+
+	count, err := rr.database.Collection(rr.collection).CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"plan/database"
+	"plan/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type revisionRepository struct {
+	database   database.Database
+	collection string
+}
+
+func NewRevisionRepository(db database.Database, collection string) domain.RevisionRepository {
+	return &revisionRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+// SaveRevision numbers the revision after the last one stored for its
+// document and inserts it. The unique index on (document_id, number) rejects
+// a number taken by a concurrent save, which is then retried with the next.
+func (rr *revisionRepository) SaveRevision(ctx context.Context, revision *domain.Revision) error {
+	collection := rr.database.Collection(rr.collection)
+
+	for attempt := 0; ; attempt++ {
+		var last domain.Revision
+		opts := options.Find().SetSort(bson.M{"number": -1}).SetLimit(1)
+		cursor, err := collection.Find(ctx, bson.M{"document_id": revision.DocumentID}, opts)
+		if err != nil {
+			return err
+		}
+		found := cursor.Next(ctx)
+		if found {
+			err = cursor.Decode(&last)
+		}
+		cursor.Close(ctx)
+		if err != nil {
+			return err
+		}
+
+		revision.ID = primitive.NewObjectID()
+		revision.Number = last.Number + 1
+		revision.CreatedAt = time.Now()
+
+		_, err = collection.InsertOne(ctx, revision)
+		if !mongo.IsDuplicateKeyError(err) || attempt == 4 {
+			return err
+		}
+	}
+}
+
+func (rr *revisionRepository) FindByDocument(ctx context.Context, documentID primitive.ObjectID) ([]domain.Revision, error) {
+	opts := options.Find().SetSort(bson.M{"number": 1})
+	cursor, err := rr.database.Collection(rr.collection).Find(ctx, bson.M{"document_id": documentID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var revisions []domain.Revision
+	if err := cursor.All(ctx, &revisions); err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+func (rr *revisionRepository) GetRevision(ctx context.Context, documentID primitive.ObjectID, number int) (*domain.Revision, error) {
+	var revision domain.Revision
+	filter := bson.M{"document_id": documentID, "number": number}
+	if err := rr.database.Collection(rr.collection).FindOne(ctx, filter).Decode(&revision); err != nil {
+		return nil, errors.New("revision not found")
+	}
+	return &revision, nil
+}
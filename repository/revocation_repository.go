@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"plan/database"
+	"plan/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type revocationRepository struct {
+	database   database.Database
+	collection string
+}
+
+func NewRevocationRepository(db database.Database, collection string) domain.RevocationRepository {
+	return &revocationRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (rr *revocationRepository) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	revocation := &domain.Revocation{
+		ID:        primitive.NewObjectID(),
+		TokenID:   tokenID,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	}
+	_, err := rr.database.Collection(rr.collection).InsertOne(ctx, revocation)
+	return err
+}
+
+// RevokeUserTokens keeps a single "issued before" record per user and only
+// ever moves it forward. Tokens carry their issue time in whole seconds, so
+// the cutoff is truncated too: a token issued in the same second as the
+// revocation stays valid.
+func (rr *revocationRepository) RevokeUserTokens(ctx context.Context, userID primitive.ObjectID, issuedBefore time.Time, expiresAt time.Time) error {
+	issuedBefore = issuedBefore.Truncate(time.Second)
+	filter := bson.M{"user_id": userID}
+	update := bson.M{
+		"$max":         bson.M{"issued_before": issuedBefore, "expires_at": expiresAt},
+		"$setOnInsert": bson.M{"created_at": time.Now()},
+	}
+
+	_, err := rr.database.Collection(rr.collection).UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (rr *revocationRepository) IsRevoked(ctx context.Context, claims *domain.JwtCustomClaims) (bool, error) {
+	filter := bson.M{
+		"$or": []bson.M{
+			{"token_id": claims.Id},
+			{
+				"user_id":       claims.UserID,
+				"issued_before": bson.M{"$gt": time.Unix(claims.IssuedAt, 0)},
+			},
+		},
+	}
+
+	count, err := rr.database.Collection(rr.collection).CountDocuments(ctx, filter)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"plan/database"
+	"plan/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type roleRuleRepository struct {
+	database   database.Database
+	collection string
+}
+
+func NewRoleRuleRepository(db database.Database, collection string) domain.RoleRuleRepository {
+	return &roleRuleRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (rr *roleRuleRepository) ListRules(ctx context.Context) ([]domain.RoleRule, error) {
+	cursor, err := rr.database.Collection(rr.collection).Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"rank": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rules []domain.RoleRule
+	if err := cursor.All(ctx, &rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (rr *roleRuleRepository) GetRule(ctx context.Context, role string) (*domain.RoleRule, error) {
+	var rule domain.RoleRule
+	err := rr.database.Collection(rr.collection).FindOne(ctx, bson.M{"role": role}).Decode(&rule)
+	if err != nil {
+		return nil, errors.New("role rule not found")
+	}
+	return &rule, nil
+}
+
+func (rr *roleRuleRepository) FindSubordinateRoles(ctx context.Context, role string) ([]domain.RoleRule, error) {
+	cursor, err := rr.database.Collection(rr.collection).Find(ctx, bson.M{"superior_role": role})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rules []domain.RoleRule
+	if err := cursor.All(ctx, &rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// SaveRule inserts the rule or replaces the one already stored for its role.
+func (rr *roleRuleRepository) SaveRule(ctx context.Context, rule *domain.RoleRule) error {
+	update := bson.M{
+		"$set": bson.M{
+			"superior_role": rule.SuperiorRole,
+			"rank":          rule.Rank,
+		},
+	}
+	_, err := rr.database.Collection(rr.collection).UpdateOne(ctx, bson.M{"role": rule.Role}, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// SeedRules stores the rules whose role has none yet, leaving existing rules
+// untouched, and returns how many it stored.
+func (rr *roleRuleRepository) SeedRules(ctx context.Context, rules []domain.RoleRule) (int, error) {
+	seeded := 0
+	for _, rule := range rules {
+		update := bson.M{"$setOnInsert": bson.M{
+			"role":          rule.Role,
+			"superior_role": rule.SuperiorRole,
+			"rank":          rule.Rank,
+		}}
+		result, err := rr.database.Collection(rr.collection).UpdateOne(ctx, bson.M{"role": rule.Role}, update, options.Update().SetUpsert(true))
+		if err != nil {
+			return seeded, err
+		}
+		if result.UpsertedCount > 0 {
+			seeded++
+		}
+	}
+	return seeded, nil
+}
+
+func (rr *roleRuleRepository) DeleteRule(ctx context.Context, role string) error {
+	result, err := rr.database.Collection(rr.collection).DeleteOne(ctx, bson.M{"role": role})
+	if err != nil {
+		return err
+	}
+	if result == 0 {
+		return errors.New("role rule not found")
+	}
+	return nil
+}
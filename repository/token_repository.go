@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"plan/database"
+	"plan/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type tokenRepository struct {
+	database   database.Database
+	collection string
+}
+
+func NewTokenRepository(db database.Database, collection string) domain.TokenRepository {
+	return &tokenRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (tr *tokenRepository) SaveToken(ctx context.Context, token *domain.RefreshToken) error {
+	if token.ID.IsZero() {
+		token.ID = primitive.NewObjectID()
+	}
+	_, err := tr.database.Collection(tr.collection).InsertOne(ctx, token)
+	return err
+}
+
+func (tr *tokenRepository) DeleteToken(ctx context.Context, tokenID string) error {
+	result, err := tr.database.Collection(tr.collection).DeleteOne(ctx, bson.M{"token_id": tokenID})
+	if err != nil {
+		return err
+	}
+	if result == 0 {
+		return errors.New("refresh token not found")
+	}
+	return nil
+}
+
+func (tr *tokenRepository) DeleteTokensByUser(ctx context.Context, userID primitive.ObjectID) error {
+	_, err := tr.database.Collection(tr.collection).DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"plan/database"
+	"plan/domain"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type transactor struct {
+	database database.Database
+}
+
+// NewTransactor returns a Transactor running transactions in sessions of the
+// database's client. MongoDB only supports transactions on replica sets and
+// sharded clusters.
+func NewTransactor(db database.Database) domain.Transactor {
+	return &transactor{database: db}
+}
+
+func (t *transactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, err := t.database.Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
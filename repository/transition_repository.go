@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"plan/database"
+	"plan/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type transitionRepository struct {
+	database   database.Database
+	collection string
+}
+
+func NewTransitionRepository(db database.Database, collection string) domain.TransitionRepository {
+	return &transitionRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (tr *transitionRepository) LogTransition(ctx context.Context, transition *domain.Transition) error {
+	transition.CreatedAt = time.Now()
+	_, err := tr.database.Collection(tr.collection).InsertOne(ctx, transition)
+	return err
+}
+
+func (tr *transitionRepository) FindByDocument(ctx context.Context, documentID primitive.ObjectID) ([]domain.Transition, error) {
+	opts := options.Find().SetSort(bson.M{"created_at": 1})
+	cursor, err := tr.database.Collection(tr.collection).Find(ctx, bson.M{"document_id": documentID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var transitions []domain.Transition
+	if err := cursor.All(ctx, &transitions); err != nil {
+		return nil, err
+	}
+	return transitions, nil
+}
@@ -7,6 +7,7 @@ import (
 	"plan/domain"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"errors"
 
@@ -35,12 +36,28 @@ func (u *userRepository) CreateUser(c context.Context, user *domain.User) error
 	fmt.Println(user)
 	return err
 }
-func (ur *userRepository) FindUnverifiedUsersByToWhom(ctx context.Context, firstName string) ([]domain.User, error) {
+func (ur *userRepository) FindUsersByFullName(ctx context.Context, fullName string) ([]domain.User, error) {
+	collection := ur.database.Collection(ur.collection)
+
+	cursor, err := collection.Find(ctx, bson.M{"full_name": fullName})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []domain.User
+	if err = cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (ur *userRepository) FindUnverifiedUsersBySupervisor(ctx context.Context, supervisorID primitive.ObjectID) ([]domain.User, error) {
 	collection := ur.database.Collection(ur.collection)
 
 	filter := bson.M{
-		"verify":  false,
-		"to_whom": firstName,
+		"verify":        false,
+		"supervisor_id": supervisorID,
 	}
 
 	cursor, err := collection.Find(ctx, filter)
@@ -55,13 +72,13 @@ func (ur *userRepository) FindUnverifiedUsersByToWhom(ctx context.Context, first
 	}
 	return users, nil
 }
-func (ur *userRepository) FetchByToWhom(ctx context.Context, firstName string) ([]domain.User, error) {
+func (ur *userRepository) FetchBySupervisor(ctx context.Context, supervisorID primitive.ObjectID) ([]domain.User, error) {
 	var users []domain.User
 
-	// Query to match To_whom with firstName
+	// Query the verified users reporting to the supervisor
 	filter := bson.M{
-		"to_whom": firstName,
-		"verify":  true,
+		"supervisor_id": supervisorID,
+		"verify":        true,
 	}
 	cursor, err := ur.database.Collection(ur.collection).Find(ctx, filter)
 	if err != nil {
@@ -107,6 +124,37 @@ func (ur *userRepository) UpdateVerifyStatus(ctx context.Context, userID primiti
 	return nil
 }
 
+func (ur *userRepository) UpdateCalendar(ctx context.Context, userID primitive.ObjectID, calendar string) error {
+	update := bson.M{"$set": bson.M{"calendar": calendar}}
+
+	result, err := ur.database.Collection(ur.collection).UpdateOne(ctx, bson.M{"_id": userID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+
+	return nil
+}
+
+func (ur *userRepository) UpdateRole(ctx context.Context, userID primitive.ObjectID, role string) error {
+	collection := ur.database.Collection(ur.collection)
+
+	filter := bson.M{"_id": userID}
+	update := bson.M{"$set": bson.M{"role": role}}
+
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+
+	return nil
+}
+
 func (ur *userRepository) DeleteUser(ctx context.Context, userID primitive.ObjectID) error {
 	collection := ur.database.Collection(ur.collection)
 
@@ -181,3 +229,136 @@ func (u *userRepository) FindUsersByRole(c context.Context, role string) ([]doma
 
 	return users, nil
 }
+
+func (ur *userRepository) UpdateOrgUnit(ctx context.Context, userID primitive.ObjectID, unitID *primitive.ObjectID) error {
+	collection := ur.database.Collection(ur.collection)
+
+	update := bson.M{"$set": bson.M{"org_unit_id": unitID}}
+	if unitID == nil {
+		update = bson.M{"$unset": bson.M{"org_unit_id": ""}}
+	}
+
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": userID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+
+	return nil
+}
+
+// FindUnitMembers returns the verified users assigned to an org unit, without
+// their password hashes.
+func (ur *userRepository) FindUnitMembers(ctx context.Context) ([]domain.User, error) {
+	filter := bson.M{
+		"org_unit_id": bson.M{"$exists": true},
+		"verify":      true,
+	}
+	opts := options.Find().SetProjection(bson.M{"password": 0})
+
+	cursor, err := ur.database.Collection(ur.collection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []domain.User
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// FindVerifiedUserIDs returns the IDs of every verified user.
+func (ur *userRepository) FindVerifiedUserIDs(ctx context.Context) ([]primitive.ObjectID, error) {
+	opts := options.Find().SetProjection(bson.M{"_id": 1})
+
+	cursor, err := ur.database.Collection(ur.collection).Find(ctx, bson.M{"verify": true}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+
+	ids := make([]primitive.ObjectID, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+	return ids, nil
+}
+
+func (ur *userRepository) CountUsersInOrgUnit(ctx context.Context, unitID primitive.ObjectID) (int64, error) {
+	return ur.database.Collection(ur.collection).CountDocuments(ctx, bson.M{"org_unit_id": unitID})
+}
+
+// GetChainOfCommand walks supervisor_id upwards from the user and returns the
+// supervisors ordered from the direct supervisor to the top.
+func (ur *userRepository) GetChainOfCommand(ctx context.Context, userID primitive.ObjectID) ([]domain.User, error) {
+	pipeline := bson.A{
+		bson.M{"$match": bson.M{"_id": userID}},
+		bson.M{"$graphLookup": bson.M{
+			"from":             ur.collection,
+			"startWith":        "$supervisor_id",
+			"connectFromField": "supervisor_id",
+			"connectToField":   "_id",
+			"as":               "chain",
+			"depthField":       "depth",
+		}},
+		bson.M{"$unwind": "$chain"},
+		bson.M{"$replaceRoot": bson.M{"newRoot": "$chain"}},
+		bson.M{"$sort": bson.M{"depth": 1}},
+		bson.M{"$project": bson.M{"password": 0, "depth": 0}},
+	}
+
+	cursor, err := ur.database.Collection(ur.collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var chain []domain.User
+	if err := cursor.All(ctx, &chain); err != nil {
+		return nil, err
+	}
+	return chain, nil
+}
+
+// GetSubordinates returns every user below the given one, direct reports
+// first.
+func (ur *userRepository) GetSubordinates(ctx context.Context, userID primitive.ObjectID) ([]domain.Subordinate, error) {
+	pipeline := bson.A{
+		bson.M{"$match": bson.M{"_id": userID}},
+		bson.M{"$graphLookup": bson.M{
+			"from":             ur.collection,
+			"startWith":        "$_id",
+			"connectFromField": "_id",
+			"connectToField":   "supervisor_id",
+			"as":               "subordinates",
+			"depthField":       "depth",
+		}},
+		bson.M{"$unwind": "$subordinates"},
+		bson.M{"$replaceRoot": bson.M{"newRoot": "$subordinates"}},
+		bson.M{"$sort": bson.M{"depth": 1, "full_name": 1}},
+		bson.M{"$project": bson.M{"password": 0}},
+	}
+
+	cursor, err := ur.database.Collection(ur.collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var subordinates []domain.Subordinate
+	if err := cursor.All(ctx, &subordinates); err != nil {
+		return nil, err
+	}
+	return subordinates, nil
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"plan/database"
+	"plan/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type webhookDeliveryRepository struct {
+	database   database.Database
+	collection string
+}
+
+func NewWebhookDeliveryRepository(db database.Database, collection string) domain.WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (dr *webhookDeliveryRepository) Log(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	if delivery.ID.IsZero() {
+		delivery.ID = primitive.NewObjectID()
+	}
+	_, err := dr.database.Collection(dr.collection).InsertOne(ctx, delivery)
+	return err
+}
+
+// ListByWebhook returns the webhook's latest delivery attempts first.
+func (dr *webhookDeliveryRepository) ListByWebhook(ctx context.Context, webhookID primitive.ObjectID, limit int64) ([]domain.WebhookDelivery, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).SetLimit(limit)
+
+	cursor, err := dr.database.Collection(dr.collection).Find(ctx, bson.M{"webhook_id": webhookID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	deliveries := []domain.WebhookDelivery{}
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
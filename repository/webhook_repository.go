@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"plan/database"
+	"plan/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type webhookRepository struct {
+	database   database.Database
+	collection string
+}
+
+func NewWebhookRepository(db database.Database, collection string) domain.WebhookRepository {
+	return &webhookRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (wr *webhookRepository) Create(ctx context.Context, webhook *domain.Webhook) error {
+	_, err := wr.database.Collection(wr.collection).InsertOne(ctx, webhook)
+	return err
+}
+
+func (wr *webhookRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*domain.Webhook, error) {
+	var webhook domain.Webhook
+	err := wr.database.Collection(wr.collection).FindOne(ctx, bson.M{"_id": id}).Decode(&webhook)
+	if err != nil {
+		return nil, domain.ErrWebhookNotFound
+	}
+	return &webhook, nil
+}
+
+func (wr *webhookRepository) List(ctx context.Context) ([]domain.Webhook, error) {
+	return wr.find(ctx, bson.M{})
+}
+
+func (wr *webhookRepository) FindSubscribed(ctx context.Context, eventType string) ([]domain.Webhook, error) {
+	return wr.find(ctx, bson.M{
+		"active": true,
+		"$or": bson.A{
+			bson.M{"events": eventType},
+			bson.M{"events": bson.M{"$size": 0}},
+		},
+	})
+}
+
+func (wr *webhookRepository) find(ctx context.Context, filter bson.M) ([]domain.Webhook, error) {
+	cursor, err := wr.database.Collection(wr.collection).Find(ctx, filter, options.Find().SetSort(bson.M{"created_at": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	webhooks := []domain.Webhook{}
+	if err := cursor.All(ctx, &webhooks); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (wr *webhookRepository) Update(ctx context.Context, webhook *domain.Webhook) error {
+	result, err := wr.database.Collection(wr.collection).UpdateOne(ctx,
+		bson.M{"_id": webhook.ID},
+		bson.M{"$set": bson.M{
+			"name":       webhook.Name,
+			"url":        webhook.URL,
+			"secret":     webhook.Secret,
+			"events":     webhook.Events,
+			"active":     webhook.Active,
+			"updated_at": webhook.UpdatedAt,
+		}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (wr *webhookRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	deleted, err := wr.database.Collection(wr.collection).DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return domain.ErrWebhookNotFound
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"plan/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type dashboardUsecase struct {
+	planRepository   domain.PlanRepository
+	reportRepository domain.ReportRepository
+	userRepository   domain.UserRepository
+	contextTimeout   time.Duration
+}
+
+func NewDashboardUsecase(planRepository domain.PlanRepository, reportRepository domain.ReportRepository, userRepository domain.UserRepository, timeout time.Duration) domain.DashboardUsecase {
+	return &dashboardUsecase{
+		planRepository:   planRepository,
+		reportRepository: reportRepository,
+		userRepository:   userRepository,
+		contextTimeout:   timeout,
+	}
+}
+
+// GetDashboard summarizes the plans and reports of the caller and everyone
+// below them. The planning office sees the whole organization.
+func (du *dashboardUsecase) GetDashboard(c context.Context, claims *domain.JwtCustomClaims, fiscalYear int) (*domain.Dashboard, error) {
+	ctx, cancel := context.WithTimeout(c, du.contextTimeout)
+	defer cancel()
+
+	dashboard := &domain.Dashboard{Scope: "organization", FiscalYear: fiscalYear}
+
+	var userIDs []primitive.ObjectID
+	if claims.Role != domain.RolePlanningOffice {
+		subordinates, err := du.userRepository.GetSubordinates(ctx, claims.UserID)
+		if err != nil {
+			return nil, err
+		}
+		userIDs = []primitive.ObjectID{claims.UserID}
+		for _, subordinate := range subordinates {
+			userIDs = append(userIDs, subordinate.ID)
+		}
+		dashboard.Scope = "subtree"
+	}
+
+	plans, err := du.planRepository.GetDashboard(ctx, userIDs, fiscalYear, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	reports, err := du.reportRepository.GetDashboard(ctx, userIDs, fiscalYear)
+	if err != nil {
+		return nil, err
+	}
+
+	dashboard.Plans = *plans
+	dashboard.Reports = *reports
+	return dashboard, nil
+}
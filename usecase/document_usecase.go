@@ -0,0 +1,237 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"plan/domain"
+	"plan/internal/pdfdoc"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type documentUsecase struct {
+	planRepository       domain.PlanRepository
+	reportRepository     domain.ReportRepository
+	transitionRepository domain.TransitionRepository
+	userRepository       domain.UserRepository
+	renderer             pdfdoc.Renderer
+	contextTimeout       time.Duration
+}
+
+// NewDocumentUsecase returns a DocumentUsecase rendering with the UTF-8
+// TrueType font at fontPath, or the built-in fonts when it is empty.
+func NewDocumentUsecase(planRepository domain.PlanRepository, reportRepository domain.ReportRepository, transitionRepository domain.TransitionRepository, userRepository domain.UserRepository, fontPath string, timeout time.Duration) domain.DocumentUsecase {
+	return &documentUsecase{
+		planRepository:       planRepository,
+		reportRepository:     reportRepository,
+		transitionRepository: transitionRepository,
+		userRepository:       userRepository,
+		renderer:             pdfdoc.Renderer{FontPath: fontPath},
+		contextTimeout:       timeout,
+	}
+}
+
+// isPrintable reports whether a document in status can be printed for
+// signing.
+func isPrintable(status string) bool {
+	return status == domain.StatusApproved || status == domain.StatusClosed
+}
+
+// PlanPDF renders an approved plan with its KPI and approval trail. Dates are
+// written in calendar.
+func (du *documentUsecase) PlanPDF(c context.Context, claims *domain.JwtCustomClaims, planID string, calendar string) (*domain.PDFFile, error) {
+	ctx, cancel := context.WithTimeout(c, du.contextTimeout)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(planID)
+	if err != nil {
+		return nil, errors.New("invalid plan ID")
+	}
+	plan, err := du.planRepository.GetPlanByID(ctx, objectID)
+	if err != nil {
+		return nil, err
+	}
+	if !canViewPlan(claims, plan) {
+		return nil, errors.New("unauthorized access")
+	}
+	if !isPrintable(plan.Status) {
+		return nil, fmt.Errorf("%w: the plan is %s", domain.ErrNotApproved, plan.Status)
+	}
+
+	trail, err := du.approvalTrail(ctx, plan.ID, calendar)
+	if err != nil {
+		return nil, err
+	}
+
+	kpi := plan.Quantify
+	doc := &pdfdoc.Document{
+		Title:    plan.Title,
+		Subtitle: fmt.Sprintf("Plan, fiscal year %d, %s", plan.FiscalYear, plan.WhichQuarter),
+		Fields: []pdfdoc.Field{
+			{Label: "Owner", Value: fmt.Sprintf("%s (%s)", plan.OwnerName, plan.OwnerRole)},
+			{Label: "Supervisor", Value: plan.SupervisorName},
+			{Label: "Fiscal year", Value: strconv.Itoa(plan.FiscalYear)},
+			{Label: "Quarter", Value: plan.WhichQuarter},
+			{Label: "Period", Value: formatDate(plan.StartDate, calendar) + " to " + formatDate(plan.EndDate, calendar)},
+			{Label: "Strategic pillar", Value: plan.AlignedPillary},
+			{Label: "Parent plan", Value: plan.SuperiorPlan},
+			{Label: "Priority", Value: plan.Priority},
+			{Label: "Status", Value: plan.Status},
+		},
+		Sections: []pdfdoc.Section{
+			{Title: "Description", Text: plan.Description},
+			{Title: "Key performance indicator", Table: &pdfdoc.Table{
+				Columns: []string{"Unit", "Direction", "Baseline", "Target", "Actual", "Achievement", "Deadline"},
+				Rows: [][]string{{
+					kpi.Unit, kpi.Direction, formatNumber(kpi.Baseline), formatNumber(kpi.Target),
+					formatNumber(kpi.Actual), formatPercent(kpi.Achievement), formatDate(kpi.Deadline, calendar),
+				}},
+			}},
+			{Title: "Approval chain", Table: approvalChainTable(plan.ApprovalChain, calendar)},
+			{Title: "Approval trail", Table: trail},
+		},
+		Signatures: []string{"Owner", "Supervisor"},
+		Footer:     "Plan " + plan.ID.Hex() + ", printed " + formatDate(time.Now(), calendar),
+	}
+
+	content, err := du.renderer.Render(doc)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.PDFFile{Filename: "plan-" + plan.ID.Hex() + ".pdf", Content: content}, nil
+}
+
+// ReportPDF renders an approved report against its plan's KPI, with its
+// approval trail. Dates are written in calendar.
+func (du *documentUsecase) ReportPDF(c context.Context, claims *domain.JwtCustomClaims, reportID string, calendar string) (*domain.PDFFile, error) {
+	ctx, cancel := context.WithTimeout(c, du.contextTimeout)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(reportID)
+	if err != nil {
+		return nil, errors.New("invalid report ID")
+	}
+	report, err := du.reportRepository.GetReportByID(ctx, objectID)
+	if err != nil {
+		return nil, err
+	}
+	if !canViewReport(claims, report) {
+		return nil, errors.New("unauthorized access")
+	}
+	if !isPrintable(report.Status) {
+		return nil, fmt.Errorf("%w: the report is %s", domain.ErrNotApproved, report.Status)
+	}
+
+	owner := ""
+	if user, err := du.userRepository.GetUserByID(ctx, report.ReportUserID); err == nil {
+		owner = fmt.Sprintf("%s (%s)", user.Full_Name, user.Role)
+	}
+
+	kpiTable := &pdfdoc.Table{
+		Columns: []string{"Unit", "Baseline", "Target", "Reported", "Achievement"},
+		Rows:    [][]string{{"", "", "", formatNumber(report.AccomplishedValue), ""}},
+	}
+	planTitle := ""
+	if !report.PlanID.IsZero() {
+		if plan, err := du.planRepository.GetPlanByID(ctx, report.PlanID); err == nil {
+			planTitle = plan.Title
+			kpi := plan.Quantify
+			kpi.Actual = report.AccomplishedValue
+			kpi.UpdateAchievement()
+			kpiTable.Rows[0] = []string{
+				kpi.Unit, formatNumber(kpi.Baseline), formatNumber(kpi.Target),
+				formatNumber(kpi.Actual), formatPercent(kpi.Achievement),
+			}
+		}
+	}
+
+	trail, err := du.approvalTrail(ctx, report.ID, calendar)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &pdfdoc.Document{
+		Title:    report.ReportTitle,
+		Subtitle: fmt.Sprintf("Progress report, fiscal year %d, quarter %d", report.FiscalYear, report.Quarter),
+		Fields: []pdfdoc.Field{
+			{Label: "Reported by", Value: owner},
+			{Label: "Supervisor", Value: report.SupervisorName},
+			{Label: "Plan", Value: planTitle},
+			{Label: "Fiscal year", Value: strconv.Itoa(report.FiscalYear)},
+			{Label: "Quarter", Value: strconv.Itoa(report.Quarter)},
+			{Label: "Status", Value: report.Status},
+		},
+		Sections: []pdfdoc.Section{
+			{Title: "Key performance indicator", Table: kpiTable},
+			{Title: "Details", Text: report.ReportDetails},
+			{Title: "Approval trail", Table: trail},
+		},
+		Signatures: []string{"Reported by", "Supervisor"},
+		Footer:     "Report " + report.ID.Hex() + ", printed " + formatDate(time.Now(), calendar),
+	}
+
+	content, err := du.renderer.Render(doc)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.PDFFile{Filename: "report-" + report.ID.Hex() + ".pdf", Content: content}, nil
+}
+
+// approvalTrail tabulates the status changes of a document with who made
+// them.
+func (du *documentUsecase) approvalTrail(ctx context.Context, documentID primitive.ObjectID, calendar string) (*pdfdoc.Table, error) {
+	transitions, err := du.transitionRepository.FindByDocument(ctx, documentID)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[primitive.ObjectID]string{}
+	table := &pdfdoc.Table{
+		Columns: []string{"Date", "Change", "By", "Comment"},
+		Widths:  []float64{0.15, 0.3, 0.2, 0.35},
+	}
+	for _, transition := range transitions {
+		name, ok := names[transition.ActorID]
+		if !ok {
+			if user, err := du.userRepository.GetUserByID(ctx, transition.ActorID); err == nil {
+				name = user.Full_Name
+			}
+			names[transition.ActorID] = name
+		}
+
+		change := transition.To
+		if transition.From != "" {
+			change = transition.From + " to " + transition.To
+		}
+		table.Rows = append(table.Rows, []string{formatDate(transition.CreatedAt, calendar), change, name, transition.Comment})
+	}
+	return table, nil
+}
+
+func approvalChainTable(chain []domain.ApprovalStep, calendar string) *pdfdoc.Table {
+	table := &pdfdoc.Table{
+		Columns: []string{"Level", "Approver", "Role", "Decision", "Date", "Comment"},
+		Widths:  []float64{0.08, 0.22, 0.15, 0.13, 0.14, 0.28},
+	}
+	for _, step := range chain {
+		decided := ""
+		if step.DecidedAt != nil {
+			decided = formatDate(*step.DecidedAt, calendar)
+		}
+		table.Rows = append(table.Rows, []string{
+			strconv.Itoa(step.Level), step.ApproverName, step.ApproverRole, step.Status, decided, step.Comment,
+		})
+	}
+	return table
+}
+
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func formatPercent(value float64) string {
+	return strconv.FormatFloat(value, 'f', 1, 64) + "%"
+}
@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"plan/domain"
+	"plan/internal/ethiocal"
+	"plan/internal/export"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type exportUsecase struct {
+	planRepository    domain.PlanRepository
+	reportRepository  domain.ReportRepository
+	userRepository    domain.UserRepository
+	orgUnitRepository domain.OrgUnitRepository
+	contextTimeout    time.Duration
+}
+
+func NewExportUsecase(planRepository domain.PlanRepository, reportRepository domain.ReportRepository, userRepository domain.UserRepository, orgUnitRepository domain.OrgUnitRepository, timeout time.Duration) domain.ExportUsecase {
+	return &exportUsecase{
+		planRepository:    planRepository,
+		reportRepository:  reportRepository,
+		userRepository:    userRepository,
+		orgUnitRepository: orgUnitRepository,
+		contextTimeout:    timeout,
+	}
+}
+
+var planColumns = []interface{}{
+	"Plan ID", "Title", "Owner", "Owner Role", "Supervisor", "Pillar", "Parent Plan",
+	"Fiscal Year", "Quarter", "Status", "Priority", "Start Date", "End Date",
+	"Unit", "Direction", "Baseline", "Target", "Actual", "Achievement (%)", "KPI Deadline",
+}
+
+var reportColumns = []interface{}{
+	"Report ID", "Title", "Plan ID", "Reporter", "Supervisor",
+	"Fiscal Year", "Quarter", "Status", "Accomplished", "Details", "Comment",
+}
+
+// ExportPlans writes the plans matching the request's filter, one row per
+// plan, as they come off the cursor. Large exports outlast the context
+// timeout, so the stream is bounded by the request alone.
+func (eu *exportUsecase) ExportPlans(c context.Context, request *domain.ExportRequest) error {
+	writer, err := eu.prepare(c, request, "Plans")
+	if err != nil {
+		return err
+	}
+	if err := writer.WriteRow(planColumns); err != nil {
+		return err
+	}
+
+	err = eu.planRepository.StreamPlans(c, request.Filter, func(plan *domain.Plan) error {
+		return writer.WriteRow([]interface{}{
+			plan.ID.Hex(), plan.Title, plan.OwnerName, plan.OwnerRole, plan.SupervisorName,
+			plan.AlignedPillary, plan.SuperiorPlan, plan.FiscalYear, plan.Quarter,
+			plan.Status, plan.Priority,
+			formatDate(plan.StartDate, request.Calendar), formatDate(plan.EndDate, request.Calendar),
+			plan.Quantify.Unit, plan.Quantify.Direction, plan.Quantify.Baseline, plan.Quantify.Target,
+			plan.Quantify.Actual, plan.Quantify.Achievement, formatDate(plan.Quantify.Deadline, request.Calendar),
+		})
+	})
+	if err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+// ExportReports writes the reports matching the request's filter, one row
+// per report, as they come off the cursor, bounded by the request alone.
+func (eu *exportUsecase) ExportReports(c context.Context, request *domain.ExportRequest) error {
+	writer, err := eu.prepare(c, request, "Reports")
+	if err != nil {
+		return err
+	}
+	if err := writer.WriteRow(reportColumns); err != nil {
+		return err
+	}
+
+	names := map[primitive.ObjectID]string{}
+	err = eu.reportRepository.StreamReports(c, request.Filter, func(report *domain.Report) error {
+		name, ok := names[report.ReportUserID]
+		if !ok {
+			if user, err := eu.lookupUser(c, report.ReportUserID); err == nil {
+				name = user.Full_Name
+			}
+			names[report.ReportUserID] = name
+		}
+
+		return writer.WriteRow([]interface{}{
+			report.ID.Hex(), report.ReportTitle, hexOrEmpty(report.PlanID), name, report.SupervisorName,
+			report.FiscalYear, report.Quarter, report.Status, report.AccomplishedValue,
+			report.ReportDetails, report.Comment,
+		})
+	})
+	if err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+// lookupUser loads a user within the context timeout.
+func (eu *exportUsecase) lookupUser(c context.Context, id primitive.ObjectID) (*domain.User, error) {
+	ctx, cancel := context.WithTimeout(c, eu.contextTimeout)
+	defer cancel()
+
+	return eu.userRepository.GetUserByID(ctx, id)
+}
+
+// prepare checks the request, resolves its department to owners and opens
+// the table writer.
+func (eu *exportUsecase) prepare(c context.Context, request *domain.ExportRequest, sheet string) (export.Writer, error) {
+	ctx, cancel := context.WithTimeout(c, eu.contextTimeout)
+	defer cancel()
+
+	filter := &request.Filter
+	if filter.Status != "" && !domain.IsValidStatus(filter.Status) {
+		return nil, fmt.Errorf("%w: unknown status %q", domain.ErrInvalidExport, filter.Status)
+	}
+	if filter.Quarter < 0 || filter.Quarter > 4 {
+		return nil, fmt.Errorf("%w: quarter must be between 1 and 4", domain.ErrInvalidExport)
+	}
+	if _, ok := export.ContentTypes[request.Format]; !ok {
+		return nil, fmt.Errorf("%w: unknown format %q", domain.ErrInvalidExport, request.Format)
+	}
+
+	if filter.DepartmentID != nil {
+		owners, err := eu.departmentMembers(ctx, *filter.DepartmentID)
+		if err != nil {
+			return nil, err
+		}
+		filter.OwnerIDs = owners
+		if filter.OwnerID != nil {
+			filter.OwnerIDs = nil
+			for _, id := range owners {
+				if id == *filter.OwnerID {
+					filter.OwnerIDs = []primitive.ObjectID{id}
+				}
+			}
+		}
+	}
+
+	return export.NewWriter(request.Format, request.W, sheet)
+}
+
+// departmentMembers lists the users of a unit and of every unit below it.
+func (eu *exportUsecase) departmentMembers(ctx context.Context, unitID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	units, err := eu.orgUnitRepository.ListUnits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	inUnit := map[primitive.ObjectID]bool{}
+	for _, unit := range units {
+		if unit.ID == unitID {
+			inUnit[unitID] = true
+		}
+	}
+	if !inUnit[unitID] {
+		return nil, fmt.Errorf("%w: department %s not found", domain.ErrInvalidExport, unitID.Hex())
+	}
+	// Units may be listed before their parents, so repeat until no unit is
+	// added.
+	for added := true; added; {
+		added = false
+		for _, unit := range units {
+			if !inUnit[unit.ID] && unit.ParentID != nil && inUnit[*unit.ParentID] {
+				inUnit[unit.ID] = true
+				added = true
+			}
+		}
+	}
+
+	members, err := eu.userRepository.FindUnitMembers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	owners := []primitive.ObjectID{}
+	for _, member := range members {
+		if member.OrgUnitID != nil && inUnit[*member.OrgUnitID] {
+			owners = append(owners, member.ID)
+		}
+	}
+	return owners, nil
+}
+
+// formatDate writes the day of t in the calendar. Zero times are left blank.
+func formatDate(t time.Time, calendar string) string {
+	if t.IsZero() {
+		return ""
+	}
+	if calendar == domain.CalendarEthiopian {
+		return ethiocal.FromTime(t).String()
+	}
+	return t.Format("2006-01-02")
+}
+
+func hexOrEmpty(id primitive.ObjectID) string {
+	if id.IsZero() {
+		return ""
+	}
+	return id.Hex()
+}
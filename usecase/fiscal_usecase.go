@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"plan/domain"
+	"plan/internal/ethiocal"
+	"time"
+)
+
+type fiscalUsecase struct {
+	fiscalYearRepository domain.FiscalYearRepository
+	planRepository       domain.PlanRepository
+	reportRepository     domain.ReportRepository
+	pillarRepository     domain.PillarRepository
+	contextTimeout       time.Duration
+}
+
+func NewFiscalUsecase(fiscalYearRepository domain.FiscalYearRepository, planRepository domain.PlanRepository, reportRepository domain.ReportRepository, pillarRepository domain.PillarRepository, timeout time.Duration) domain.FiscalUsecase {
+	return &fiscalUsecase{
+		fiscalYearRepository: fiscalYearRepository,
+		planRepository:       planRepository,
+		reportRepository:     reportRepository,
+		pillarRepository:     pillarRepository,
+		contextTimeout:       timeout,
+	}
+}
+
+func (fu *fiscalUsecase) ListFiscalYears(c context.Context) ([]domain.FiscalYear, error) {
+	ctx, cancel := context.WithTimeout(c, fu.contextTimeout)
+	defer cancel()
+
+	return fu.fiscalYearRepository.ListFiscalYears(ctx)
+}
+
+func (fu *fiscalUsecase) GetFiscalYear(c context.Context, year int) (*domain.FiscalYear, error) {
+	ctx, cancel := context.WithTimeout(c, fu.contextTimeout)
+	defer cancel()
+
+	return fu.fiscalYearRepository.GetFiscalYear(ctx, year)
+}
+
+// GetCurrentPeriod returns the fiscal year and period covering today.
+func (fu *fiscalUsecase) GetCurrentPeriod(c context.Context) (*domain.FiscalYear, *domain.Period, error) {
+	ctx, cancel := context.WithTimeout(c, fu.contextTimeout)
+	defer cancel()
+
+	now := time.Now()
+	fiscalYear, err := fu.fiscalYearRepository.FindByDate(ctx, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	period, ok := fiscalYear.PeriodAt(now)
+	if !ok {
+		return nil, nil, errors.New("period not found")
+	}
+	return fiscalYear, period, nil
+}
+
+// CreateFiscalYear stores a fiscal year. Without periods the year is split
+// into four open quarters.
+func (fu *fiscalUsecase) CreateFiscalYear(c context.Context, fiscalYear *domain.FiscalYear) error {
+	ctx, cancel := context.WithTimeout(c, fu.contextTimeout)
+	defer cancel()
+
+	if _, err := fu.fiscalYearRepository.GetFiscalYear(ctx, fiscalYear.Year); err == nil {
+		return errors.New("fiscal year already exists")
+	}
+	if err := fu.validateFiscalYear(ctx, fiscalYear); err != nil {
+		return err
+	}
+
+	return fu.fiscalYearRepository.CreateFiscalYear(ctx, fiscalYear)
+}
+
+func (fu *fiscalUsecase) UpdateFiscalYear(c context.Context, year int, fiscalYear *domain.FiscalYear) error {
+	ctx, cancel := context.WithTimeout(c, fu.contextTimeout)
+	defer cancel()
+
+	if _, err := fu.fiscalYearRepository.GetFiscalYear(ctx, year); err != nil {
+		return err
+	}
+
+	fiscalYear.Year = year
+	if err := fu.validateFiscalYear(ctx, fiscalYear); err != nil {
+		return err
+	}
+
+	return fu.fiscalYearRepository.UpdateFiscalYear(ctx, fiscalYear)
+}
+
+// validateFiscalYear checks the periods and that the year does not overlap
+// another one, so every date falls in at most one fiscal year.
+func (fu *fiscalUsecase) validateFiscalYear(ctx context.Context, fiscalYear *domain.FiscalYear) error {
+	switch fiscalYear.Calendar {
+	case "":
+		fiscalYear.Calendar = domain.CalendarGregorian
+	case domain.CalendarGregorian:
+	case domain.CalendarEthiopian:
+		// Ethiopian years always start on Hamle 1 and, unless given, are
+		// split into the quarters starting on Hamle, Tikimt, Tir and Miyazia.
+		bounds := ethiocal.FiscalQuarterBounds(fiscalYear.Year)
+		fiscalYear.StartDate = bounds[0].Time(time.UTC)
+		if len(fiscalYear.Periods) == 0 {
+			fiscalYear.Periods = ethiopianQuarters(bounds)
+		}
+	default:
+		return fmt.Errorf("invalid calendar: %s", fiscalYear.Calendar)
+	}
+
+	if err := fiscalYear.Normalize(); err != nil {
+		return err
+	}
+
+	overlapping, err := fu.fiscalYearRepository.FindOverlapping(ctx, fiscalYear.StartDate, fiscalYear.EndDate)
+	if err != nil {
+		return err
+	}
+	for _, other := range overlapping {
+		if other.Year != fiscalYear.Year {
+			return fmt.Errorf("%w: overlaps fiscal year %d", domain.ErrInvalidPeriod, other.Year)
+		}
+	}
+
+	return nil
+}
+
+func ethiopianQuarters(bounds []ethiocal.Date) []domain.Period {
+	periods := make([]domain.Period, len(bounds)-1)
+	for i := range periods {
+		periods[i] = domain.Period{
+			Quarter:   i + 1,
+			Name:      fmt.Sprintf("Q%d", i+1),
+			StartDate: bounds[i].Time(time.UTC),
+			EndDate:   bounds[i+1].Time(time.UTC),
+			Open:      true,
+		}
+	}
+	return periods
+}
+
+// DeleteFiscalYear removes a fiscal year nothing has been planned or
+// reported in yet.
+func (fu *fiscalUsecase) DeleteFiscalYear(c context.Context, year int) error {
+	ctx, cancel := context.WithTimeout(c, fu.contextTimeout)
+	defer cancel()
+
+	plans, err := fu.planRepository.CountByFiscalYear(ctx, year)
+	if err != nil {
+		return err
+	}
+	reports, err := fu.reportRepository.CountByFiscalYear(ctx, year)
+	if err != nil {
+		return err
+	}
+	pillars, err := fu.pillarRepository.ListPillars(ctx, year)
+	if err != nil {
+		return err
+	}
+	if plans > 0 || reports > 0 || len(pillars) > 0 {
+		return errors.New("fiscal year is in use")
+	}
+
+	return fu.fiscalYearRepository.DeleteFiscalYear(ctx, year)
+}
+
+func (fu *fiscalUsecase) SetPeriodOpen(c context.Context, year, quarter int, open bool) error {
+	ctx, cancel := context.WithTimeout(c, fu.contextTimeout)
+	defer cancel()
+
+	return fu.fiscalYearRepository.SetPeriodOpen(ctx, year, quarter, open)
+}
@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"plan/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	defaultNotificationLimit = 50
+	maxNotificationLimit     = 200
+)
+
+type notifier struct {
+	notificationRepository domain.NotificationRepository
+	events                 domain.EventHub
+}
+
+// NewNotifier returns a Notifier saving notifications to the repository and
+// pushing each one to its recipient through the event hub.
+func NewNotifier(notificationRepository domain.NotificationRepository, events domain.EventHub) domain.Notifier {
+	return &notifier{
+		notificationRepository: notificationRepository,
+		events:                 events,
+	}
+}
+
+func (n *notifier) Notify(ctx context.Context, notifications ...domain.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	created, err := n.notificationRepository.Create(ctx, notifications)
+	if err != nil {
+		return err
+	}
+
+	events := make([]domain.Event, len(created))
+	for i := range created {
+		event, err := domain.NewEvent(domain.EventNotification, created[i].RecipientID, created[i])
+		if err != nil {
+			return err
+		}
+		events[i] = event
+	}
+	return n.events.Publish(ctx, events...)
+}
+
+type notificationUsecase struct {
+	notificationRepository domain.NotificationRepository
+	contextTimeout         time.Duration
+}
+
+func NewNotificationUsecase(notificationRepository domain.NotificationRepository, timeout time.Duration) domain.NotificationUsecase {
+	return &notificationUsecase{
+		notificationRepository: notificationRepository,
+		contextTimeout:         timeout,
+	}
+}
+
+func (nu *notificationUsecase) ListNotifications(c context.Context, claims *domain.JwtCustomClaims, unreadOnly bool, limit int64) ([]domain.Notification, error) {
+	ctx, cancel := context.WithTimeout(c, nu.contextTimeout)
+	defer cancel()
+
+	if limit <= 0 {
+		limit = defaultNotificationLimit
+	}
+	if limit > maxNotificationLimit {
+		limit = maxNotificationLimit
+	}
+
+	return nu.notificationRepository.List(ctx, claims.UserID, unreadOnly, limit)
+}
+
+func (nu *notificationUsecase) CountUnread(c context.Context, claims *domain.JwtCustomClaims) (int64, error) {
+	ctx, cancel := context.WithTimeout(c, nu.contextTimeout)
+	defer cancel()
+
+	return nu.notificationRepository.CountUnread(ctx, claims.UserID)
+}
+
+// MarkRead marks one of the caller's notifications read, or unread again.
+func (nu *notificationUsecase) MarkRead(c context.Context, claims *domain.JwtCustomClaims, id string, read bool) error {
+	ctx, cancel := context.WithTimeout(c, nu.contextTimeout)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrNotificationNotFound
+	}
+	return nu.notificationRepository.SetRead(ctx, claims.UserID, objectID, read, time.Now())
+}
+
+func (nu *notificationUsecase) MarkAllRead(c context.Context, claims *domain.JwtCustomClaims) (int64, error) {
+	ctx, cancel := context.WithTimeout(c, nu.contextTimeout)
+	defer cancel()
+
+	return nu.notificationRepository.MarkAllRead(ctx, claims.UserID, time.Now())
+}
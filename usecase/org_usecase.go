@@ -0,0 +1,260 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"plan/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type orgUsecase struct {
+	orgUnitRepository  domain.OrgUnitRepository
+	roleRuleRepository domain.RoleRuleRepository
+	userRepository     domain.UserRepository
+	contextTimeout     time.Duration
+}
+
+func NewOrgUsecase(orgUnitRepository domain.OrgUnitRepository, roleRuleRepository domain.RoleRuleRepository, userRepository domain.UserRepository, timeout time.Duration) domain.OrgUsecase {
+	return &orgUsecase{
+		orgUnitRepository:  orgUnitRepository,
+		roleRuleRepository: roleRuleRepository,
+		userRepository:     userRepository,
+		contextTimeout:     timeout,
+	}
+}
+
+func (ou *orgUsecase) GetTree(c context.Context) ([]*domain.OrgUnitNode, error) {
+	ctx, cancel := context.WithTimeout(c, ou.contextTimeout)
+	defer cancel()
+
+	units, err := ou.orgUnitRepository.ListUnits(ctx)
+	if err != nil {
+		return nil, err
+	}
+	members, err := ou.userRepository.FindUnitMembers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[primitive.ObjectID]*domain.OrgUnitNode, len(units))
+	for _, unit := range units {
+		nodes[unit.ID] = &domain.OrgUnitNode{OrgUnit: unit, Members: []domain.User{}, Children: []*domain.OrgUnitNode{}}
+	}
+	for _, member := range members {
+		if node, ok := nodes[*member.OrgUnitID]; ok {
+			node.Members = append(node.Members, member)
+		}
+	}
+
+	// Units whose parent is missing are shown as roots rather than dropped.
+	roots := []*domain.OrgUnitNode{}
+	for _, unit := range units {
+		node := nodes[unit.ID]
+		if unit.ParentID != nil {
+			if parent, ok := nodes[*unit.ParentID]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+
+	return roots, nil
+}
+
+func (ou *orgUsecase) GetChainOfCommand(c context.Context, claims *domain.JwtCustomClaims, userID string) ([]domain.User, error) {
+	ctx, cancel := context.WithTimeout(c, ou.contextTimeout)
+	defer cancel()
+
+	objectID, err := ou.authorizeView(ctx, claims, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ou.userRepository.GetChainOfCommand(ctx, objectID)
+}
+
+func (ou *orgUsecase) GetSubordinates(c context.Context, claims *domain.JwtCustomClaims, userID string) ([]domain.Subordinate, error) {
+	ctx, cancel := context.WithTimeout(c, ou.contextTimeout)
+	defer cancel()
+
+	objectID, err := ou.authorizeView(ctx, claims, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ou.userRepository.GetSubordinates(ctx, objectID)
+}
+
+// authorizeView lets users look at their own position, anyone above them in
+// the chain of command and the planning office look at everyone.
+func (ou *orgUsecase) authorizeView(ctx context.Context, claims *domain.JwtCustomClaims, userID string) (primitive.ObjectID, error) {
+	objectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return objectID, errors.New("invalid user ID format")
+	}
+
+	if claims.Role == domain.RolePlanningOffice || objectID == claims.UserID {
+		return objectID, nil
+	}
+
+	chain, err := ou.userRepository.GetChainOfCommand(ctx, objectID)
+	if err != nil {
+		return objectID, err
+	}
+	for _, superior := range chain {
+		if superior.ID == claims.UserID {
+			return objectID, nil
+		}
+	}
+
+	return objectID, errors.New("unauthorized access")
+}
+
+func (ou *orgUsecase) CreateUnit(c context.Context, unit *domain.OrgUnit) error {
+	ctx, cancel := context.WithTimeout(c, ou.contextTimeout)
+	defer cancel()
+
+	if err := ou.validateUnit(ctx, primitive.NilObjectID, unit); err != nil {
+		return err
+	}
+
+	return ou.orgUnitRepository.CreateUnit(ctx, unit)
+}
+
+func (ou *orgUsecase) UpdateUnit(c context.Context, unitID string, unit *domain.OrgUnit) error {
+	ctx, cancel := context.WithTimeout(c, ou.contextTimeout)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(unitID)
+	if err != nil {
+		return errors.New("invalid org unit ID format")
+	}
+	if _, err := ou.orgUnitRepository.GetUnitByID(ctx, objectID); err != nil {
+		return err
+	}
+
+	if err := ou.validateUnit(ctx, objectID, unit); err != nil {
+		return err
+	}
+
+	unit.ID = objectID
+	return ou.orgUnitRepository.UpdateUnit(ctx, unit)
+}
+
+// validateUnit checks the kind and that the parent exists without making the
+// unit its own ancestor.
+func (ou *orgUsecase) validateUnit(ctx context.Context, unitID primitive.ObjectID, unit *domain.OrgUnit) error {
+	switch unit.Kind {
+	case domain.OrgUnitDepartment, domain.OrgUnitTeam, domain.OrgUnitOffice:
+	default:
+		return fmt.Errorf("invalid org unit kind: %s", unit.Kind)
+	}
+
+	for parentID := unit.ParentID; parentID != nil; {
+		if *parentID == unitID {
+			return errors.New("org unit cannot be its own ancestor")
+		}
+		parent, err := ou.orgUnitRepository.GetUnitByID(ctx, *parentID)
+		if err != nil {
+			return errors.New("parent org unit not found")
+		}
+		parentID = parent.ParentID
+	}
+
+	return nil
+}
+
+func (ou *orgUsecase) DeleteUnit(c context.Context, unitID string) error {
+	ctx, cancel := context.WithTimeout(c, ou.contextTimeout)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(unitID)
+	if err != nil {
+		return errors.New("invalid org unit ID format")
+	}
+
+	children, err := ou.orgUnitRepository.CountChildren(ctx, objectID)
+	if err != nil {
+		return err
+	}
+	members, err := ou.userRepository.CountUsersInOrgUnit(ctx, objectID)
+	if err != nil {
+		return err
+	}
+	if children > 0 || members > 0 {
+		return errors.New("org unit is not empty")
+	}
+
+	return ou.orgUnitRepository.DeleteUnit(ctx, objectID)
+}
+
+func (ou *orgUsecase) AssignUser(c context.Context, userID string, unitID string) error {
+	ctx, cancel := context.WithTimeout(c, ou.contextTimeout)
+	defer cancel()
+
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return errors.New("invalid user ID format")
+	}
+
+	// An empty unit ID removes the user from their unit.
+	var unitObjectID *primitive.ObjectID
+	if unitID != "" {
+		objectID, err := primitive.ObjectIDFromHex(unitID)
+		if err != nil {
+			return errors.New("invalid org unit ID format")
+		}
+		if _, err := ou.orgUnitRepository.GetUnitByID(ctx, objectID); err != nil {
+			return err
+		}
+		unitObjectID = &objectID
+	}
+
+	return ou.userRepository.UpdateOrgUnit(ctx, userObjectID, unitObjectID)
+}
+
+func (ou *orgUsecase) ListRoleRules(c context.Context) ([]domain.RoleRule, error) {
+	ctx, cancel := context.WithTimeout(c, ou.contextTimeout)
+	defer cancel()
+
+	return ou.roleRuleRepository.ListRules(ctx)
+}
+
+func (ou *orgUsecase) SaveRoleRule(c context.Context, rule *domain.RoleRule) error {
+	ctx, cancel := context.WithTimeout(c, ou.contextTimeout)
+	defer cancel()
+
+	// Walk up from the new superior to make sure it exists and the rule does
+	// not close a loop.
+	for superior := rule.SuperiorRole; superior != ""; {
+		if superior == rule.Role {
+			return errors.New("role cannot report to itself")
+		}
+		next, err := ou.roleRuleRepository.GetRule(ctx, superior)
+		if err != nil {
+			return fmt.Errorf("unknown superior role: %s", superior)
+		}
+		superior = next.SuperiorRole
+	}
+
+	return ou.roleRuleRepository.SaveRule(ctx, rule)
+}
+
+func (ou *orgUsecase) DeleteRoleRule(c context.Context, role string) error {
+	ctx, cancel := context.WithTimeout(c, ou.contextTimeout)
+	defer cancel()
+
+	subordinates, err := ou.roleRuleRepository.FindSubordinateRoles(ctx, role)
+	if err != nil {
+		return err
+	}
+	if len(subordinates) > 0 {
+		return errors.New("other roles still report to this role")
+	}
+
+	return ou.roleRuleRepository.DeleteRule(ctx, role)
+}
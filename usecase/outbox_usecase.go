@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"plan/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	defaultOutboxLimit = 50
+	maxOutboxLimit     = 200
+)
+
+type outboxUsecase struct {
+	outboxRepository domain.OutboxRepository
+	contextTimeout   time.Duration
+}
+
+func NewOutboxUsecase(outboxRepository domain.OutboxRepository, timeout time.Duration) domain.OutboxUsecase {
+	return &outboxUsecase{
+		outboxRepository: outboxRepository,
+		contextTimeout:   timeout,
+	}
+}
+
+func (uc *outboxUsecase) ListMessages(c context.Context, status string, limit int64) ([]domain.OutboxMessage, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	switch status {
+	case "", domain.OutboxPending, domain.OutboxSent, domain.OutboxDead:
+	default:
+		return nil, errors.New("invalid status: " + status)
+	}
+	if limit <= 0 {
+		limit = defaultOutboxLimit
+	}
+	if limit > maxOutboxLimit {
+		limit = maxOutboxLimit
+	}
+
+	return uc.outboxRepository.List(ctx, status, limit)
+}
+
+func (uc *outboxUsecase) GetMessage(c context.Context, id string) (*domain.OutboxMessage, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrOutboxNotFound
+	}
+	return uc.outboxRepository.GetByID(ctx, objectID)
+}
+
+// RetryMessage puts a dead message back in the queue for immediate delivery,
+// with a fresh set of attempts.
+func (uc *outboxUsecase) RetryMessage(c context.Context, id string) (*domain.OutboxMessage, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrOutboxNotFound
+	}
+	if _, err := uc.outboxRepository.GetByID(ctx, objectID); err != nil {
+		return nil, err
+	}
+	if err := uc.outboxRepository.Requeue(ctx, objectID, time.Now()); err != nil {
+		return nil, err
+	}
+	return uc.outboxRepository.GetByID(ctx, objectID)
+}
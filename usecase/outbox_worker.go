@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"plan/domain"
+	"time"
+)
+
+const (
+	// outboxLease is how long a claimed message stays hidden from other
+	// workers. A worker that dies mid-delivery leaves the message to be
+	// retried once the lease ends.
+	outboxLease = 2 * time.Minute
+
+	outboxBaseDelay = 30 * time.Second
+	outboxMaxDelay  = 6 * time.Hour
+)
+
+// OutboxWorker delivers the messages of the outbox, retrying failed ones
+// with exponential backoff until they are dead-lettered.
+type OutboxWorker struct {
+	outboxRepository domain.OutboxRepository
+	mailer           domain.Mailer
+	webhooks         domain.WebhookSender
+	interval         time.Duration
+}
+
+// NewOutboxWorker returns a worker polling the outbox at the interval.
+func NewOutboxWorker(outboxRepository domain.OutboxRepository, mailer domain.Mailer, webhooks domain.WebhookSender, interval time.Duration) *OutboxWorker {
+	return &OutboxWorker{
+		outboxRepository: outboxRepository,
+		mailer:           mailer,
+		webhooks:         webhooks,
+		interval:         interval,
+	}
+}
+
+// Run delivers due messages until ctx is done.
+func (w *OutboxWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.drain(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// drain delivers messages until none is due.
+func (w *OutboxWorker) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		message, err := w.outboxRepository.ClaimDue(ctx, time.Now(), outboxLease)
+		if err != nil {
+			log.Println("failed to claim outbox message:", err)
+			return
+		}
+		if message == nil {
+			return
+		}
+		w.process(ctx, message)
+	}
+}
+
+func (w *OutboxWorker) process(ctx context.Context, message *domain.OutboxMessage) {
+	deliverCtx, cancel := context.WithTimeout(ctx, outboxLease)
+	err := w.deliver(deliverCtx, message)
+	cancel()
+
+	if err == nil {
+		if err := w.outboxRepository.MarkSent(ctx, message.ID, time.Now()); err != nil {
+			log.Printf("failed to mark outbox message %s sent: %v", message.ID.Hex(), err)
+		}
+		return
+	}
+
+	var next *time.Time
+	if message.Attempts < domain.OutboxMaxAttempts {
+		at := time.Now().Add(outboxBackoff(message.Attempts))
+		next = &at
+	} else {
+		log.Printf("outbox message %s dead after %d attempts: %v", message.ID.Hex(), message.Attempts, err)
+	}
+	if err := w.outboxRepository.MarkFailed(ctx, message.ID, err.Error(), next); err != nil {
+		log.Printf("failed to record outbox message %s failure: %v", message.ID.Hex(), err)
+	}
+}
+
+func (w *OutboxWorker) deliver(ctx context.Context, message *domain.OutboxMessage) error {
+	switch message.Kind {
+	case domain.OutboxMail:
+		if message.Mail == nil {
+			return errors.New("mail message has no mail")
+		}
+		return w.mailer.Send(ctx, message.Mail)
+	case domain.OutboxWebhook:
+		if message.Webhook == nil {
+			return errors.New("webhook message has no webhook")
+		}
+		return w.webhooks.Deliver(ctx, message.Webhook, message.Attempts)
+	}
+	return fmt.Errorf("unknown outbox message kind %q", message.Kind)
+}
+
+// outboxBackoff is the delay before the attempt after the given one: it
+// doubles from outboxBaseDelay up to outboxMaxDelay, with up to a tenth of
+// jitter so failures do not retry in lockstep.
+func outboxBackoff(attempts int) time.Duration {
+	delay := outboxMaxDelay
+	if attempts < 20 {
+		if d := outboxBaseDelay << (attempts - 1); d < outboxMaxDelay {
+			delay = d
+		}
+	}
+	return delay + time.Duration(rand.Int63n(int64(delay/10)+1))
+}
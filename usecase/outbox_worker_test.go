@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"plan/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestOutboxBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		base     time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{8, 64 * time.Minute},
+		{10, 256 * time.Minute},
+		{11, outboxMaxDelay},
+		{19, outboxMaxDelay},
+		{20, outboxMaxDelay},
+		{64, outboxMaxDelay},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 100; i++ {
+			got := outboxBackoff(tt.attempts)
+			if got < tt.base || got > tt.base+tt.base/10 {
+				t.Fatalf("outboxBackoff(%d) = %v, want between %v and %v", tt.attempts, got, tt.base, tt.base+tt.base/10)
+			}
+		}
+	}
+}
+
+func TestOutboxBackoffJitter(t *testing.T) {
+	seen := map[time.Duration]bool{}
+	for i := 0; i < 50; i++ {
+		seen[outboxBackoff(4)] = true
+	}
+	if len(seen) < 2 {
+		t.Errorf("outboxBackoff(4) returned the same delay 50 times: %v", seen)
+	}
+}
+
+type fakeOutboxRepository struct {
+	domain.OutboxRepository
+	sent     []primitive.ObjectID
+	failed   []primitive.ObjectID
+	lastNext *time.Time
+}
+
+func (r *fakeOutboxRepository) MarkSent(ctx context.Context, id primitive.ObjectID, sentAt time.Time) error {
+	r.sent = append(r.sent, id)
+	return nil
+}
+
+func (r *fakeOutboxRepository) MarkFailed(ctx context.Context, id primitive.ObjectID, lastError string, next *time.Time) error {
+	r.failed = append(r.failed, id)
+	r.lastNext = next
+	return nil
+}
+
+type fakeMailer struct {
+	err error
+}
+
+func (m *fakeMailer) Send(ctx context.Context, mail *domain.Mail) error {
+	return m.err
+}
+
+func TestOutboxWorkerProcess(t *testing.T) {
+	tests := []struct {
+		name     string
+		mailErr  error
+		attempts int
+		sent     bool
+		retry    bool
+	}{
+		{"delivered", nil, 1, true, false},
+		{"first failure", errors.New("smtp down"), 1, false, true},
+		{"failure before the last attempt", errors.New("smtp down"), domain.OutboxMaxAttempts - 1, false, true},
+		{"last failure", errors.New("smtp down"), domain.OutboxMaxAttempts, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outbox := &fakeOutboxRepository{}
+			worker := NewOutboxWorker(outbox, &fakeMailer{err: tt.mailErr}, nil, time.Second)
+			message := &domain.OutboxMessage{
+				ID:       primitive.NewObjectID(),
+				Kind:     domain.OutboxMail,
+				Mail:     &domain.Mail{Event: domain.MailAccountApproved, To: []string{"a@example.com"}},
+				Attempts: tt.attempts,
+			}
+
+			before := time.Now()
+			worker.process(context.Background(), message)
+
+			if sent := len(outbox.sent) == 1; sent != tt.sent {
+				t.Fatalf("sent = %v, want %v", sent, tt.sent)
+			}
+			if tt.sent {
+				if len(outbox.failed) != 0 {
+					t.Errorf("a delivered message was marked failed")
+				}
+				return
+			}
+			if len(outbox.failed) != 1 {
+				t.Fatalf("marked failed %d times, want once", len(outbox.failed))
+			}
+			if retry := outbox.lastNext != nil; retry != tt.retry {
+				t.Fatalf("retry = %v, want %v", retry, tt.retry)
+			}
+			if tt.retry {
+				delay := outbox.lastNext.Sub(before)
+				base := outboxBaseDelay << (tt.attempts - 1)
+				if delay < base || delay > base+base/10+time.Second {
+					t.Errorf("next attempt in %v, want about %v", delay, base)
+				}
+			}
+		})
+	}
+}
+
+func TestOutboxWorkerRejectsMalformedMessages(t *testing.T) {
+	outbox := &fakeOutboxRepository{}
+	worker := NewOutboxWorker(outbox, &fakeMailer{}, nil, time.Second)
+
+	messages := []*domain.OutboxMessage{
+		{ID: primitive.NewObjectID(), Kind: domain.OutboxMail, Attempts: 1},
+		{ID: primitive.NewObjectID(), Kind: domain.OutboxWebhook, Attempts: 1},
+		{ID: primitive.NewObjectID(), Kind: "fax", Attempts: 1},
+	}
+	for _, message := range messages {
+		worker.process(context.Background(), message)
+	}
+	if len(outbox.sent) != 0 || len(outbox.failed) != len(messages) {
+		t.Errorf("sent %d and failed %d of %d malformed messages", len(outbox.sent), len(outbox.failed), len(messages))
+	}
+}
@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"plan/domain"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type pillarUsecase struct {
+	pillarRepository     domain.PillarRepository
+	planRepository       domain.PlanRepository
+	fiscalYearRepository domain.FiscalYearRepository
+	contextTimeout       time.Duration
+}
+
+func NewPillarUsecase(pillarRepository domain.PillarRepository, planRepository domain.PlanRepository, fiscalYearRepository domain.FiscalYearRepository, timeout time.Duration) domain.PillarUsecase {
+	return &pillarUsecase{
+		pillarRepository:     pillarRepository,
+		planRepository:       planRepository,
+		fiscalYearRepository: fiscalYearRepository,
+		contextTimeout:       timeout,
+	}
+}
+
+func (pu *pillarUsecase) ListPillars(c context.Context, fiscalYear int) ([]domain.Pillar, error) {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	return pu.pillarRepository.ListPillars(ctx, fiscalYear)
+}
+
+func (pu *pillarUsecase) GetPillar(c context.Context, pillarID string) (*domain.Pillar, error) {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(pillarID)
+	if err != nil {
+		return nil, errors.New("invalid pillar ID format")
+	}
+
+	return pu.pillarRepository.GetPillarByID(ctx, objectID)
+}
+
+func (pu *pillarUsecase) CreatePillar(c context.Context, pillar *domain.Pillar) error {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	if err := pu.validatePillar(ctx, primitive.NilObjectID, pillar); err != nil {
+		return err
+	}
+
+	return pu.pillarRepository.CreatePillar(ctx, pillar)
+}
+
+// UpdatePillar saves the pillar and renames it on the plans that reference it.
+func (pu *pillarUsecase) UpdatePillar(c context.Context, pillarID string, pillar *domain.Pillar) error {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(pillarID)
+	if err != nil {
+		return errors.New("invalid pillar ID format")
+	}
+	existing, err := pu.pillarRepository.GetPillarByID(ctx, objectID)
+	if err != nil {
+		return err
+	}
+
+	if err := pu.validatePillar(ctx, objectID, pillar); err != nil {
+		return err
+	}
+
+	pillar.ID = objectID
+	if err := pu.pillarRepository.UpdatePillar(ctx, pillar); err != nil {
+		return err
+	}
+
+	if pillar.Name != existing.Name {
+		return pu.planRepository.RenamePillar(ctx, objectID, pillar.Name)
+	}
+	return nil
+}
+
+// validatePillar trims the pillar and checks its fiscal year exists and its
+// code is unique within that year.
+func (pu *pillarUsecase) validatePillar(ctx context.Context, pillarID primitive.ObjectID, pillar *domain.Pillar) error {
+	pillar.Code = strings.TrimSpace(pillar.Code)
+	pillar.Name = strings.TrimSpace(pillar.Name)
+	if pillar.Code == "" || pillar.Name == "" {
+		return errors.New("pillar code and name are required")
+	}
+
+	if _, err := pu.fiscalYearRepository.GetFiscalYear(ctx, pillar.FiscalYear); err != nil {
+		return err
+	}
+
+	other, err := pu.pillarRepository.GetPillarByCode(ctx, pillar.FiscalYear, pillar.Code)
+	if err == nil && other.ID != pillarID {
+		return domain.ErrDuplicatePillar
+	}
+	if err != nil && !errors.Is(err, domain.ErrPillarNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+// DeletePillar removes a pillar no plan references.
+func (pu *pillarUsecase) DeletePillar(c context.Context, pillarID string) error {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(pillarID)
+	if err != nil {
+		return errors.New("invalid pillar ID format")
+	}
+
+	plans, err := pu.planRepository.CountByPillar(ctx, objectID)
+	if err != nil {
+		return err
+	}
+	if plans > 0 {
+		return errors.New("pillar is referenced by plans")
+	}
+
+	return pu.pillarRepository.DeletePillar(ctx, objectID)
+}
+
+// GetPillarStats reports the plans and achievement under every pillar of the
+// fiscal year, including pillars no plan references yet.
+func (pu *pillarUsecase) GetPillarStats(c context.Context, fiscalYear int) ([]domain.PillarStat, error) {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	pillars, err := pu.pillarRepository.ListPillars(ctx, fiscalYear)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]primitive.ObjectID, len(pillars))
+	for i, pillar := range pillars {
+		ids[i] = pillar.ID
+	}
+	counted, err := pu.planRepository.GetPillarStats(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byPillar := make(map[primitive.ObjectID]domain.PillarStat, len(counted))
+	for _, stat := range counted {
+		byPillar[stat.PillarID] = stat
+	}
+
+	stats := make([]domain.PillarStat, len(pillars))
+	for i, pillar := range pillars {
+		stat := byPillar[pillar.ID]
+		stat.PillarID = pillar.ID
+		stat.Code = pillar.Code
+		stat.Name = pillar.Name
+		stat.FiscalYear = pillar.FiscalYear
+		stats[i] = stat
+	}
+	return stats, nil
+}
@@ -0,0 +1,328 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"plan/domain"
+	"plan/internal/ethiocal"
+	"plan/internal/export"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/xuri/excelize/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// importColumns maps the accepted headers of an import, in normalized form,
+// to the columns they fill. The headers of a plan export are accepted too.
+var importColumns = map[string]string{
+	"title":              "title",
+	"description":        "description",
+	"priority":           "priority",
+	"pillar":             "pillar",
+	"pillar_code":        "pillar",
+	"pillar_id":          "pillar",
+	"parent_plan_id":     "parent_plan_id",
+	"supervisor_plan_id": "parent_plan_id",
+	"start_date":         "start_date",
+	"end_date":           "end_date",
+	"unit":               "unit",
+	"direction":          "direction",
+	"baseline":           "baseline",
+	"target":             "target",
+	"deadline":           "deadline",
+	"kpi_deadline":       "deadline",
+}
+
+var requiredImportColumns = []string{"title", "start_date", "end_date"}
+
+// ImportPlans reads plans from a CSV or XLSX table and checks each row as
+// CreatePlan would. Plans are created for the caller, all in one
+// transaction, only when every row is valid and the request is not a dry run.
+// A whole file outlasts the context timeout, so it bounds each row and the
+// transaction rather than the import.
+func (pu *planUsecaseStruct) ImportPlans(c context.Context, claims *domain.JwtCustomClaims, request *domain.ImportRequest) (*domain.ImportResult, error) {
+	reader, err := export.NewReader(request.Format, request.R)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidImport, err)
+	}
+	defer reader.Close()
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: the file is empty", domain.ErrInvalidImport)
+	} else if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidImport, err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		if column, ok := importColumns[normalizeHeader(name)]; ok {
+			if _, seen := columns[column]; !seen {
+				columns[column] = i
+			}
+		}
+	}
+	for _, column := range requiredImportColumns {
+		if _, ok := columns[column]; !ok {
+			return nil, fmt.Errorf("%w: missing column %q", domain.ErrInvalidImport, column)
+		}
+	}
+
+	owner, err := pu.importOwner(c, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.ImportResult{
+		DryRun:  request.DryRun,
+		PlanIDs: []primitive.ObjectID{},
+		Errors:  []domain.ImportRowError{},
+	}
+	var plans []*domain.Plan
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("%w: row %d: %v", domain.ErrInvalidImport, row, err)
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+
+		result.Rows++
+		if result.Rows > domain.MaxImportRows {
+			return nil, fmt.Errorf("%w: more than %d plans", domain.ErrInvalidImport, domain.MaxImportRows)
+		}
+
+		plan, rowErrors := pu.importRow(c, claims, owner, request, columns, record)
+		for i := range rowErrors {
+			rowErrors[i].Row = row
+		}
+		if len(rowErrors) > 0 {
+			result.Errors = append(result.Errors, rowErrors...)
+			continue
+		}
+		result.Valid++
+		plans = append(plans, plan)
+	}
+	if result.Rows == 0 {
+		return nil, fmt.Errorf("%w: the file has no plans", domain.ErrInvalidImport)
+	}
+
+	if request.DryRun || len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	err = pu.transactor.WithTransaction(ctx, func(tx context.Context) error {
+		for _, plan := range plans {
+			if err := pu.insertPlan(tx, plan); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, plan := range plans {
+		pu.notifyPlanStatus(c, plan, plan.OwnerID, "")
+		result.PlanIDs = append(result.PlanIDs, plan.ID)
+	}
+	result.Created = len(plans)
+	return result, nil
+}
+
+// importOwner loads the caller, who owns the imported plans.
+func (pu *planUsecaseStruct) importOwner(c context.Context, userID primitive.ObjectID) (*domain.User, error) {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	return pu.userRepository.GetUserByID(ctx, userID)
+}
+
+// importRow turns a row into a prepared plan of the caller, or lists what is
+// wrong with it.
+func (pu *planUsecaseStruct) importRow(c context.Context, claims *domain.JwtCustomClaims, owner *domain.User, request *domain.ImportRequest, columns map[string]int, record []string) (*domain.Plan, []domain.ImportRowError) {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	get := func(column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+	var rowErrors []domain.ImportRowError
+	fail := func(column, message string) {
+		rowErrors = append(rowErrors, domain.ImportRowError{Column: column, Message: message})
+	}
+
+	plan := &domain.Plan{
+		Title:          get("title"),
+		Description:    get("description"),
+		Priority:       get("priority"),
+		OwnerRole:      claims.Role,
+		OwnerID:        claims.UserID,
+		OwnerName:      claims.Full_Name,
+		SupervisorName: owner.To_whom,
+		SupervisorID:   owner.SupervisorID,
+		CreatedBy:      claims.Username,
+	}
+	plan.Quantify.Unit = get("unit")
+	plan.Quantify.Direction = strings.ToLower(get("direction"))
+	if plan.Title == "" {
+		fail("title", "title is required")
+	}
+
+	// Columns are checked in a fixed order, so a file always gets its errors
+	// in the same order.
+	dates := []struct {
+		column string
+		target *time.Time
+	}{
+		{"start_date", &plan.StartDate},
+		{"end_date", &plan.EndDate},
+		{"deadline", &plan.Quantify.Deadline},
+	}
+	for _, date := range dates {
+		value := get(date.column)
+		if value == "" {
+			continue
+		}
+		t, err := parseImportDate(value, request.Format, request.Calendar)
+		if err != nil {
+			fail(date.column, err.Error())
+			continue
+		}
+		*date.target = t
+	}
+
+	numbers := []struct {
+		column string
+		target *float64
+	}{
+		{"baseline", &plan.Quantify.Baseline},
+		{"target", &plan.Quantify.Target},
+	}
+	for _, number := range numbers {
+		value := get(number.column)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.ParseFloat(strings.NewReplacer(",", "", "%", "").Replace(value), 64)
+		if err != nil {
+			fail(number.column, fmt.Sprintf("%q is not a number", value))
+			continue
+		}
+		*number.target = n
+	}
+
+	if value := get("parent_plan_id"); value != "" {
+		id, err := primitive.ObjectIDFromHex(value)
+		if err != nil {
+			fail("parent_plan_id", fmt.Sprintf("%q is not a plan ID", value))
+		} else {
+			plan.SupervisorPlanID = &id
+		}
+	}
+	if len(rowErrors) > 0 {
+		return nil, rowErrors
+	}
+
+	// Pillars are given by ID or by their code in the plan's fiscal year.
+	if value := get("pillar"); value != "" && plan.SupervisorPlanID == nil {
+		if id, err := primitive.ObjectIDFromHex(value); err == nil {
+			plan.PillarID = &id
+		} else if fiscalYear, err := pu.fiscalYearRepository.FindByDate(ctx, plan.StartDate); err == nil {
+			pillar, err := pu.pillarRepository.GetPillarByCode(ctx, fiscalYear.Year, value)
+			if errors.Is(err, domain.ErrPillarNotFound) {
+				fail("pillar", fmt.Sprintf("no pillar %q in fiscal year %d", value, fiscalYear.Year))
+				return nil, rowErrors
+			} else if err != nil {
+				fail("pillar", err.Error())
+				return nil, rowErrors
+			}
+			plan.PillarID = &pillar.ID
+		}
+	}
+
+	if err := pu.preparePlan(ctx, plan, request.Draft); err != nil {
+		fail(importErrorColumn(err), err.Error())
+		return nil, rowErrors
+	}
+	return plan, nil
+}
+
+// importErrorColumn names the column a validation error is about.
+func importErrorColumn(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrInvalidKPI):
+		return "target"
+	case errors.Is(err, domain.ErrInvalidPillar):
+		return "pillar"
+	case errors.Is(err, domain.ErrInvalidParentPlan):
+		return "parent_plan_id"
+	case errors.Is(err, domain.ErrInvalidPeriod), errors.Is(err, domain.ErrClosedPeriod):
+		return "start_date"
+	}
+	return ""
+}
+
+// parseImportDate reads a date written as YYYY-MM-DD, as an RFC 3339 time
+// or, in XLSX files, as a serial number. Written dates are in calendar.
+func parseImportDate(value, format, calendar string) (time.Time, error) {
+	if serial, err := strconv.ParseFloat(value, 64); err == nil && format == export.XLSX {
+		t, err := excelize.ExcelDateToTime(serial, false)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%q is not a date", value)
+		}
+		return t, nil
+	}
+
+	if calendar == domain.CalendarEthiopian {
+		if len(value) < 10 {
+			return time.Time{}, fmt.Errorf("%q is not an Ethiopian date", value)
+		}
+		date, err := ethiocal.Parse(value[:10])
+		if err != nil {
+			return time.Time{}, err
+		}
+		return date.Time(time.UTC), nil
+	}
+
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("%q is not a date, use YYYY-MM-DD", value)
+}
+
+// normalizeHeader lowercases a header and joins its words with underscores,
+// so "Start Date" and "start_date" match.
+func normalizeHeader(header string) string {
+	words := strings.FieldsFunc(strings.ToLower(header), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, "_")
+}
+
+func isBlankRecord(record []string) bool {
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
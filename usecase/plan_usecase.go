@@ -3,10 +3,14 @@ package usecase
 import (
 	"fmt"
 	"plan/domain"
+	"plan/internal/diffutil"
 
 	// "plan/internal/tokenutil"
 	"context"
 	"errors"
+	"log"
+	"sort"
+	"strings"
 
 	// "plan/internal/userutil"
 
@@ -18,33 +22,59 @@ import (
 )
 
 type planUsecaseStruct struct {
-	planRepository domain.PlanRepository
-	contextTimeout time.Duration
+	planRepository           domain.PlanRepository
+	reportRepository         domain.ReportRepository
+	announcementRepository   domain.AnnouncementRepository
+	commentRepository        domain.CommentRepository
+	userRepository           domain.UserRepository
+	roleRuleRepository       domain.RoleRuleRepository
+	approvalPolicyRepository domain.ApprovalPolicyRepository
+	transitionRepository     domain.TransitionRepository
+	revisionRepository       domain.RevisionRepository
+	pillarRepository         domain.PillarRepository
+	fiscalYearRepository     domain.FiscalYearRepository
+	transactor               domain.Transactor
+	notifier                 domain.Notifier
+	webhooks                 domain.WebhookDispatcher
+	contextTimeout           time.Duration
 }
 
-func NewPlanUsecase(planRepositoryPAR domain.PlanRepository, timeout time.Duration) domain.PlanUsecase {
+func NewPlanUsecase(planRepositoryPAR domain.PlanRepository, reportRepository domain.ReportRepository, announcementRepository domain.AnnouncementRepository, commentRepository domain.CommentRepository, userRepository domain.UserRepository, roleRuleRepository domain.RoleRuleRepository, approvalPolicyRepository domain.ApprovalPolicyRepository, transitionRepository domain.TransitionRepository, revisionRepository domain.RevisionRepository, pillarRepository domain.PillarRepository, fiscalYearRepository domain.FiscalYearRepository, transactor domain.Transactor, notifier domain.Notifier, webhooks domain.WebhookDispatcher, timeout time.Duration) domain.PlanUsecase {
 	return &planUsecaseStruct{
-		planRepository: planRepositoryPAR,
-		contextTimeout: timeout,
+		planRepository:           planRepositoryPAR,
+		reportRepository:         reportRepository,
+		announcementRepository:   announcementRepository,
+		commentRepository:        commentRepository,
+		userRepository:           userRepository,
+		roleRuleRepository:       roleRuleRepository,
+		approvalPolicyRepository: approvalPolicyRepository,
+		transitionRepository:     transitionRepository,
+		revisionRepository:       revisionRepository,
+		pillarRepository:         pillarRepository,
+		fiscalYearRepository:     fiscalYearRepository,
+		transactor:               transactor,
+		notifier:                 notifier,
+		webhooks:                 webhooks,
+		contextTimeout:           timeout,
 	}
 }
-func (uc *planUsecaseStruct) GetPlansByOwnerID(ctx context.Context, ownerID primitive.ObjectID,datatype string) ([]domain.Plan, error) {
+func (uc *planUsecaseStruct) GetPlansByOwnerID(ctx context.Context, ownerID primitive.ObjectID) ([]domain.Plan, error) {
 	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
 	defer cancel()
-	return uc.planRepository.FindByOwnerID(ctx, ownerID,datatype)
+	return uc.planRepository.FindByOwnerID(ctx, ownerID)
 }
 
-func (uc *planUsecaseStruct) GetReportsByUserID(ctx context.Context, userID primitive.ObjectID,datatype string) ([]domain.Report, error) {
+func (uc *planUsecaseStruct) GetReportsByUserID(ctx context.Context, userID primitive.ObjectID) ([]domain.Report, error) {
 	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
 	defer cancel()
-	return uc.planRepository.FindByUserID(ctx, userID,datatype)
+	return uc.reportRepository.FindByUserID(ctx, userID)
 }
 func (uc *planUsecaseStruct) DeleteAnnouncement(ctx context.Context, id primitive.ObjectID) error {
 	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
 	defer cancel()
 
 	// Call the repository to delete
-	err := uc.planRepository.Delete(ctx, id)
+	err := uc.announcementRepository.DeleteAnnouncement(ctx, id)
 	if err != nil {
 		if err.Error() == "announcement not found" {
 			return errors.New("announcement not found")
@@ -58,7 +88,7 @@ func (ru *planUsecaseStruct) GetAllAnnouncements(ctx context.Context) ([]domain.
 	ctx, cancel := context.WithTimeout(ctx, ru.contextTimeout)
 	defer cancel()
 
-	return ru.planRepository.GetAllAnnouncements(ctx)
+	return ru.announcementRepository.GetAllAnnouncements(ctx)
 }
 
 func (ru *planUsecaseStruct) PublishAnnouncement(ctx context.Context, announcement *domain.Announcement) error {
@@ -70,113 +100,1749 @@ func (ru *planUsecaseStruct) PublishAnnouncement(ctx context.Context, announceme
 	}
 
 	announcement.CreatedTime = time.Now()
-	return ru.planRepository.CreateAnnouncement(ctx, announcement)
+	if err := ru.announcementRepository.CreateAnnouncement(ctx, announcement); err != nil {
+		return err
+	}
+
+	// The announcement is published; failing to tell everyone must not make a
+	// retry publish it twice.
+	recipients, err := ru.userRepository.FindVerifiedUserIDs(ctx)
+	if err != nil {
+		log.Printf("failed to notify users of announcement %s: %v", announcement.ID.Hex(), err)
+		return nil
+	}
+	notifications := make([]domain.Notification, len(recipients))
+	for i, recipientID := range recipients {
+		notifications[i] = domain.Notification{
+			RecipientID: recipientID,
+			Type:        domain.NotifyAnnouncementPublished,
+			Title:       announcement.Title,
+			Message:     announcement.Description,
+			SubjectType: "announcement",
+			SubjectID:   &announcement.ID,
+		}
+	}
+	if err := ru.notifier.Notify(ctx, notifications...); err != nil {
+		log.Printf("failed to notify users of announcement %s: %v", announcement.ID.Hex(), err)
+	}
+	return nil
+}
+func (ru *planUsecaseStruct) UpdateReport(c context.Context, claims *domain.JwtCustomClaims, reportID string, updatedReport *domain.Report) error {
+	ctx, cancel := context.WithTimeout(c, ru.contextTimeout)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(reportID)
+	if err != nil {
+		return errors.New("invalid report ID format")
+	}
+
+	existing, err := ru.reportRepository.GetReportByID(ctx, objectID)
+	if err != nil {
+		return err
+	}
+	if existing.ReportUserID != claims.UserID {
+		return errors.New("unauthorized access")
+	}
+	if !domain.IsEditable(existing.Status) {
+		return fmt.Errorf("%w: %s reports cannot be edited", domain.ErrInvalidTransition, existing.Status)
+	}
+
+	// The routing fields and the plan are not part of the editable content.
+	updatedReport.Type = existing.Type
+	updatedReport.SupervisorID = existing.SupervisorID
+	updatedReport.SupervisorName = existing.SupervisorName
+	updatedReport.PlanID = existing.PlanID
+	updatedReport.ReportUserID = existing.ReportUserID
+
+	// The report stays in its period unless the edit moves it.
+	if updatedReport.FiscalYear == 0 && updatedReport.Quarter == 0 {
+		updatedReport.FiscalYear = existing.FiscalYear
+		updatedReport.Quarter = existing.Quarter
+	}
+
+	// Editing a report sent back for revision resubmits it; drafts stay drafts.
+	updatedReport.Status = existing.Status
+	if existing.Status == domain.StatusRevisionRequested {
+		updatedReport.Status = domain.StatusSubmitted
+	}
+
+	period, err := ru.alignReportPeriod(ctx, updatedReport, updatedReport.Status == domain.StatusSubmitted)
+	if err != nil {
+		return err
+	}
+	if err := ru.alignReportPlan(ctx, updatedReport, objectID, period); err != nil {
+		return err
+	}
+
+	return ru.transactor.WithTransaction(ctx, func(tx context.Context) error {
+		if err := ru.reportRepository.UpdateReport(tx, objectID, existing.Status, updatedReport); err != nil {
+			return err
+		}
+		if err := ru.logTransition(tx, objectID, "report", existing.Status, updatedReport.Status, claims.UserID, ""); err != nil {
+			return err
+		}
+		return ru.recordReportRevision(tx, objectID, claims.UserID)
+	})
+}
+
+func (pu *planUsecaseStruct) UpdatePlan(c context.Context, claims *domain.JwtCustomClaims, planID string, updatedPlan *domain.Plan) error {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(planID)
+	if err != nil {
+		return errors.New("invalid plan ID format")
+	}
+
+	existing, err := pu.planRepository.GetPlanByID(ctx, objectID)
+	if err != nil {
+		return err
+	}
+	if existing.OwnerID != claims.UserID {
+		return errors.New("unauthorized access")
+	}
+	if !domain.IsEditable(existing.Status) {
+		return fmt.Errorf("%w: %s plans cannot be edited", domain.ErrInvalidTransition, existing.Status)
+	}
+
+	// Actual and achievement come from approved reports, not from the edit.
+	if err := updatedPlan.Quantify.Normalize(); err != nil {
+		return err
+	}
+	updatedPlan.Quantify.Actual = existing.Quantify.Actual
+	updatedPlan.Quantify.UpdateAchievement()
+
+	if err := pu.alignCalendar(ctx, updatedPlan, existing.Status != domain.StatusDraft); err != nil {
+		return err
+	}
+	if err := pu.alignParent(ctx, updatedPlan, existing.SupervisorID); err != nil {
+		return err
+	}
+
+	// Editing a plan sent back for revision resubmits it through the whole
+	// approval chain; drafts stay drafts.
+	if existing.Status == domain.StatusDraft {
+		updatedPlan.Status = domain.StatusDraft
+		updatedPlan.ApprovalChain = nil
+		updatedPlan.CurrentApproverID = nil
+	} else if err := pu.startApproval(ctx, existing.OwnerID, existing.OwnerRole, updatedPlan); err != nil {
+		return err
+	}
+
+	return pu.transactor.WithTransaction(ctx, func(tx context.Context) error {
+		if err := pu.planRepository.UpdatePlan(tx, objectID, existing.Status, updatedPlan); err != nil {
+			return err
+		}
+		if err := pu.logSubmission(tx, objectID, existing.Status, updatedPlan.Status, claims.UserID, ""); err != nil {
+			return err
+		}
+		return pu.recordPlanRevision(tx, objectID, claims.UserID)
+	})
+}
+
+func (ru *planUsecaseStruct) UpdateReportStatus(c context.Context, reportID primitive.ObjectID, supervisorID primitive.ObjectID, status, comment string) error {
+	ctx, cancel := context.WithTimeout(c, ru.contextTimeout)
+	defer cancel()
+
+	to, err := domain.DecisionStatus(status)
+	if err != nil {
+		return err
+	}
+
+	report, err := ru.reportRepository.GetReportByID(ctx, reportID)
+	if err != nil {
+		return err
+	}
+	if report.SupervisorID != supervisorID {
+		return errors.New("report not found")
+	}
+	if err := domain.ValidateTransition(report.Status, to); err != nil {
+		return err
+	}
+
+	// The decision and the plan's KPI are stored together, or not at all.
+	from := report.Status
+	err = ru.transactor.WithTransaction(ctx, func(tx context.Context) error {
+		if err := ru.reportRepository.UpdateReportStatus(tx, reportID, supervisorID, to, comment); err != nil {
+			return err
+		}
+		if err := ru.logTransition(tx, reportID, "report", from, to, supervisorID, comment); err != nil {
+			return err
+		}
+		if err := ru.recordReportRevision(tx, reportID, supervisorID); err != nil {
+			return err
+		}
+		if to == domain.StatusApproved && !report.PlanID.IsZero() {
+			if err := ru.applyReportToPlan(tx, report, supervisorID); err != nil {
+				return err
+			}
+		}
+		decided := *report
+		decided.Status = to
+		return ru.dispatchReportWebhooks(tx, &decided, supervisorID, comment)
+	})
+	if err != nil {
+		return err
+	}
+
+	report.Status = to
+	ru.notifyReportStatus(ctx, report, supervisorID, comment)
+	return nil
+}
+
+// applyReportToPlan makes the value of the plan's approved report for its
+// latest period the actual value of the plan's KPI and recomputes the
+// achievement, so approving a late report for an earlier period does not
+// roll the plan back.
+func (ru *planUsecaseStruct) applyReportToPlan(ctx context.Context, report *domain.Report, approverID primitive.ObjectID) error {
+	plan, err := ru.planRepository.GetPlanByID(ctx, report.PlanID)
+	if err != nil {
+		return err
+	}
+	latest, err := ru.reportRepository.FindLatestApproved(ctx, report.PlanID)
+	if err != nil {
+		return err
+	}
+
+	plan.Quantify.Actual = latest.AccomplishedValue
+	plan.Quantify.UpdateAchievement()
+	if err := ru.planRepository.UpdateKPIActual(ctx, plan.ID, plan.Quantify.Actual, plan.Quantify.Achievement); err != nil {
+		return err
+	}
+
+	return ru.recordPlanRevision(ctx, plan.ID, approverID)
+}
+
+// UpdatePlanStatus records the caller's decision on the step the plan is
+// waiting on. An approval hands the plan to the next approver in the chain,
+// leaving it under review, and approves it once the chain is complete; a
+// revision request ends the chain.
+func (pu *planUsecaseStruct) UpdatePlanStatus(c context.Context, planID primitive.ObjectID, supervisorID primitive.ObjectID, status, comment string) error {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	decision, err := domain.DecisionStatus(status)
+	if err != nil {
+		return err
+	}
+
+	plan, err := pu.planRepository.GetPlanByID(ctx, planID)
+	if err != nil {
+		return err
+	}
+
+	// Plans submitted before approval chains existed get one now, stored
+	// along with the decision. The legacy document has no current approver to
+	// guard the update on.
+	var legacy *domain.Plan
+	if len(plan.ApprovalChain) == 0 && plan.Status == domain.StatusSubmitted {
+		if err := pu.startApproval(ctx, plan.OwnerID, plan.OwnerRole, plan); err != nil {
+			return err
+		}
+
+		// Nobody is left to approve it, so it was approved on the spot.
+		if plan.Status == domain.StatusApproved {
+			err := pu.transactor.WithTransaction(ctx, func(tx context.Context) error {
+				if err := pu.planRepository.UpdatePlan(tx, plan.ID, domain.StatusSubmitted, plan); err != nil {
+					return err
+				}
+				if err := pu.logSubmission(tx, planID, domain.StatusSubmitted, plan.Status, supervisorID, comment); err != nil {
+					return err
+				}
+				if err := pu.recordPlanRevision(tx, planID, supervisorID); err != nil {
+					return err
+				}
+				return pu.dispatchPlanWebhooks(tx, plan, supervisorID, comment)
+			})
+			if err != nil {
+				return err
+			}
+			pu.notifyPlanStatus(ctx, plan, supervisorID, comment)
+			if decision != domain.StatusApproved {
+				return fmt.Errorf("%w: the plan needs no approval and was approved", domain.ErrInvalidTransition)
+			}
+			return nil
+		}
+
+		prepared := *plan
+		prepared.ApprovalChain = append([]domain.ApprovalStep(nil), plan.ApprovalChain...)
+		legacy = &prepared
+	}
+
+	if plan.CurrentApproverID == nil {
+		return errors.New("plan is not awaiting approval")
+	}
+	if *plan.CurrentApproverID != supervisorID {
+		return errors.New("unauthorized access")
+	}
+
+	now := time.Now()
+	level := -1
+	for i := range plan.ApprovalChain {
+		if plan.ApprovalChain[i].Status == domain.StepPending {
+			level = i
+			break
+		}
+	}
+	if level < 0 {
+		return errors.New("plan is not awaiting approval")
+	}
+
+	step := &plan.ApprovalChain[level]
+	step.Status = domain.StepApproved
+	if decision == domain.StatusRevisionRequested {
+		step.Status = domain.StepRejected
+	}
+	step.Comment = comment
+	step.DecidedAt = &now
+	plan.Comment = comment
+
+	from := plan.Status
+	switch {
+	case decision == domain.StatusRevisionRequested:
+		plan.Status = domain.StatusRevisionRequested
+		plan.CurrentApproverID = nil
+	case level+1 < len(plan.ApprovalChain):
+		next := plan.ApprovalChain[level+1].ApproverID
+		plan.Status = domain.StatusUnderReview
+		plan.CurrentApproverID = &next
+	default:
+		plan.Status = domain.StatusApproved
+		plan.CurrentApproverID = nil
+	}
+
+	// Intermediate approvals after the first leave the plan under review.
+	if plan.Status != from {
+		if err := domain.ValidateTransition(from, plan.Status); err != nil {
+			return err
+		}
+	}
+
+	err = pu.transactor.WithTransaction(ctx, func(tx context.Context) error {
+		if legacy != nil {
+			if err := pu.planRepository.UpdatePlan(tx, planID, domain.StatusSubmitted, legacy); err != nil {
+				return err
+			}
+		}
+		if err := pu.planRepository.UpdateApprovalState(tx, plan, supervisorID); err != nil {
+			return err
+		}
+		if err := pu.logTransition(tx, planID, "plan", from, plan.Status, supervisorID, comment); err != nil {
+			return err
+		}
+		if err := pu.recordPlanRevision(tx, planID, supervisorID); err != nil {
+			return err
+		}
+		return pu.dispatchPlanWebhooks(tx, plan, supervisorID, comment)
+	})
+	if err != nil {
+		return err
+	}
+
+	pu.notifyPlanStatus(ctx, plan, supervisorID, comment)
+	return nil
+}
+
+// TransitionPlan applies the owner-driven moves of the lifecycle: submitting,
+// withdrawing to draft, closing and archiving. Review decisions go through
+// UpdatePlanStatus.
+func (pu *planUsecaseStruct) TransitionPlan(c context.Context, claims *domain.JwtCustomClaims, planID primitive.ObjectID, request *domain.TransitionRequest) error {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	plan, err := pu.planRepository.GetPlanByID(ctx, planID)
+	if err != nil {
+		return err
+	}
+	if err := authorizeOwnerTransition(claims, plan.OwnerID, request.To); err != nil {
+		return err
+	}
+
+	from := plan.Status
+	if err := domain.ValidateTransition(from, request.To); err != nil {
+		return err
+	}
+
+	switch request.To {
+	case domain.StatusSubmitted:
+		if err := pu.alignCalendar(ctx, plan, true); err != nil {
+			return err
+		}
+		if err := pu.startApproval(ctx, plan.OwnerID, plan.OwnerRole, plan); err != nil {
+			return err
+		}
+	case domain.StatusDraft:
+		plan.Status = domain.StatusDraft
+		plan.ApprovalChain = nil
+		plan.CurrentApproverID = nil
+	}
+
+	err = pu.transactor.WithTransaction(ctx, func(tx context.Context) error {
+		switch request.To {
+		case domain.StatusSubmitted, domain.StatusDraft:
+			if err := pu.planRepository.UpdatePlan(tx, planID, from, plan); err != nil {
+				return err
+			}
+		default:
+			if err := pu.planRepository.UpdateStatus(tx, planID, from, request.To); err != nil {
+				return err
+			}
+			plan.Status = request.To
+		}
+
+		if err := pu.logSubmission(tx, planID, from, plan.Status, claims.UserID, request.Comment); err != nil {
+			return err
+		}
+		if err := pu.recordPlanRevision(tx, planID, claims.UserID); err != nil {
+			return err
+		}
+		if request.To == domain.StatusSubmitted {
+			return pu.dispatchPlanWebhooks(tx, plan, claims.UserID, request.Comment)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if request.To == domain.StatusSubmitted {
+		pu.notifyPlanStatus(ctx, plan, claims.UserID, request.Comment)
+	}
+	return nil
+}
+
+// logSubmission logs a plan's move from one status to another. A plan with
+// nobody to approve it is approved as soon as it is submitted, which is logged
+// as both steps so the history only holds transitions of the lifecycle.
+func (pu *planUsecaseStruct) logSubmission(ctx context.Context, planID primitive.ObjectID, from, to string, actorID primitive.ObjectID, comment string) error {
+	if to == domain.StatusApproved && from != domain.StatusSubmitted && from != domain.StatusUnderReview {
+		if err := pu.logTransition(ctx, planID, "plan", from, domain.StatusSubmitted, actorID, comment); err != nil {
+			return err
+		}
+		from = domain.StatusSubmitted
+	}
+	return pu.logTransition(ctx, planID, "plan", from, to, actorID, comment)
+}
+
+func (ru *planUsecaseStruct) TransitionReport(c context.Context, claims *domain.JwtCustomClaims, reportID primitive.ObjectID, request *domain.TransitionRequest) error {
+	ctx, cancel := context.WithTimeout(c, ru.contextTimeout)
+	defer cancel()
+
+	report, err := ru.reportRepository.GetReportByID(ctx, reportID)
+	if err != nil {
+		return err
+	}
+	if err := authorizeOwnerTransition(claims, report.ReportUserID, request.To); err != nil {
+		return err
+	}
+	if err := domain.ValidateTransition(report.Status, request.To); err != nil {
+		return err
+	}
+	if request.To == domain.StatusSubmitted {
+		period, err := ru.alignReportPeriod(ctx, report, true)
+		if err != nil {
+			return err
+		}
+		if err := ru.alignReportPlan(ctx, report, reportID, period); err != nil {
+			return err
+		}
+	}
+
+	err = ru.transactor.WithTransaction(ctx, func(tx context.Context) error {
+		if err := ru.reportRepository.UpdateStatus(tx, reportID, report.Status, request.To); err != nil {
+			return err
+		}
+		if err := ru.logTransition(tx, reportID, "report", report.Status, request.To, claims.UserID, request.Comment); err != nil {
+			return err
+		}
+		if err := ru.recordReportRevision(tx, reportID, claims.UserID); err != nil {
+			return err
+		}
+		if request.To == domain.StatusSubmitted {
+			submitted := *report
+			submitted.Status = request.To
+			return ru.dispatchReportWebhooks(tx, &submitted, claims.UserID, request.Comment)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if request.To == domain.StatusSubmitted {
+		report.Status = request.To
+		ru.notifyReportStatus(ctx, report, claims.UserID, request.Comment)
+	}
+	return nil
+}
+
+// authorizeOwnerTransition lets owners submit and withdraw their documents,
+// and owners or the planning office close and archive them.
+func authorizeOwnerTransition(claims *domain.JwtCustomClaims, ownerID primitive.ObjectID, to string) error {
+	switch to {
+	case domain.StatusSubmitted, domain.StatusDraft:
+		if claims.UserID != ownerID {
+			return errors.New("unauthorized access")
+		}
+	case domain.StatusClosed, domain.StatusArchived:
+		if claims.UserID != ownerID && claims.Role != domain.RolePlanningOffice {
+			return errors.New("unauthorized access")
+		}
+	default:
+		return fmt.Errorf("%w: %s is a review decision", domain.ErrInvalidTransition, to)
+	}
+	return nil
+}
+
+func (pu *planUsecaseStruct) GetPlanTransitions(c context.Context, claims *domain.JwtCustomClaims, planID primitive.ObjectID) ([]domain.Transition, error) {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	plan, err := pu.planRepository.GetPlanByID(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+	if !canViewPlan(claims, plan) {
+		return nil, errors.New("unauthorized access")
+	}
+
+	return pu.transitionRepository.FindByDocument(ctx, planID)
+}
+
+func (ru *planUsecaseStruct) GetReportTransitions(c context.Context, claims *domain.JwtCustomClaims, reportID primitive.ObjectID) ([]domain.Transition, error) {
+	ctx, cancel := context.WithTimeout(c, ru.contextTimeout)
+	defer cancel()
+
+	report, err := ru.reportRepository.GetReportByID(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	if !canViewReport(claims, report) {
+		return nil, errors.New("unauthorized access")
+	}
+
+	return ru.transitionRepository.FindByDocument(ctx, reportID)
+}
+
+// recordPlanRevision snapshots the plan as stored after a write.
+func (pu *planUsecaseStruct) recordPlanRevision(ctx context.Context, planID primitive.ObjectID, authorID primitive.ObjectID) error {
+	plan, err := pu.planRepository.GetPlanByID(ctx, planID)
+	if err != nil {
+		return err
+	}
+
+	return pu.revisionRepository.SaveRevision(ctx, &domain.Revision{
+		DocumentID:   planID,
+		DocumentType: "plan",
+		AuthorID:     authorID,
+		Plan:         plan,
+	})
+}
+
+// recordReportRevision snapshots the report as stored after a write.
+func (ru *planUsecaseStruct) recordReportRevision(ctx context.Context, reportID primitive.ObjectID, authorID primitive.ObjectID) error {
+	report, err := ru.reportRepository.GetReportByID(ctx, reportID)
+	if err != nil {
+		return err
+	}
+
+	return ru.revisionRepository.SaveRevision(ctx, &domain.Revision{
+		DocumentID:   reportID,
+		DocumentType: "report",
+		AuthorID:     authorID,
+		Report:       report,
+	})
+}
+
+func (pu *planUsecaseStruct) GetPlanRevisions(c context.Context, claims *domain.JwtCustomClaims, planID primitive.ObjectID) ([]domain.Revision, error) {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	plan, err := pu.planRepository.GetPlanByID(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+	if !canViewPlan(claims, plan) {
+		return nil, errors.New("unauthorized access")
+	}
+
+	return pu.revisionRepository.FindByDocument(ctx, planID)
+}
+
+func (ru *planUsecaseStruct) GetReportRevisions(c context.Context, claims *domain.JwtCustomClaims, reportID primitive.ObjectID) ([]domain.Revision, error) {
+	ctx, cancel := context.WithTimeout(c, ru.contextTimeout)
+	defer cancel()
+
+	report, err := ru.reportRepository.GetReportByID(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	if !canViewReport(claims, report) {
+		return nil, errors.New("unauthorized access")
+	}
+
+	return ru.revisionRepository.FindByDocument(ctx, reportID)
+}
+
+func (pu *planUsecaseStruct) DiffPlanRevisions(c context.Context, claims *domain.JwtCustomClaims, planID primitive.ObjectID, from, to int) (*domain.RevisionDiff, error) {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	plan, err := pu.planRepository.GetPlanByID(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+	if !canViewPlan(claims, plan) {
+		return nil, errors.New("unauthorized access")
+	}
+
+	return pu.diffRevisions(ctx, planID, from, to, func(revision *domain.Revision) interface{} { return revision.Plan })
+}
+
+func (ru *planUsecaseStruct) DiffReportRevisions(c context.Context, claims *domain.JwtCustomClaims, reportID primitive.ObjectID, from, to int) (*domain.RevisionDiff, error) {
+	ctx, cancel := context.WithTimeout(c, ru.contextTimeout)
+	defer cancel()
+
+	report, err := ru.reportRepository.GetReportByID(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	if !canViewReport(claims, report) {
+		return nil, errors.New("unauthorized access")
+	}
+
+	return ru.diffRevisions(ctx, reportID, from, to, func(revision *domain.Revision) interface{} { return revision.Report })
+}
+
+// diffRevisions compares the snapshots of two revisions of a document.
+// updated_at changes on every write and is left out.
+func (pu *planUsecaseStruct) diffRevisions(ctx context.Context, documentID primitive.ObjectID, from, to int, snapshot func(*domain.Revision) interface{}) (*domain.RevisionDiff, error) {
+	fromRevision, err := pu.revisionRepository.GetRevision(ctx, documentID, from)
+	if err != nil {
+		return nil, err
+	}
+	toRevision, err := pu.revisionRepository.GetRevision(ctx, documentID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := diffutil.Diff(snapshot(fromRevision), snapshot(toRevision), "updated_at")
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.RevisionDiff{From: from, To: to, Changes: changes}, nil
+}
+
+// canViewReport lets the author, their supervisor and the planning office see
+// a report's history.
+func canViewReport(claims *domain.JwtCustomClaims, report *domain.Report) bool {
+	return claims.Role == domain.RolePlanningOffice || report.ReportUserID == claims.UserID || report.SupervisorID == claims.UserID
+}
+
+// logTransition records a status change. Calls that leave the status as it
+// was are not logged.
+func (pu *planUsecaseStruct) logTransition(ctx context.Context, documentID primitive.ObjectID, documentType, from, to string, actorID primitive.ObjectID, comment string) error {
+	if from == to {
+		return nil
+	}
+
+	return pu.transitionRepository.LogTransition(ctx, &domain.Transition{
+		DocumentID:   documentID,
+		DocumentType: documentType,
+		From:         from,
+		To:           to,
+		ActorID:      actorID,
+		Comment:      comment,
+	})
+}
+
+// planWebhookEvents are the webhook events fired when a plan reaches a status.
+var planWebhookEvents = map[string]string{
+	domain.StatusSubmitted:         domain.WebhookPlanSubmitted,
+	domain.StatusApproved:          domain.WebhookPlanApproved,
+	domain.StatusRevisionRequested: domain.WebhookPlanRevisionRequested,
+}
+
+// reportWebhookEvents are the webhook events fired when a report reaches a
+// status.
+var reportWebhookEvents = map[string]string{
+	domain.StatusSubmitted:         domain.WebhookReportSubmitted,
+	domain.StatusApproved:          domain.WebhookReportApproved,
+	domain.StatusRevisionRequested: domain.WebhookReportRevisionRequested,
+}
+
+// dispatchPlanWebhooks queues the webhooks subscribed to the plan's new
+// status. It runs in the transaction storing the status, so an event is
+// queued exactly when the change is saved.
+func (pu *planUsecaseStruct) dispatchPlanWebhooks(ctx context.Context, plan *domain.Plan, actorID primitive.ObjectID, comment string) error {
+	eventType, ok := planWebhookEvents[plan.Status]
+	if !ok {
+		return nil
+	}
+	data := map[string]interface{}{"plan": plan, "actor_id": actorID, "comment": comment}
+	return pu.webhooks.Dispatch(ctx, eventType, data)
+}
+
+// dispatchReportWebhooks queues the webhooks subscribed to the report's new
+// status, in the transaction storing it.
+func (ru *planUsecaseStruct) dispatchReportWebhooks(ctx context.Context, report *domain.Report, actorID primitive.ObjectID, comment string) error {
+	eventType, ok := reportWebhookEvents[report.Status]
+	if !ok {
+		return nil
+	}
+	data := map[string]interface{}{"report": report, "actor_id": actorID, "comment": comment}
+	return ru.webhooks.Dispatch(ctx, eventType, data)
+}
+
+// notifyPlanStatus tells whoever has to act on the plan's new status: the
+// approver it is waiting on, or its owner once it is decided. Nobody is told
+// about their own action. The change is already saved by then, so failures
+// are logged rather than returned.
+func (pu *planUsecaseStruct) notifyPlanStatus(ctx context.Context, plan *domain.Plan, actorID primitive.ObjectID, comment string) {
+
+	notification := domain.Notification{
+		SubjectType: "plan",
+		SubjectID:   &plan.ID,
+		ActorID:     &actorID,
+	}
+
+	switch plan.Status {
+	case domain.StatusSubmitted, domain.StatusUnderReview:
+		if plan.CurrentApproverID == nil {
+			return
+		}
+		notification.RecipientID = *plan.CurrentApproverID
+		notification.Type = domain.NotifyPlanSubmitted
+		notification.Title = "Plan awaiting your approval"
+		notification.Message = fmt.Sprintf("%s submitted %q for your approval.", plan.OwnerName, plan.Title)
+	case domain.StatusApproved:
+		notification.RecipientID = plan.OwnerID
+		notification.Type = domain.NotifyPlanApproved
+		notification.Title = "Plan approved"
+		notification.Message = fmt.Sprintf("%q was approved.", plan.Title)
+	case domain.StatusRevisionRequested:
+		notification.RecipientID = plan.OwnerID
+		notification.Type = domain.NotifyPlanRejected
+		notification.Title = "Plan sent back for revision"
+		notification.Message = fmt.Sprintf("%q was sent back for revision.", plan.Title)
+		if comment != "" {
+			notification.Message = fmt.Sprintf("%q was sent back for revision: %s", plan.Title, comment)
+		}
+	default:
+		return
+	}
+
+	if notification.RecipientID == actorID {
+		return
+	}
+	if err := pu.notifier.Notify(ctx, notification); err != nil {
+		log.Printf("failed to notify user %s of plan %s: %v", notification.RecipientID.Hex(), plan.ID.Hex(), err)
+	}
+}
+
+// notifyReportStatus tells the supervisor the report was submitted to them,
+// or its owner that it was decided. Like notifyPlanStatus, it logs its
+// failures.
+func (ru *planUsecaseStruct) notifyReportStatus(ctx context.Context, report *domain.Report, actorID primitive.ObjectID, comment string) {
+
+	notification := domain.Notification{
+		SubjectType: "report",
+		SubjectID:   &report.ID,
+		ActorID:     &actorID,
+	}
+
+	switch report.Status {
+	case domain.StatusSubmitted:
+		if report.SupervisorID.IsZero() {
+			return
+		}
+		notification.RecipientID = report.SupervisorID
+		notification.Type = domain.NotifyReportSubmitted
+		notification.Title = "Report awaiting your approval"
+		notification.Message = fmt.Sprintf("A report on %q was submitted for your approval.", report.ReportTitle)
+	case domain.StatusApproved:
+		notification.RecipientID = report.ReportUserID
+		notification.Type = domain.NotifyReportApproved
+		notification.Title = "Report approved"
+		notification.Message = fmt.Sprintf("Your report on %q was approved.", report.ReportTitle)
+	case domain.StatusRevisionRequested:
+		notification.RecipientID = report.ReportUserID
+		notification.Type = domain.NotifyReportRejected
+		notification.Title = "Report sent back for revision"
+		notification.Message = fmt.Sprintf("Your report on %q was sent back for revision.", report.ReportTitle)
+		if comment != "" {
+			notification.Message = fmt.Sprintf("Your report on %q was sent back for revision: %s", report.ReportTitle, comment)
+		}
+	default:
+		return
+	}
+
+	if notification.RecipientID == actorID {
+		return
+	}
+	if err := ru.notifier.Notify(ctx, notification); err != nil {
+		log.Printf("failed to notify user %s of report %s: %v", notification.RecipientID.Hex(), report.ID.Hex(), err)
+	}
+}
+
+// startApproval builds a fresh approval chain for the owner's plan and sets it
+// pending on the first approver. Owners with nobody above them have their
+// plans approved straight away.
+func (pu *planUsecaseStruct) startApproval(ctx context.Context, ownerID primitive.ObjectID, ownerRole string, plan *domain.Plan) error {
+	chain, err := pu.buildApprovalChain(ctx, ownerID, ownerRole)
+	if err != nil {
+		return err
+	}
+
+	plan.ApprovalChain = chain
+	if len(chain) == 0 {
+		plan.Status = domain.StatusApproved
+		plan.CurrentApproverID = nil
+		return nil
+	}
+
+	plan.Status = domain.StatusSubmitted
+	plan.CurrentApproverID = &chain[0].ApproverID
+	return nil
+}
+
+// buildApprovalChain walks the owner's chain of command up to the final
+// approver role of the owner's policy, or stops at the direct supervisor when
+// the role has no policy. When nobody in the chain holds the final role, as
+// when the post is vacant, the chain stops at the highest role below it.
+func (pu *planUsecaseStruct) buildApprovalChain(ctx context.Context, ownerID primitive.ObjectID, ownerRole string) ([]domain.ApprovalStep, error) {
+	superiors, err := pu.userRepository.GetChainOfCommand(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if len(superiors) == 0 {
+		return []domain.ApprovalStep{}, nil
+	}
+
+	approvers := superiors[:1]
+	if policy, err := pu.approvalPolicyRepository.GetPolicy(ctx, ownerRole); err == nil {
+		rules, err := pu.roleRuleRepository.ListRules(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ranks := make(map[string]int, len(rules))
+		for _, rule := range rules {
+			ranks[rule.Role] = rule.Rank
+		}
+		finalRank, rankKnown := ranks[policy.FinalApproverRole]
+
+		for i, superior := range superiors {
+			if rank, ok := ranks[superior.Role]; i > 0 && rankKnown && ok && rank > finalRank {
+				break
+			}
+			approvers = superiors[:i+1]
+			if superior.Role == policy.FinalApproverRole {
+				break
+			}
+		}
+	}
+
+	chain := make([]domain.ApprovalStep, len(approvers))
+	for i, approver := range approvers {
+		chain[i] = domain.ApprovalStep{
+			Level:        i + 1,
+			ApproverID:   approver.ID,
+			ApproverName: approver.Full_Name,
+			ApproverRole: approver.Role,
+			Status:       domain.StepPending,
+		}
+	}
+	return chain, nil
+}
+
+func (pu *planUsecaseStruct) GetApprovalChain(c context.Context, claims *domain.JwtCustomClaims, planID primitive.ObjectID) ([]domain.ApprovalStep, error) {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	plan, err := pu.planRepository.GetPlanByID(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canViewPlan(claims, plan) {
+		return nil, errors.New("unauthorized access")
+	}
+
+	return plan.ApprovalChain, nil
+}
+
+// canViewPlan lets the owner, anyone in the approval chain and the planning
+// office see a plan's history.
+func canViewPlan(claims *domain.JwtCustomClaims, plan *domain.Plan) bool {
+	if claims.Role == domain.RolePlanningOffice || plan.OwnerID == claims.UserID || plan.SupervisorID == claims.UserID {
+		return true
+	}
+	for _, step := range plan.ApprovalChain {
+		if step.ApproverID == claims.UserID {
+			return true
+		}
+	}
+	return false
+}
+
+func (pu *planUsecaseStruct) ListApprovalPolicies(c context.Context) ([]domain.ApprovalPolicy, error) {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	return pu.approvalPolicyRepository.ListPolicies(ctx)
+}
+
+// SaveApprovalPolicy only accepts a final approver role that sits above the
+// owner role in the role hierarchy.
+func (pu *planUsecaseStruct) SaveApprovalPolicy(c context.Context, policy *domain.ApprovalPolicy) error {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	rule, err := pu.roleRuleRepository.GetRule(ctx, policy.OwnerRole)
+	if err != nil {
+		return fmt.Errorf("unknown role: %s", policy.OwnerRole)
+	}
+
+	for superior := rule.SuperiorRole; superior != ""; {
+		if superior == policy.FinalApproverRole {
+			return pu.approvalPolicyRepository.SavePolicy(ctx, policy)
+		}
+		next, err := pu.roleRuleRepository.GetRule(ctx, superior)
+		if err != nil {
+			break
+		}
+		superior = next.SuperiorRole
+	}
+
+	return fmt.Errorf("%s is not above %s in the role hierarchy", policy.FinalApproverRole, policy.OwnerRole)
+}
+
+func (pu *planUsecaseStruct) DeleteApprovalPolicy(c context.Context, ownerRole string) error {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	return pu.approvalPolicyRepository.DeletePolicy(ctx, ownerRole)
+}
+
+func (ru *planUsecaseStruct) FetchReportsBySupervisorAndStatus(c context.Context, supervisorID primitive.ObjectID, reportStatus string) ([]domain.Report, error) {
+	ctx, cancel := context.WithTimeout(c, ru.contextTimeout)
+	defer cancel()
+
+	return ru.reportRepository.GetReportsBySupervisorAndStatus(ctx, supervisorID, reportStatus)
+}
+
+func (pu *planUsecaseStruct) FetchPlansBySupervisorAndStatus(c context.Context, supervisorID primitive.ObjectID, status string) ([]domain.Plan, error) {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	return pu.planRepository.GetPlansBySupervisorAndStatus(ctx, supervisorID, status)
+}
+
+// CreatePlan saves the plan as a draft or submits it straight to the first
+// approver of its chain.
+func (pu *planUsecaseStruct) CreatePlan(c context.Context, plan *domain.Plan, draft bool) (*primitive.ObjectID, error) {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	if err := pu.assignPlanSupervisor(ctx, plan); err != nil {
+		return nil, err
+	}
+	if err := pu.preparePlan(ctx, plan, draft); err != nil {
+		return nil, err
+	}
+	err := pu.transactor.WithTransaction(ctx, func(tx context.Context) error {
+		return pu.insertPlan(tx, plan)
+	})
+	if err != nil {
+		return nil, err
+	}
+	pu.notifyPlanStatus(ctx, plan, plan.OwnerID, "")
+
+	return &plan.ID, nil
+}
+
+// assignPlanSupervisor sets the plan's supervisor to the owner's current one.
+// The supervisor in the caller's token is the one they had at sign-in and goes
+// stale once they are reassigned.
+func (pu *planUsecaseStruct) assignPlanSupervisor(ctx context.Context, plan *domain.Plan) error {
+	owner, err := pu.userRepository.GetUserByID(ctx, plan.OwnerID)
+	if err != nil {
+		return err
+	}
+	plan.SupervisorID = owner.SupervisorID
+	plan.SupervisorName = owner.To_whom
+	return nil
+}
+
+// assignReportSupervisor sets the report's supervisor to the reporter's
+// current one.
+func (ru *planUsecaseStruct) assignReportSupervisor(ctx context.Context, report *domain.Report) error {
+	reporter, err := ru.userRepository.GetUserByID(ctx, report.ReportUserID)
+	if err != nil {
+		return err
+	}
+	report.SupervisorID = reporter.SupervisorID
+	report.SupervisorName = reporter.To_whom
+	return nil
+}
+
+// preparePlan checks a new plan and fills in the fields set by the system,
+// up to its approval chain unless it is a draft.
+func (pu *planUsecaseStruct) preparePlan(ctx context.Context, plan *domain.Plan, draft bool) error {
+	plan.CreatedAt = time.Now()
+	plan.UpdatedAt = time.Now()
+	plan.ApprovalChain = nil
+	plan.CurrentApproverID = nil
+
+	// The KPI starts at its baseline until a report is approved.
+	if err := plan.Quantify.Normalize(); err != nil {
+		return err
+	}
+	plan.Quantify.Actual = plan.Quantify.Baseline
+	plan.Quantify.UpdateAchievement()
+
+	if err := pu.alignCalendar(ctx, plan, !draft); err != nil {
+		return err
+	}
+	if err := pu.alignParent(ctx, plan, plan.SupervisorID); err != nil {
+		return err
+	}
+
+	if draft {
+		plan.Status = domain.StatusDraft
+		return nil
+	}
+	return pu.startApproval(ctx, plan.OwnerID, plan.OwnerRole, plan)
 }
-func (ru *planUsecaseStruct) UpdateReport(c context.Context, reportID string, updatedReport *domain.Report) error {
-	ctx, cancel := context.WithTimeout(c, ru.contextTimeout)
+
+// insertPlan stores a prepared plan with its first transition and revision
+// and queues its webhooks. It runs in a transaction; callers notify about the
+// plan once it is committed.
+func (pu *planUsecaseStruct) insertPlan(ctx context.Context, plan *domain.Plan) error {
+	if err := pu.planRepository.CreatePlan(ctx, plan); err != nil {
+		return err
+	}
+	if err := pu.logSubmission(ctx, plan.ID, "", plan.Status, plan.OwnerID, ""); err != nil {
+		return err
+	}
+	if err := pu.recordPlanRevision(ctx, plan.ID, plan.OwnerID); err != nil {
+		return err
+	}
+	return pu.dispatchPlanWebhooks(ctx, plan, plan.OwnerID, "")
+}
+func (pu *planUsecaseStruct) GetPlansByStatusAndOwner(ctx context.Context, userID primitive.ObjectID, status string) ([]domain.Plan, error) {
+	c, cancel := context.WithTimeout(ctx, pu.contextTimeout)
 	defer cancel()
 
-	objectID, err := primitive.ObjectIDFromHex(reportID)
+	return pu.planRepository.GetPlansByStatusAndOwner(c, userID, status)
+}
+
+func (pu *planUsecaseStruct) GetPlanTitlesByOwnerID(ctx context.Context, ownerID primitive.ObjectID) ([]string, error) {
+	c, cancel := context.WithTimeout(ctx, pu.contextTimeout)
+	defer cancel()
+
+	return pu.planRepository.GetPlanTitlesByOwnerID(c, ownerID)
+}
+
+// alignParent checks the plan's parent and copies the parent's title and
+// pillar onto the plan. A parent must be an approved plan of the owner's
+// supervisor; plans without one are top-level plans and must name a pillar of
+// the catalog themselves.
+func (pu *planUsecaseStruct) alignParent(ctx context.Context, plan *domain.Plan, supervisorID primitive.ObjectID) error {
+	if plan.SupervisorPlanID == nil || plan.SupervisorPlanID.IsZero() {
+		plan.SupervisorPlanID = nil
+		plan.SuperiorPlan = ""
+		return pu.alignPillar(ctx, plan)
+	}
+
+	parent, err := pu.planRepository.GetPlanByID(ctx, *plan.SupervisorPlanID)
 	if err != nil {
-		return errors.New("invalid report ID format")
+		if err.Error() == "plan not found" {
+			return fmt.Errorf("%w: plan %s not found", domain.ErrInvalidParentPlan, plan.SupervisorPlanID.Hex())
+		}
+		return err
+	}
+	if supervisorID.IsZero() || parent.OwnerID != supervisorID {
+		return fmt.Errorf("%w: the parent plan does not belong to your supervisor", domain.ErrInvalidParentPlan)
+	}
+	if parent.Status != domain.StatusApproved {
+		return fmt.Errorf("%w: the parent plan is %s, not Approved", domain.ErrInvalidParentPlan, parent.Status)
+	}
+	if parent.FiscalYear != plan.FiscalYear {
+		return fmt.Errorf("%w: the parent plan belongs to fiscal year %d", domain.ErrInvalidParentPlan, parent.FiscalYear)
 	}
 
-	return ru.planRepository.UpdateReport(ctx, objectID, updatedReport)
+	plan.SuperiorPlan = parent.Title
+	// A parent from before the catalog has no pillar to inherit; the plan
+	// must name its own.
+	if parent.PillarID == nil || parent.PillarID.IsZero() {
+		return pu.alignPillar(ctx, plan)
+	}
+	plan.PillarID = parent.PillarID
+	plan.AlignedPillary = parent.AlignedPillary
+	return nil
 }
 
-func (pu *planUsecaseStruct) UpdatePlan(c context.Context, planID string, updatedPlan *domain.Plan) error {
-	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
-	defer cancel()
+// alignPillar checks the plan's pillar against the catalog and stores the
+// pillar's name on the plan.
+func (pu *planUsecaseStruct) alignPillar(ctx context.Context, plan *domain.Plan) error {
+	if plan.PillarID == nil || plan.PillarID.IsZero() {
+		return fmt.Errorf("%w: a strategic pillar is required", domain.ErrInvalidPillar)
+	}
 
-	objectID, err := primitive.ObjectIDFromHex(planID)
+	pillar, err := pu.pillarRepository.GetPillarByID(ctx, *plan.PillarID)
+	if errors.Is(err, domain.ErrPillarNotFound) {
+		return fmt.Errorf("%w: pillar %s not found", domain.ErrInvalidPillar, plan.PillarID.Hex())
+	}
 	if err != nil {
-		return errors.New("invalid plan ID format")
+		return err
+	}
+
+	if pillar.FiscalYear != plan.FiscalYear {
+		return fmt.Errorf("%w: the pillar belongs to fiscal year %d", domain.ErrInvalidPillar, pillar.FiscalYear)
 	}
 
-	return pu.planRepository.UpdatePlan(ctx, objectID, updatedPlan)
+	plan.AlignedPillary = pillar.Name
+	return nil
 }
 
-func (ru *planUsecaseStruct) UpdateReportStatus(c context.Context, reportID primitive.ObjectID, supervisorName, status, comment string) error {
-	ctx, cancel := context.WithTimeout(c, ru.contextTimeout)
-	defer cancel()
+// alignCalendar derives the plan's fiscal year and quarter from its start
+// date. A plan may not run past the end of its fiscal year, and one starting
+// in a closed period cannot be submitted.
+func (pu *planUsecaseStruct) alignCalendar(ctx context.Context, plan *domain.Plan, submitting bool) error {
+	if plan.StartDate.IsZero() || plan.EndDate.IsZero() {
+		return fmt.Errorf("%w: start and end dates are required", domain.ErrInvalidPeriod)
+	}
+	if plan.EndDate.Before(plan.StartDate) {
+		return fmt.Errorf("%w: the plan ends before it starts", domain.ErrInvalidPeriod)
+	}
 
-	// Validate status
-	if status != "Approved" && status != "Rejected" {
-		return fmt.Errorf("invalid status")
+	fiscalYear, err := pu.fiscalYearRepository.FindByDate(ctx, plan.StartDate)
+	if err != nil {
+		return fmt.Errorf("%w: no fiscal year covers %s", domain.ErrInvalidPeriod, plan.StartDate.Format("2006-01-02"))
+	}
+	if !plan.EndDate.Before(fiscalYear.EndDate) {
+		return fmt.Errorf("%w: the plan runs past the end of fiscal year %d", domain.ErrInvalidPeriod, fiscalYear.Year)
 	}
+	period, ok := fiscalYear.PeriodAt(plan.StartDate)
+	if !ok {
+		return fmt.Errorf("%w: no period of fiscal year %d covers %s", domain.ErrInvalidPeriod, fiscalYear.Year, plan.StartDate.Format("2006-01-02"))
+	}
+
+	plan.FiscalYear = fiscalYear.Year
+	plan.Quarter = period.Quarter
+	plan.WhichQuarter = period.Name
 
-	// Update the report in the repository
-	return ru.planRepository.UpdateReportStatus(ctx, reportID, supervisorName, status, comment)
+	if submitting && !period.Open {
+		return fmt.Errorf("%w: %s of fiscal year %d", domain.ErrClosedPeriod, period.Name, fiscalYear.Year)
+	}
+	return nil
 }
 
-func (pu *planUsecaseStruct) UpdatePlanStatus(c context.Context, planID primitive.ObjectID, supervisorName, status, comment string) error {
-	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
-	defer cancel()
+// alignReportPeriod checks the period a report covers, defaulting to the
+// current one, and returns it. Reports cannot cover periods that have not
+// started, and cannot be submitted into closed ones.
+func (ru *planUsecaseStruct) alignReportPeriod(ctx context.Context, report *domain.Report, submitting bool) (*domain.Period, error) {
+	now := time.Now()
+
+	var fiscalYear *domain.FiscalYear
+	var err error
+	if report.FiscalYear == 0 && report.Quarter == 0 {
+		fiscalYear, err = ru.fiscalYearRepository.FindByDate(ctx, now)
+		if err != nil {
+			return nil, fmt.Errorf("%w: no fiscal year covers today", domain.ErrInvalidPeriod)
+		}
+		period, ok := fiscalYear.PeriodAt(now)
+		if !ok {
+			return nil, fmt.Errorf("%w: no period of fiscal year %d covers today", domain.ErrInvalidPeriod, fiscalYear.Year)
+		}
+		report.FiscalYear = fiscalYear.Year
+		report.Quarter = period.Quarter
+	} else {
+		fiscalYear, err = ru.fiscalYearRepository.GetFiscalYear(ctx, report.FiscalYear)
+		if err != nil {
+			return nil, fmt.Errorf("%w: fiscal year %d not found", domain.ErrInvalidPeriod, report.FiscalYear)
+		}
+	}
 
-	// Validate status
-	if status != "Approved" && status != "Rejected" {
-		return fmt.Errorf("invalid status")
+	period, ok := fiscalYear.Period(report.Quarter)
+	if !ok {
+		return nil, fmt.Errorf("%w: fiscal year %d has no quarter %d", domain.ErrInvalidPeriod, report.FiscalYear, report.Quarter)
+	}
+	if period.StartDate.After(now) {
+		return nil, fmt.Errorf("%w: %s of fiscal year %d has not started", domain.ErrInvalidPeriod, period.Name, fiscalYear.Year)
+	}
+	if submitting && !period.Open {
+		return nil, fmt.Errorf("%w: %s of fiscal year %d", domain.ErrClosedPeriod, period.Name, fiscalYear.Year)
+	}
+	return period, nil
+}
+
+// alignReportPlan checks that the report is on an approved plan of the
+// submitter running in the period, and that the plan has no other report for
+// the period. reportID is the report being edited, if any.
+func (ru *planUsecaseStruct) alignReportPlan(ctx context.Context, report *domain.Report, reportID primitive.ObjectID, period *domain.Period) error {
+	if report.PlanID.IsZero() {
+		return fmt.Errorf("%w: plan_id is required", domain.ErrInvalidReportPlan)
+	}
+
+	plan, err := ru.planRepository.GetPlanByID(ctx, report.PlanID)
+	if err != nil {
+		if err.Error() == "plan not found" {
+			return fmt.Errorf("%w: plan %s not found", domain.ErrInvalidReportPlan, report.PlanID.Hex())
+		}
+		return err
+	}
+	if plan.OwnerID != report.ReportUserID {
+		return fmt.Errorf("%w: the plan belongs to someone else", domain.ErrInvalidReportPlan)
+	}
+	if plan.Status != domain.StatusApproved {
+		return fmt.Errorf("%w: the plan is %s, not Approved", domain.ErrInvalidReportPlan, plan.Status)
+	}
+	if !plan.StartDate.Before(period.EndDate) || plan.EndDate.Before(period.StartDate) {
+		return fmt.Errorf("%w: the plan does not run in %s", domain.ErrInvalidReportPlan, period.Name)
+	}
+
+	existing, err := ru.reportRepository.FindForPeriod(ctx, report.PlanID, report.FiscalYear, report.Quarter)
+	if err == nil && existing.ID != reportID {
+		return domain.ErrDuplicateReport
+	}
+	if err != nil && !errors.Is(err, domain.ErrReportNotFound) {
+		return err
+	}
+
+	if report.ReportTitle == "" {
+		report.ReportTitle = plan.Title
+	}
+	return nil
+}
+
+// findPeriod returns a period of a fiscal year, the current one when
+// fiscalYear is 0.
+func (ru *planUsecaseStruct) findPeriod(ctx context.Context, fiscalYear, quarter int) (*domain.FiscalYear, *domain.Period, error) {
+	if fiscalYear == 0 {
+		now := time.Now()
+		year, err := ru.fiscalYearRepository.FindByDate(ctx, now)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: no fiscal year covers today", domain.ErrInvalidPeriod)
+		}
+		period, ok := year.PeriodAt(now)
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: no period of fiscal year %d covers today", domain.ErrInvalidPeriod, year.Year)
+		}
+		return year, period, nil
 	}
 
-	// Update the plan in the repository
-	return pu.planRepository.UpdatePlanStatus(ctx, planID, supervisorName, status, comment)
+	year, err := ru.fiscalYearRepository.GetFiscalYear(ctx, fiscalYear)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: fiscal year %d not found", domain.ErrInvalidPeriod, fiscalYear)
+	}
+	period, ok := year.Period(quarter)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: fiscal year %d has no quarter %d", domain.ErrInvalidPeriod, fiscalYear, quarter)
+	}
+	return year, period, nil
 }
 
-func (ru *planUsecaseStruct) FetchReportsBySupervisorAndStatus(c context.Context, supervisorName, reportStatus string) ([]domain.Report, error) {
+// GetMissingReports lists the plans running in a period that have no report
+// for it. The period defaults to the current one. scope picks whose plans
+// are listed: the caller's ("mine"), those of everyone below the caller
+// ("team") or, for the planning office, everyone's ("all").
+func (ru *planUsecaseStruct) GetMissingReports(c context.Context, claims *domain.JwtCustomClaims, scope string, fiscalYear, quarter int) ([]domain.MissingReport, error) {
 	ctx, cancel := context.WithTimeout(c, ru.contextTimeout)
 	defer cancel()
 
-	return ru.planRepository.GetReportsBySupervisorAndStatus(ctx, supervisorName, reportStatus)
+	year, period, err := ru.findPeriod(ctx, fiscalYear, quarter)
+	if err != nil {
+		return nil, err
+	}
+
+	var owners []primitive.ObjectID
+	switch scope {
+	case "", "mine":
+		owners = []primitive.ObjectID{claims.UserID}
+	case "team":
+		subordinates, err := ru.userRepository.GetSubordinates(ctx, claims.UserID)
+		if err != nil {
+			return nil, err
+		}
+		owners = make([]primitive.ObjectID, len(subordinates))
+		for i, subordinate := range subordinates {
+			owners[i] = subordinate.ID
+		}
+	case "all":
+		if claims.Role != domain.RolePlanningOffice {
+			return nil, errors.New("unauthorized access")
+		}
+	default:
+		return nil, fmt.Errorf("invalid scope: %s", scope)
+	}
+
+	plans, err := ru.planRepository.FindRunningPlans(ctx, owners, period.StartDate, period.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	missing := []domain.MissingReport{}
+	if len(plans) == 0 {
+		return missing, nil
+	}
+
+	planIDs := make([]primitive.ObjectID, len(plans))
+	for i, plan := range plans {
+		planIDs[i] = plan.ID
+	}
+	reported, err := ru.reportRepository.FindReportedPlanIDs(ctx, planIDs, year.Year, period.Quarter)
+	if err != nil {
+		return nil, err
+	}
+	hasReport := make(map[primitive.ObjectID]bool, len(reported))
+	for _, id := range reported {
+		hasReport[id] = true
+	}
+
+	for _, plan := range plans {
+		if hasReport[plan.ID] {
+			continue
+		}
+		missing = append(missing, domain.MissingReport{
+			PlanID:     plan.ID,
+			Title:      plan.Title,
+			OwnerID:    plan.OwnerID,
+			OwnerName:  plan.OwnerName,
+			FiscalYear: year.Year,
+			Quarter:    period.Quarter,
+			Period:     period.Name,
+		})
+	}
+	return missing, nil
 }
 
-func (pu *planUsecaseStruct) FetchPlansBySupervisorAndStatus(c context.Context, supervisorName, status string) ([]domain.Plan, error) {
-	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+// GetUnitReport consolidates the approved reports of everyone below the
+// caller for a period, the current one when fiscalYear is 0.
+func (ru *planUsecaseStruct) GetUnitReport(c context.Context, claims *domain.JwtCustomClaims, fiscalYear, quarter int) (*domain.UnitReport, error) {
+	ctx, cancel := context.WithTimeout(c, ru.contextTimeout)
 	defer cancel()
 
-	return pu.planRepository.GetPlansBySupervisorAndStatus(ctx, supervisorName, status)
+	unit, _, err := ru.buildUnitReport(ctx, claims.UserID, fiscalYear, quarter)
+	return unit, err
 }
 
-func (pu *planUsecaseStruct) CreatePlan(c context.Context, plan *domain.Plan) (*primitive.ObjectID, error) {
-	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+// SubmitUnitReport submits the caller's unit report upward: each KPI that
+// rolls up to a plan of the caller becomes the caller's report on that plan.
+// The reported value is the point between the plan's baseline and target
+// matching the consolidated achievement.
+func (ru *planUsecaseStruct) SubmitUnitReport(c context.Context, claims *domain.JwtCustomClaims, fiscalYear, quarter int) ([]domain.Report, error) {
+	ctx, cancel := context.WithTimeout(c, ru.contextTimeout)
 	defer cancel()
 
-	plan.CreatedAt = time.Now()
-	plan.UpdatedAt = time.Now()
-	plan.Status = "Pending" // Status starts as Pending
+	unit, plans, err := ru.buildUnitReport(ctx, claims.UserID, fiscalYear, quarter)
+	if err != nil {
+		return nil, err
+	}
+	reporter, err := ru.userRepository.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var reports []domain.Report
+	for _, kpi := range unit.KPIs {
+		if kpi.PlanID == nil {
+			continue
+		}
+		plan := plans[*kpi.PlanID]
+		reports = append(reports, domain.Report{
+			ReportUserID:      claims.UserID,
+			ReportTitle:       plan.Title,
+			AccomplishedValue: plan.Quantify.Baseline + kpi.Achievement/100*(plan.Quantify.Target-plan.Quantify.Baseline),
+			ReportDetails:     kpi.Narrative,
+			Type:              "report",
+			SupervisorName:    reporter.To_whom,
+			SupervisorID:      reporter.SupervisorID,
+			PlanID:            plan.ID,
+			FiscalYear:        unit.FiscalYear,
+			Quarter:           unit.Quarter,
+			Status:            domain.StatusSubmitted,
+		})
+	}
+	if len(reports) == 0 {
+		return nil, fmt.Errorf("%w: no approved reports roll up to your plans", domain.ErrInvalidReportPlan)
+	}
+
+	// Check every report before submitting any of them.
+	for i := range reports {
+		period, err := ru.alignReportPeriod(ctx, &reports[i], true)
+		if err != nil {
+			return nil, err
+		}
+		if err := ru.alignReportPlan(ctx, &reports[i], primitive.NilObjectID, period); err != nil {
+			return nil, err
+		}
+	}
 
-	// Insert the plan into the repository
-	err := pu.planRepository.CreatePlan(ctx, plan)
+	// Submit every report or none of them.
+	err = ru.transactor.WithTransaction(ctx, func(tx context.Context) error {
+		for i := range reports {
+			report := &reports[i]
+			if err := ru.reportRepository.SubmitReport(tx, report); err != nil {
+				return err
+			}
+			if err := ru.logTransition(tx, report.ID, "report", "", report.Status, claims.UserID, "consolidated from the unit report"); err != nil {
+				return err
+			}
+			if err := ru.recordReportRevision(tx, report.ID, claims.UserID); err != nil {
+				return err
+			}
+			if err := ru.dispatchReportWebhooks(tx, report, claims.UserID, ""); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &plan.ID, nil
+	for i := range reports {
+		ru.notifyReportStatus(ctx, &reports[i], claims.UserID, "")
+	}
+	return reports, nil
 }
-func (pu *planUsecaseStruct) GetPlansByStatusAndOwner(ctx context.Context, userID primitive.ObjectID, status string) ([]domain.Plan, error) {
-	c, cancel := context.WithTimeout(ctx, pu.contextTimeout)
+
+// buildUnitReport consolidates the approved reports of everyone below the
+// user for a period. A report is left out when a plan between it and the
+// user's plan has an approved report of its own, since that report already
+// consolidates it. It also returns the plans it looked at, by ID.
+func (ru *planUsecaseStruct) buildUnitReport(ctx context.Context, userID primitive.ObjectID, fiscalYear, quarter int) (*domain.UnitReport, map[primitive.ObjectID]*domain.Plan, error) {
+	year, period, err := ru.findPeriod(ctx, fiscalYear, quarter)
+	if err != nil {
+		return nil, nil, err
+	}
+	unit := &domain.UnitReport{
+		UserID:     userID,
+		FiscalYear: year.Year,
+		Quarter:    period.Quarter,
+		Period:     period.Name,
+		KPIs:       []domain.KPIRollup{},
+	}
+	plans := map[primitive.ObjectID]*domain.Plan{}
+
+	subordinates, err := ru.userRepository.GetSubordinates(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(subordinates) == 0 {
+		return unit, plans, nil
+	}
+	userIDs := make([]primitive.ObjectID, len(subordinates))
+	for i, subordinate := range subordinates {
+		userIDs[i] = subordinate.ID
+	}
+
+	reports, err := ru.reportRepository.FindApprovedForPeriod(ctx, userIDs, year.Year, period.Quarter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Load the reported plans and their ancestors up to the user's plans.
+	var pending []primitive.ObjectID
+	for _, report := range reports {
+		pending = append(pending, report.PlanID)
+	}
+	for len(pending) > 0 {
+		found, err := ru.planRepository.FindByIDs(ctx, pending)
+		if err != nil {
+			return nil, nil, err
+		}
+		pending = nil
+		for i := range found {
+			plan := &found[i]
+			plans[plan.ID] = plan
+		}
+		for _, plan := range found {
+			if plan.OwnerID != userID && plan.SupervisorPlanID != nil && plans[*plan.SupervisorPlanID] == nil {
+				pending = append(pending, *plan.SupervisorPlanID)
+			}
+		}
+	}
+
+	reported := make(map[primitive.ObjectID]bool, len(reports))
+	for _, report := range reports {
+		reported[report.PlanID] = true
+	}
+
+	index := map[string]int{}
+	for _, report := range reports {
+		plan, ok := plans[report.PlanID]
+		if !ok {
+			continue
+		}
+
+		key := "unit:" + plan.Quantify.Unit
+		var root *domain.Plan
+		covered := false
+		// Bounded by the number of plans, in case parent links form a cycle.
+		ancestor := plan
+		for steps := 0; ancestor != nil && steps < len(plans); steps++ {
+			if ancestor.OwnerID == userID {
+				root = ancestor
+				key = root.ID.Hex()
+				break
+			}
+			// A report on a plan in between already consolidates this one.
+			if ancestor != plan && reported[ancestor.ID] {
+				covered = true
+				break
+			}
+			if ancestor.SupervisorPlanID == nil {
+				break
+			}
+			ancestor = plans[*ancestor.SupervisorPlanID]
+		}
+		if covered {
+			continue
+		}
+
+		i, ok := index[key]
+		if !ok {
+			kpi := domain.KPIRollup{Title: "Unaligned plans", Unit: plan.Quantify.Unit}
+			if root != nil {
+				id := root.ID
+				kpi.PlanID = &id
+				kpi.Title = root.Title
+				kpi.Unit = root.Quantify.Unit
+			}
+			i = len(unit.KPIs)
+			index[key] = i
+			unit.KPIs = append(unit.KPIs, kpi)
+		}
+
+		quantify := plan.Quantify
+		quantify.Actual = report.AccomplishedValue
+		quantify.UpdateAchievement()
+
+		kpi := &unit.KPIs[i]
+		if len(kpi.Sections) > 0 {
+			first := plans[kpi.Sections[0].PlanID].Quantify
+			if first.Unit != quantify.Unit || first.Direction != quantify.Direction {
+				kpi.Mixed = true
+			}
+		}
+		kpi.Target += quantify.Target
+		kpi.Baseline += quantify.Baseline
+		kpi.Accomplished += report.AccomplishedValue
+		kpi.Sections = append(kpi.Sections, domain.ReportSection{
+			ReportID:     report.ID,
+			PlanID:       plan.ID,
+			PlanTitle:    plan.Title,
+			OwnerID:      plan.OwnerID,
+			OwnerName:    plan.OwnerName,
+			Unit:         quantify.Unit,
+			Accomplished: report.AccomplishedValue,
+			Achievement:  quantify.Achievement,
+			Details:      report.ReportDetails,
+		})
+		unit.Reports++
+	}
+
+	var narratives []string
+	for i := range unit.KPIs {
+		kpi := &unit.KPIs[i]
+		if kpi.Mixed {
+			var total float64
+			for _, section := range kpi.Sections {
+				total += section.Achievement
+			}
+			kpi.Achievement = total / float64(len(kpi.Sections))
+		} else {
+			totals := domain.Quantify{
+				Target:    kpi.Target,
+				Baseline:  kpi.Baseline,
+				Actual:    kpi.Accomplished,
+				Direction: plans[kpi.Sections[0].PlanID].Quantify.Direction,
+			}
+			totals.UpdateAchievement()
+			kpi.Achievement = totals.Achievement
+		}
+
+		var parts []string
+		for _, section := range kpi.Sections {
+			if strings.TrimSpace(section.Details) == "" {
+				continue
+			}
+			parts = append(parts, fmt.Sprintf("%s (%s):\n%s", section.PlanTitle, section.OwnerName, strings.TrimSpace(section.Details)))
+		}
+		kpi.Narrative = strings.Join(parts, "\n\n")
+		if kpi.Narrative != "" {
+			narratives = append(narratives, "## "+kpi.Title+"\n\n"+kpi.Narrative)
+		}
+	}
+	unit.Narrative = strings.Join(narratives, "\n\n")
+
+	return unit, plans, nil
+}
+
+// GetParentPlanOptions lists the plans the caller can align a plan to: the
+// approved plans of the caller's supervisor.
+func (pu *planUsecaseStruct) GetParentPlanOptions(c context.Context, claims *domain.JwtCustomClaims) ([]domain.PlanRef, error) {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
 	defer cancel()
 
-	return pu.planRepository.GetPlansByStatusAndOwner(c, userID, status)
+	user, err := pu.userRepository.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.SupervisorID.IsZero() {
+		return []domain.PlanRef{}, nil
+	}
+
+	return pu.planRepository.GetAlignablePlans(ctx, user.SupervisorID)
 }
 
-func (pu *planUsecaseStruct) GetPlanTitlesByOwnerName(ctx context.Context, ownerName string) ([]string, error) {
-	c, cancel := context.WithTimeout(ctx, pu.contextTimeout)
+// GetCascade builds the cascade tree of the pillar, or of every pillar when
+// pillarID is empty. The planning office sees every plan; others see their
+// own plans and those of the people below them, leaving out others' drafts.
+// Plans whose parent is missing from the cascade are shown at the top of
+// their pillar.
+func (pu *planUsecaseStruct) GetCascade(c context.Context, claims *domain.JwtCustomClaims, pillarID string) ([]domain.PillarCascade, error) {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
 	defer cancel()
 
-	return pu.planRepository.GetPlanTitlesByOwnerName(c, ownerName)
+	var pillar *primitive.ObjectID
+	if pillarID != "" {
+		id, err := primitive.ObjectIDFromHex(pillarID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid pillar ID %q", domain.ErrInvalidPillar, pillarID)
+		}
+		pillar = &id
+	}
+
+	var owners []primitive.ObjectID
+	if claims.Role != domain.RolePlanningOffice {
+		subordinates, err := pu.userRepository.GetSubordinates(ctx, claims.UserID)
+		if err != nil {
+			return nil, err
+		}
+		owners = append(owners, claims.UserID)
+		for _, subordinate := range subordinates {
+			owners = append(owners, subordinate.ID)
+		}
+	}
+
+	found, err := pu.planRepository.FindCascade(ctx, pillar, owners)
+	if err != nil {
+		return nil, err
+	}
+
+	var plans []domain.Plan
+	inCascade := make(map[primitive.ObjectID]bool, len(found))
+	for _, plan := range found {
+		if plan.Status == domain.StatusDraft && plan.OwnerID != claims.UserID {
+			continue
+		}
+		plans = append(plans, plan)
+		inCascade[plan.ID] = true
+	}
+
+	children := make(map[primitive.ObjectID][]domain.Plan)
+	var roots []domain.Plan
+	for _, plan := range plans {
+		if plan.SupervisorPlanID != nil && inCascade[*plan.SupervisorPlanID] {
+			children[*plan.SupervisorPlanID] = append(children[*plan.SupervisorPlanID], plan)
+		} else {
+			roots = append(roots, plan)
+		}
+	}
+
+	cascades := []domain.PillarCascade{}
+	index := make(map[string]int)
+	visited := make(map[primitive.ObjectID]bool, len(plans))
+	addRoot := func(root domain.Plan) {
+		i, ok := index[root.AlignedPillary]
+		if !ok {
+			i = len(cascades)
+			index[root.AlignedPillary] = i
+			cascades = append(cascades, domain.PillarCascade{Pillar: root.AlignedPillary, Plans: []domain.CascadeNode{}})
+		}
+		cascades[i].Plans = append(cascades[i].Plans, cascadeNode(root, children, visited))
+	}
+	for _, root := range roots {
+		addRoot(root)
+	}
+	// Plans aligned to each other in a loop have no root; the loop is cut at
+	// its oldest plan.
+	for _, plan := range plans {
+		if !visited[plan.ID] {
+			addRoot(plan)
+		}
+	}
+
+	sort.SliceStable(cascades, func(i, j int) bool { return cascades[i].Pillar < cascades[j].Pillar })
+	return cascades, nil
+}
+
+// cascadeNode builds the subtree of the plan. A plan already in the tree is
+// not descended into again, so a loop of parents cannot recurse forever.
+func cascadeNode(plan domain.Plan, children map[primitive.ObjectID][]domain.Plan, visited map[primitive.ObjectID]bool) domain.CascadeNode {
+	visited[plan.ID] = true
+	node := domain.CascadeNode{
+		ID:          plan.ID,
+		Title:       plan.Title,
+		OwnerID:     plan.OwnerID,
+		OwnerName:   plan.OwnerName,
+		OwnerRole:   plan.OwnerRole,
+		Status:      plan.Status,
+		Achievement: plan.Quantify.Achievement,
+		Children:    []domain.CascadeNode{},
+	}
+	for _, child := range children[plan.ID] {
+		if visited[child.ID] {
+			continue
+		}
+		node.Children = append(node.Children, cascadeNode(child, children, visited))
+	}
+	return node
 }
 
 func (ru *planUsecaseStruct) SubmitReport(ctx context.Context, report *domain.Report) error {
 	c, cancel := context.WithTimeout(ctx, ru.contextTimeout)
 	defer cancel()
 
-	return ru.planRepository.SubmitReport(c, report)
+	report.Status = domain.StatusSubmitted
+	if err := ru.assignReportSupervisor(c, report); err != nil {
+		return err
+	}
+	period, err := ru.alignReportPeriod(c, report, true)
+	if err != nil {
+		return err
+	}
+	if err := ru.alignReportPlan(c, report, primitive.NilObjectID, period); err != nil {
+		return err
+	}
+	err = ru.transactor.WithTransaction(c, func(tx context.Context) error {
+		if err := ru.reportRepository.SubmitReport(tx, report); err != nil {
+			return err
+		}
+		if err := ru.logTransition(tx, report.ID, "report", "", report.Status, report.ReportUserID, ""); err != nil {
+			return err
+		}
+		if err := ru.recordReportRevision(tx, report.ID, report.ReportUserID); err != nil {
+			return err
+		}
+		return ru.dispatchReportWebhooks(tx, report, report.ReportUserID, "")
+	})
+	if err != nil {
+		return err
+	}
+
+	ru.notifyReportStatus(c, report, report.ReportUserID, "")
+	return nil
 }
 func (ru *planUsecaseStruct) GetFilteredReports(ctx context.Context, userID primitive.ObjectID, status string) ([]domain.Report, error) {
 	c, cancel := context.WithTimeout(ctx, ru.contextTimeout)
 	defer cancel()
 
-	return ru.planRepository.GetFilteredReports(c, userID, status)
+	return ru.reportRepository.GetFilteredReports(c, userID, status)
 }
 func (pu *planUsecaseStruct) GetAllPlansByUser(ctx context.Context, userID primitive.ObjectID) ([]domain.Plan, error) {
 	c, cancel := context.WithTimeout(ctx, pu.contextTimeout)
@@ -185,12 +1851,21 @@ func (pu *planUsecaseStruct) GetAllPlansByUser(ctx context.Context, userID primi
 	return pu.planRepository.GetAllPlansByUser(c, userID)
 }
 
-func (uc *planUsecaseStruct) CountItems(ctx context.Context, itemType string, toWhom string) (int, error) {
+func (uc *planUsecaseStruct) CountItems(ctx context.Context, itemType string, supervisorID primitive.ObjectID) (int, error) {
 	if itemType != "plan" && itemType != "report" {
 		return 0, fmt.Errorf("invalid item type")
 	}
 
-	count, err := uc.planRepository.CountItems(ctx, itemType, toWhom)
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	var count int
+	var err error
+	if itemType == "report" {
+		count, err = uc.reportRepository.CountPending(ctx, supervisorID)
+	} else {
+		count, err = uc.planRepository.CountAwaitingApproval(ctx, supervisorID)
+	}
 	if err != nil {
 		return 0, err
 	}
@@ -198,8 +1873,6 @@ func (uc *planUsecaseStruct) CountItems(ctx context.Context, itemType string, to
 	return count, nil
 }
 
-// GetUsersByToWhomWithCount fetches users whose To_whom matches the given firstName and returns their count.
-
 func (pu *planUsecaseStruct) GetPlan(ctx context.Context, ownerID primitive.ObjectID) (*domain.Plan, error) {
 	ctx, cancel := context.WithTimeout(ctx, pu.contextTimeout)
 	defer cancel()
@@ -235,12 +1908,12 @@ func (pu *planUsecaseStruct) GetPlan(ctx context.Context, ownerID primitive.Obje
 // 	return pu.planRepository.UpdatePlan(ctx, existingPlan)
 // }
 
-func (pu *planUsecaseStruct) GetSubmittedPlans(ctx context.Context, supervisor_name string) ([]domain.Plan, error) {
+func (pu *planUsecaseStruct) GetSubmittedPlans(ctx context.Context, supervisorID primitive.ObjectID) ([]domain.Plan, error) {
 	ctx, cancel := context.WithTimeout(ctx, pu.contextTimeout)
 	defer cancel()
 
-	// Fetch plans submitted to the user or one level below
-	plans, err := pu.planRepository.GetSubmittedPlans(ctx, supervisor_name)
+	// Fetch plans submitted to the user
+	plans, err := pu.planRepository.GetSubmittedPlans(ctx, supervisorID)
 	if err != nil {
 		return nil, err
 	}
@@ -248,56 +1921,29 @@ func (pu *planUsecaseStruct) GetSubmittedPlans(ctx context.Context, supervisor_n
 	return plans, nil
 }
 
-// func (pu *planUsecaseStruct) ApprovePlan(ctx context.Context, planID, ownerID primitive.ObjectID) error {
-// 	ctx, cancel := context.WithTimeout(ctx, pu.contextTimeout)
-// 	defer cancel()
-
-// 	// Fetch the plan
-// 	plan, err := pu.planRepository.GetPlanByID(ctx, planID)
-// 	if err != nil {
-// 		return err
-// 	}
-
-// 	// Check if the user is authorized to approve this plan
-// 	// if plan.OwnerID != ownerID {
-// 	// 	return errors.New("unauthorized: only the plan owner can approve it")
-// 	// }
-
-// 	// Update the status to "Approved"
-// 	plan.Status = "Approved"
-// 	plan.UpdatedAt = time.Now()
-
-// 	// If the plan has a supervisor, forward it to the supervisor
-// 	if plan.SupervisorName != "" {
-// 		err = pu.planRepository.ForwardPlanToSupervisor(ctx, plan)
-// 		if err != nil {
-// 			return err
-// 		}
-// 	} else {
-// 		// Just save the updated status if there's no supervisor
-// 		err = pu.planRepository.UpdatePlanPacth(ctx, plan)
-// 		if err != nil {
-// 			return err
-// 		}
-// 	}
-
-// 	return nil
-// }
-
 func (cu *planUsecaseStruct) AddComment(ctx context.Context, comment *domain.Comment) error {
 	ctx, cancel := context.WithTimeout(ctx, cu.contextTimeout)
 	defer cancel()
 
 	// Add the comment to the database
-	return cu.planRepository.CreateComment(ctx, comment)
+	return cu.commentRepository.CreateComment(ctx, comment)
 }
 
 func (cu *planUsecaseStruct) GetSupervisorComments(ctx context.Context, userID primitive.ObjectID) ([]domain.Comment, error) {
 	ctx, cancel := context.WithTimeout(ctx, cu.contextTimeout)
 	defer cancel()
 
-	// Fetch comments made by the supervisor on plans related to the user
-	return cu.planRepository.FetchSupervisorComments(ctx, userID)
+	// Fetch comments made on the user's plans, whatever their status
+	plans, err := cu.planRepository.FindByOwnerID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	planIDs := make([]primitive.ObjectID, len(plans))
+	for i, plan := range plans {
+		planIDs[i] = plan.ID
+	}
+
+	return cu.commentRepository.FetchSupervisorComments(ctx, planIDs)
 }
 
 func (cu *planUsecaseStruct) GetCommentsByPlanID(ctx context.Context, planID primitive.ObjectID) ([]domain.Comment, error) {
@@ -305,5 +1951,5 @@ func (cu *planUsecaseStruct) GetCommentsByPlanID(ctx context.Context, planID pri
 	defer cancel()
 
 	// Fetch comments from the repository
-	return cu.planRepository.FetchCommentsByPlanID(ctx, planID)
+	return cu.commentRepository.FetchCommentsByPlanID(ctx, planID)
 }
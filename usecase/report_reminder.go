@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"plan/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// reportDueWindow is how long before the end of a period the owners of plans
+// with no report for it are reminded.
+const reportDueWindow = 14 * 24 * time.Hour
+
+// ReportReminder notifies the owners of approved plans running in the current
+// period when the period is about to end and the plan has no report for it.
+// Each owner is reminded once per plan and period.
+type ReportReminder struct {
+	fiscalYearRepository domain.FiscalYearRepository
+	planRepository       domain.PlanRepository
+	reportRepository     domain.ReportRepository
+	notifier             domain.Notifier
+	interval             time.Duration
+}
+
+// NewReportReminder returns a reminder checking for due reports at the
+// interval.
+func NewReportReminder(fiscalYearRepository domain.FiscalYearRepository, planRepository domain.PlanRepository, reportRepository domain.ReportRepository, notifier domain.Notifier, interval time.Duration) *ReportReminder {
+	return &ReportReminder{
+		fiscalYearRepository: fiscalYearRepository,
+		planRepository:       planRepository,
+		reportRepository:     reportRepository,
+		notifier:             notifier,
+		interval:             interval,
+	}
+}
+
+// Run sends reminders until ctx is done.
+func (r *ReportReminder) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.remind(ctx, time.Now()); err != nil {
+			log.Println("failed to send report reminders:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *ReportReminder) remind(ctx context.Context, now time.Time) error {
+	year, err := r.fiscalYearRepository.FindByDate(ctx, now)
+	if err != nil {
+		return nil // No fiscal year covers today, so nothing is due
+	}
+	period, ok := year.PeriodAt(now)
+	if !ok || !period.Open || period.EndDate.Sub(now) > reportDueWindow {
+		return nil
+	}
+
+	plans, err := r.planRepository.FindRunningPlans(ctx, nil, period.StartDate, period.EndDate)
+	if err != nil || len(plans) == 0 {
+		return err
+	}
+	planIDs := make([]primitive.ObjectID, len(plans))
+	for i, plan := range plans {
+		planIDs[i] = plan.ID
+	}
+	reported, err := r.reportRepository.FindReportedPlanIDs(ctx, planIDs, year.Year, period.Quarter)
+	if err != nil {
+		return err
+	}
+	hasReport := make(map[primitive.ObjectID]bool, len(reported))
+	for _, id := range reported {
+		hasReport[id] = true
+	}
+
+	// The end date is exclusive, so the last day to report is the day before.
+	dueBy := period.EndDate.AddDate(0, 0, -1).Format("2006-01-02")
+	var notifications []domain.Notification
+	for i := range plans {
+		plan := &plans[i]
+		if hasReport[plan.ID] {
+			continue
+		}
+		notifications = append(notifications, domain.Notification{
+			RecipientID: plan.OwnerID,
+			Type:        domain.NotifyReportDue,
+			Title:       "Report due",
+			Message:     fmt.Sprintf("The %s %d report on %q is due by %s.", period.Name, year.Year, plan.Title, dueBy),
+			SubjectType: "plan",
+			SubjectID:   &plan.ID,
+			Key:         fmt.Sprintf("%s:%s:%d:%d", domain.NotifyReportDue, plan.ID.Hex(), year.Year, period.Quarter),
+		})
+	}
+	return r.notifier.Notify(ctx, notifications...)
+}
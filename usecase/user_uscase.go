@@ -3,37 +3,45 @@ package usecase
 import (
 	"fmt"
 	"log"
-	"os"
+	"plan/config"
 	"plan/domain"
-	"strconv"
 
 	// "github.com/dgrijalva/jwt-go"
-	"github.com/joho/godotenv"
 
-	// "plan/internal/tokenutil"
 	"context"
 	"errors"
+	"plan/internal/tokenutil"
 	"plan/internal/userutil"
 
-	"gopkg.in/gomail.v2"
-
 	// "net/smtp"
 	"time"
 
-	// "github.com/dgrijalva/jwt-go"
-	jwt "github.com/golang-jwt/jwt/v4"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type signupUsecase struct {
-	userRepository domain.UserRepository
-	contextTimeout time.Duration
+	userRepository       domain.UserRepository
+	tokenRepository      domain.TokenRepository
+	revocationRepository domain.RevocationRepository
+	roleRuleRepository   domain.RoleRuleRepository
+	env                  *config.Env
+	outboxRepository     domain.OutboxRepository
+	transactor           domain.Transactor
+	notifier             domain.Notifier
+	contextTimeout       time.Duration
 }
 
-func NewSignupUsecase(userRepository domain.UserRepository, timeout time.Duration) domain.SignupUsecase {
+func NewSignupUsecase(userRepository domain.UserRepository, tokenRepository domain.TokenRepository, revocationRepository domain.RevocationRepository, roleRuleRepository domain.RoleRuleRepository, env *config.Env, outboxRepository domain.OutboxRepository, transactor domain.Transactor, notifier domain.Notifier, timeout time.Duration) domain.SignupUsecase {
 	return &signupUsecase{
-		userRepository: userRepository,
-		contextTimeout: timeout,
+		userRepository:       userRepository,
+		tokenRepository:      tokenRepository,
+		revocationRepository: revocationRepository,
+		roleRuleRepository:   roleRuleRepository,
+		env:                  env,
+		outboxRepository:     outboxRepository,
+		transactor:           transactor,
+		notifier:             notifier,
+		contextTimeout:       timeout,
 	}
 }
 func (uu *signupUsecase) FetchUserByID(c context.Context, userID primitive.ObjectID) (*domain.User, error) {
@@ -57,18 +65,89 @@ func (su *signupUsecase) RegisterUser(c context.Context, user *domain.AuthSignup
 		Email:     user.Email,
 		Password:  hashedPassword,
 		Role:      user.Role,
-		To_whom:   user.To_whom,
 		Verify:    false,
 	}
-	err = su.userRepository.CreateUser(ctx, adduser)
-	return &adduser.ID, err
+
+	supervisor, err := su.resolveSupervisor(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if supervisor != nil {
+		adduser.SupervisorID = supervisor.ID
+		adduser.To_whom = supervisor.Full_Name
+	}
+
+	err = su.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := su.userRepository.CreateUser(ctx, adduser); err != nil {
+			return err
+		}
+		if supervisor != nil {
+			err := su.notifier.Notify(ctx, domain.Notification{
+				RecipientID: supervisor.ID,
+				Type:        domain.NotifyUserAwaitingVerification,
+				Title:       "User awaiting verification",
+				Message:     fmt.Sprintf("%s signed up as %s and is waiting for you to verify them.", adduser.Full_Name, adduser.Role),
+				SubjectType: "user",
+				SubjectID:   &adduser.ID,
+				ActorID:     &adduser.ID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return su.enqueueMail(ctx, domain.MailAccountPending, adduser, map[string]interface{}{"Supervisor": adduser.To_whom})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &adduser.ID, nil
+}
+
+// resolveSupervisor finds the supervisor picked at signup. Clients should send
+// supervisor_id; a bare to_whom name is only accepted while it is unambiguous.
+func (su *signupUsecase) resolveSupervisor(ctx context.Context, user *domain.AuthSignup) (*domain.User, error) {
+	if user.SupervisorID != "" {
+		supervisorID, err := primitive.ObjectIDFromHex(user.SupervisorID)
+		if err != nil {
+			return nil, errors.New("invalid supervisor ID format")
+		}
+		supervisor, err := su.userRepository.GetUserByID(ctx, supervisorID)
+		if err != nil {
+			return nil, errors.New("supervisor not found")
+		}
+		return supervisor, nil
+	}
+
+	if user.To_whom == "" {
+		// Only the top of the hierarchy has nobody to report to.
+		if rule, err := su.roleRuleRepository.GetRule(ctx, user.Role); err == nil && rule.SuperiorRole == "" {
+			return nil, nil
+		}
+		return nil, errors.New("supervisor_id is required")
+	}
+
+	candidates, err := su.userRepository.FindUsersByFullName(ctx, user.To_whom)
+	if err != nil {
+		return nil, err
+	}
+	switch len(candidates) {
+	case 0:
+		return nil, errors.New("supervisor not found")
+	case 1:
+		return &candidates[0], nil
+	default:
+		return nil, fmt.Errorf("more than one user is named %q, send supervisor_id instead", user.To_whom)
+	}
 }
 
-func (su *signupUsecase) LoginUser(ctx context.Context, auth *domain.AuthLogin) (string, error) {
+func (su *signupUsecase) LoginUser(c context.Context, auth *domain.AuthLogin) (*domain.TokenPair, error) {
+	ctx, cancel := context.WithTimeout(c, su.contextTimeout)
+	defer cancel()
+
 	// Fetch user from the repository
 	user, err := su.userRepository.GetUserByUsername(ctx, auth.Email)
 	if err != nil {
-		return "", errors.New("invalid credentials")
+		return nil, errors.New("invalid credentials")
 	}
 
 	// Verify the password
@@ -78,28 +157,161 @@ func (su *signupUsecase) LoginUser(ctx context.Context, auth *domain.AuthLogin)
 	err = userutil.ComparePassword(user.Password, auth.Password)
 	if err != nil {
 		fmt.Println("password not match")
-		return "", errors.New("invalid credentials")
+		return nil, errors.New("invalid credentials")
 	}
 
 	// Check if the user is verified
 	if !user.Verify {
-		return "", errors.New("your account is pending verification")
+		return nil, errors.New("your account is pending verification")
+	}
+
+	return su.issueTokens(ctx, user)
+}
+
+// RefreshTokens exchanges a refresh token for a new token pair. Every refresh
+// token can be used once: its record is deleted before the new pair is issued.
+// Presenting a token whose record is already gone means it was stolen or
+// replayed, so all of the user's refresh tokens are revoked.
+func (su *signupUsecase) RefreshTokens(c context.Context, refreshToken string) (*domain.TokenPair, error) {
+	ctx, cancel := context.WithTimeout(c, su.contextTimeout)
+	defer cancel()
+
+	claims, err := tokenutil.VerifyToken(refreshToken, su.env.RefreshTokenSecret)
+	if err != nil || claims.Kind != domain.RefreshTokenKind {
+		return nil, errors.New("invalid refresh token")
 	}
 
-	// Generate JWT token
-	token, err := GenerateJWTToken(user)
+	if err := su.tokenRepository.DeleteToken(ctx, claims.Id); err != nil {
+		if err := su.tokenRepository.DeleteTokensByUser(ctx, claims.UserID); err != nil {
+			log.Println("failed to revoke refresh tokens:", err)
+		}
+		return nil, errors.New("invalid refresh token")
+	}
+
+	// Reload the user so role or verification changes are picked up.
+	user, err := su.userRepository.GetUserByID(ctx, claims.UserID)
 	if err != nil {
-		return "", errors.New("failed to generate token")
+		return nil, errors.New("invalid refresh token")
+	}
+	if !user.Verify {
+		return nil, errors.New("your account is pending verification")
+	}
+
+	return su.issueTokens(ctx, user)
+}
+
+// Logout revokes the access token used for the request and drops the given
+// refresh token. With request.All set every session of the user is ended.
+func (su *signupUsecase) Logout(c context.Context, claims *domain.JwtCustomClaims, request *domain.LogoutRequest) error {
+	ctx, cancel := context.WithTimeout(c, su.contextTimeout)
+	defer cancel()
+
+	if request.All {
+		return su.RevokeUserSessions(ctx, claims.UserID)
+	}
+
+	if err := su.revocationRepository.RevokeToken(ctx, claims.Id, time.Unix(claims.ExpiresAt, 0)); err != nil {
+		return err
+	}
+
+	if request.RefreshToken != "" {
+		refreshClaims, err := tokenutil.VerifyToken(request.RefreshToken, su.env.RefreshTokenSecret)
+		if err != nil || refreshClaims.Kind != domain.RefreshTokenKind || refreshClaims.UserID != claims.UserID {
+			return errors.New("invalid refresh token")
+		}
+		// Already rotated or revoked tokens are fine to ignore here.
+		_ = su.tokenRepository.DeleteToken(ctx, refreshClaims.Id)
+	}
+
+	return nil
+}
+
+// RevokeUserSessions invalidates every access and refresh token issued to
+// the user so far.
+func (su *signupUsecase) RevokeUserSessions(c context.Context, userID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(c, su.contextTimeout)
+	defer cancel()
+
+	now := time.Now()
+	expiresAt := now.Add(time.Hour * time.Duration(su.env.AccessTokenExpiryHour))
+	if err := su.revocationRepository.RevokeUserTokens(ctx, userID, now, expiresAt); err != nil {
+		return err
+	}
+
+	return su.tokenRepository.DeleteTokensByUser(ctx, userID)
+}
+
+func (su *signupUsecase) UpdateUserRole(c context.Context, userID string, role string) error {
+	ctx, cancel := context.WithTimeout(c, su.contextTimeout)
+	defer cancel()
+
+	if _, err := su.roleRuleRepository.GetRule(ctx, role); err != nil {
+		return fmt.Errorf("invalid role: %s", role)
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return errors.New("invalid user ID format")
+	}
+
+	if err := su.userRepository.UpdateRole(ctx, objectID, role); err != nil {
+		return err
 	}
 
-	return token, nil
+	// Tokens carry the role, so sessions started with the old one must end.
+	return su.RevokeUserSessions(ctx, objectID)
 }
 
-func (uc *signupUsecase) FetchUnverifiedUsersByToWhom(c context.Context, firstName string) ([]domain.User, error) {
+// UpdateCalendarPreference stores the calendar the user reads dates in and
+// returns fresh tokens carrying it. Tokens issued before keep the old one
+// until they are refreshed.
+func (su *signupUsecase) UpdateCalendarPreference(c context.Context, userID primitive.ObjectID, calendar string) (*domain.TokenPair, error) {
+	ctx, cancel := context.WithTimeout(c, su.contextTimeout)
+	defer cancel()
+
+	if !domain.IsValidCalendar(calendar) {
+		return nil, fmt.Errorf("invalid calendar: %s", calendar)
+	}
+	if err := su.userRepository.UpdateCalendar(ctx, userID, calendar); err != nil {
+		return nil, err
+	}
+
+	user, err := su.userRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return su.issueTokens(ctx, user)
+}
+
+func (su *signupUsecase) issueTokens(ctx context.Context, user *domain.User) (*domain.TokenPair, error) {
+	accessToken, _, err := tokenutil.CreateAccessToken(user, su.env.AccessTokenSecret, su.env.AccessTokenExpiryHour)
+	if err != nil {
+		return nil, errors.New("failed to generate token")
+	}
+
+	refreshToken, refreshClaims, err := tokenutil.CreateRefreshToken(user, su.env.RefreshTokenSecret, su.env.RefreshTokenExpiryHour)
+	if err != nil {
+		return nil, errors.New("failed to generate token")
+	}
+
+	err = su.tokenRepository.SaveToken(ctx, &domain.RefreshToken{
+		TokenID:   refreshClaims.Id,
+		UserID:    user.ID,
+		ExpiresAt: time.Unix(refreshClaims.ExpiresAt, 0),
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.TokenPair{AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+func (uc *signupUsecase) FetchUnverifiedSubordinates(c context.Context, supervisorID primitive.ObjectID) ([]domain.User, error) {
 	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
 	defer cancel()
 
-	return uc.userRepository.FindUnverifiedUsersByToWhom(ctx, firstName)
+	return uc.userRepository.FindUnverifiedUsersBySupervisor(ctx, supervisorID)
 }
 
 // func (uu *signupUsecase) GetVerificationStatus(ctx context.Context, userID string) (bool, error) {
@@ -111,9 +323,9 @@ func (uc *signupUsecase) FetchUnverifiedUsersByToWhom(c context.Context, firstNa
 
 //		return user.Verify, nil
 //	}
-func (uc *signupUsecase) GetUsersByToWhomWithCount(ctx context.Context, firstName string) ([]domain.User, int, error) {
+func (uc *signupUsecase) GetSubordinatesWithCount(ctx context.Context, supervisorID primitive.ObjectID) ([]domain.User, int, error) {
 	// Delegate to repository
-	users, err := uc.userRepository.FetchByToWhom(ctx, firstName)
+	users, err := uc.userRepository.FetchBySupervisor(ctx, supervisorID)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -137,55 +349,27 @@ func (uc *signupUsecase) RejectUser(c context.Context, userID string) error {
 		return errors.New("user not found")
 	}
 
-	// Delete user
-	err = uc.userRepository.DeleteUser(ctx, objectID)
-	if err != nil {
-		return err
-	}
-
-	// Optional: Send rejection email or log the action
-	if err := SendRejectionEmail(user.Email, user.Full_Name); err != nil {
-		return errors.New("failed to send rejection email")
-	}
-
-	return nil
-}
-
-func GenerateJWTToken(user *domain.User) (string, error) {
-	claims := &domain.JwtCustomClaims{
-		Full_Name: user.Full_Name,
-		UserID:    user.ID,
-		Email:     user.Email,
-		Role:      user.Role,
-		To_whom:   user.To_whom,
-		Status:    user.Verify,
-		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: time.Now().Add(time.Hour * time.Duration(72)).Unix(),
-		},
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte("ts"))
+	return uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.userRepository.DeleteUser(ctx, objectID); err != nil {
+			return err
+		}
+		if err := uc.RevokeUserSessions(ctx, objectID); err != nil {
+			return err
+		}
+		return uc.enqueueMail(ctx, domain.MailAccountRejected, user, nil)
+	})
 }
 
 func (su *signupUsecase) GetSuperiors(c context.Context, role string) ([]domain.User, error) {
 	ctx, cancel := context.WithTimeout(c, su.contextTimeout)
 	defer cancel()
 
-	// Define the hierarchy map
-	roleHierarchy := map[string]string{
-		"vice_president": "planning_office",
-		"Staff":          "team_lead",
-		"team_lead":      "director",
-		"director":       "vice_president",
-	}
-
-	superiorRole, exists := roleHierarchy[role]
-	if !exists {
+	rule, err := su.roleRuleRepository.GetRule(ctx, role)
+	if err != nil || rule.SuperiorRole == "" {
 		return nil, fmt.Errorf("invalid role: %s", role)
 	}
 
-	return su.userRepository.FindUsersByRole(ctx, superiorRole)
+	return su.userRepository.FindUsersByRole(ctx, rule.SuperiorRole)
 }
 func (uc *signupUsecase) VerifyUser(c context.Context, userID string) error {
 	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
@@ -202,91 +386,25 @@ func (uc *signupUsecase) VerifyUser(c context.Context, userID string) error {
 		return errors.New("failed to retrieve user details")
 	}
 
-	// Update verification status
-	if err := uc.userRepository.UpdateVerifyStatus(ctx, objectID, true); err != nil {
-		return err
-	}
-
-	// Send approval email
-	fmt.Printf("Sending approval email to %s\n", user.Full_Name)
-
-	if err := SendApprovalEmail(user.Email, user.Full_Name); err != nil {
-		return err
-	}
-
-	return nil
+	return uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.userRepository.UpdateVerifyStatus(ctx, objectID, true); err != nil {
+			return err
+		}
+		return uc.enqueueMail(ctx, domain.MailAccountApproved, user, nil)
+	})
 }
 
-// SendApprovalEmail sends an email to a user notifying them of their approval.
-func SendApprovalEmail(to string, firstName string) error {
-	// SMTP server configuration
-	err := godotenv.Load()
-	if err != nil {
-		log.Fatalf("Error loading .env file")
-		return err
+// enqueueMail queues an email to the user about an event of their account.
+// Called in the transaction of the change, so the email is sent if and only
+// if the change is saved.
+func (su *signupUsecase) enqueueMail(ctx context.Context, event string, user *domain.User, data map[string]interface{}) error {
+	if data == nil {
+		data = map[string]interface{}{}
 	}
+	data["Name"] = user.Full_Name
 
-	// Get SMTP credentials from environment variables
-	smtpUsername := os.Getenv("SMTPUsername")
-	smtpPassword := os.Getenv("SMTPPassword")
-	smtpHost := os.Getenv("SMTPHost")
-	smtpPortStr := os.Getenv("SMTPPort") // Replace with your email password or app password
-	smtpPort, err := strconv.Atoi(smtpPortStr)
-	if err != nil {
-		return fmt.Errorf("invalid SMTP port: %v", err)
-	}
-
-	// Email content
-	subject := "AASTU Planning System Account Approved"
-	body := fmt.Sprintf("Hello %s,\n\nYour account has been approved. You can now log in and start using our services.\n\nThank you!", firstName)
-
-	// Create email message
-	mailer := gomail.NewMessage()
-	mailer.SetHeader("From", smtpUsername)
-	mailer.SetHeader("To", to)
-	mailer.SetHeader("Subject", subject)
-	mailer.SetBody("text/plain", body)
-
-	// Create email dialer
-	dialer := gomail.NewDialer(smtpHost, smtpPort, smtpUsername, smtpPassword)
-	// dialer.Timeout = 10 * time.Second // Increase timeout to 10 seconds
-
-	// Send the email
-	return dialer.DialAndSend(mailer)
-}
-
-func SendRejectionEmail(to string, firstName string) error {
-	// SMTP server configuration
-	err := godotenv.Load()
-	if err != nil {
-		log.Fatalf("Error loading .env file")
-		return err
-	}
-
-	// Get SMTP credentials from environment variables
-	smtpUsername := os.Getenv("SMTPUsername")
-	smtpPassword := os.Getenv("SMTPPassword")
-	smtpHost := os.Getenv("SMTPHost")
-	smtpPortStr := os.Getenv("SMTPPort")
-	smtpPort, err := strconv.Atoi(smtpPortStr)
-	if err != nil {
-		return fmt.Errorf("invalid SMTP port: %v", err)
-	}
-
-	// Email content
-	subject := "AASTU Planning System Account Rejected"
-	body := fmt.Sprintf("Hello %s,\n\nWe regret to inform you that your account has been rejected. For further details, please contact support.\n\nThank you!", firstName)
-
-	// Create email message
-	mailer := gomail.NewMessage()
-	mailer.SetHeader("From", smtpUsername)
-	mailer.SetHeader("To", to)
-	mailer.SetHeader("Subject", subject)
-	mailer.SetBody("text/plain", body)
-
-	// Create email dialer
-	dialer := gomail.NewDialer(smtpHost, smtpPort, smtpUsername, smtpPassword)
-
-	// Send the email
-	return dialer.DialAndSend(mailer)
+	return su.outboxRepository.Enqueue(ctx, &domain.OutboxMessage{
+		Kind: domain.OutboxMail,
+		Mail: &domain.Mail{Event: event, To: []string{user.Email}, Data: data},
+	})
 }
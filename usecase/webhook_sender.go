@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"plan/domain"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Headers of webhook requests. The signature is the hex HMAC-SHA256, keyed
+// with the webhook's secret, of the timestamp, a dot and the body, so
+// receivers can reject both forged and replayed requests.
+const (
+	webhookEventHeader     = "X-Webhook-Event"
+	webhookDeliveryHeader  = "X-Webhook-Delivery" // ID of the event, the same on every retry
+	webhookAttemptHeader   = "X-Webhook-Attempt"
+	webhookTimestampHeader = "X-Webhook-Timestamp" // Unix seconds
+	webhookSignatureHeader = "X-Webhook-Signature" // sha256=<hex>
+)
+
+// webhookResponseLimit is how much of a response body a delivery log keeps.
+const webhookResponseLimit = 1024
+
+type webhookDispatcher struct {
+	webhookRepository domain.WebhookRepository
+	outboxRepository  domain.OutboxRepository
+}
+
+// NewWebhookDispatcher returns a dispatcher queuing webhook deliveries in the
+// outbox, where the outbox worker sends and retries them.
+func NewWebhookDispatcher(webhookRepository domain.WebhookRepository, outboxRepository domain.OutboxRepository) domain.WebhookDispatcher {
+	return &webhookDispatcher{
+		webhookRepository: webhookRepository,
+		outboxRepository:  outboxRepository,
+	}
+}
+
+func (d *webhookDispatcher) Dispatch(ctx context.Context, eventType string, data interface{}) error {
+	webhooks, err := d.webhookRepository.FindSubscribed(ctx, eventType)
+	if err != nil || len(webhooks) == 0 {
+		return err
+	}
+
+	payload := domain.WebhookPayload{
+		ID:        primitive.NewObjectID(),
+		Type:      eventType,
+		CreatedAt: time.Now(),
+		Data:      data,
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range webhooks {
+		err := d.outboxRepository.Enqueue(ctx, &domain.OutboxMessage{
+			Kind: domain.OutboxWebhook,
+			Webhook: &domain.WebhookMessage{
+				WebhookID: webhook.ID,
+				EventID:   payload.ID,
+				EventType: eventType,
+				Body:      body,
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type webhookSender struct {
+	webhookRepository  domain.WebhookRepository
+	deliveryRepository domain.WebhookDeliveryRepository
+	client             *http.Client
+}
+
+func NewWebhookSender(webhookRepository domain.WebhookRepository, deliveryRepository domain.WebhookDeliveryRepository, client *http.Client) domain.WebhookSender {
+	return &webhookSender{
+		webhookRepository:  webhookRepository,
+		deliveryRepository: deliveryRepository,
+		client:             client,
+	}
+}
+
+func (s *webhookSender) Deliver(ctx context.Context, message *domain.WebhookMessage, attempt int) error {
+	webhook, err := s.webhookRepository.GetByID(ctx, message.WebhookID)
+	if errors.Is(err, domain.ErrWebhookNotFound) {
+		log.Printf("dropped %s event %s: webhook %s was deleted", message.EventType, message.EventID.Hex(), message.WebhookID.Hex())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !webhook.Active {
+		log.Printf("dropped %s event %s: webhook %s is inactive", message.EventType, message.EventID.Hex(), message.WebhookID.Hex())
+		return nil
+	}
+
+	_, err = s.Send(ctx, webhook, message, attempt)
+	return err
+}
+
+// Send POSTs the message to the webhook and logs the attempt. Responses
+// other than 2xx fail the delivery.
+func (s *webhookSender) Send(ctx context.Context, webhook *domain.Webhook, message *domain.WebhookMessage, attempt int) (*domain.WebhookDelivery, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(message.Body))
+	if err != nil {
+		return nil, err
+	}
+	timestamp := time.Now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(webhookEventHeader, message.EventType)
+	request.Header.Set(webhookDeliveryHeader, message.EventID.Hex())
+	request.Header.Set(webhookAttemptHeader, strconv.Itoa(attempt))
+	request.Header.Set(webhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(webhookSignatureHeader, "sha256="+signWebhook(webhook.Secret, timestamp, message.Body))
+
+	delivery := &domain.WebhookDelivery{
+		WebhookID: webhook.ID,
+		EventID:   message.EventID,
+		EventType: message.EventType,
+		Attempt:   attempt,
+		URL:       webhook.URL,
+		CreatedAt: time.Now(),
+	}
+
+	response, err := s.client.Do(request)
+	delivery.DurationMs = time.Since(delivery.CreatedAt).Milliseconds()
+	if err != nil {
+		delivery.Error = err.Error()
+	} else {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, webhookResponseLimit))
+		response.Body.Close()
+		delivery.StatusCode = response.StatusCode
+		delivery.ResponseBody = string(body)
+		delivery.Success = response.StatusCode >= 200 && response.StatusCode < 300
+		if !delivery.Success {
+			delivery.Error = fmt.Sprintf("unexpected status %d", response.StatusCode)
+		}
+	}
+
+	// A lost log entry must not cause the event to be sent again.
+	if err := s.deliveryRepository.Log(ctx, delivery); err != nil {
+		log.Printf("failed to log delivery of %s event %s: %v", message.EventType, message.EventID.Hex(), err)
+	}
+
+	if !delivery.Success {
+		return delivery, errors.New(delivery.Error)
+	}
+	return delivery, nil
+}
+
+func signWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"plan/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSignWebhook(t *testing.T) {
+	tests := []struct {
+		secret    string
+		timestamp int64
+		body      string
+		want      string
+	}{
+		{"whsec_test", 1700000000, `{"type":"plan.approved"}`, "f5646d09ef7038e0e0e8b64fb798030b9907ab3e8e4fe172fa66eef04886ea11"},
+		{"", 0, "", "b849d5a581847b281957065739df36df2463d1977ea8d6e1e4e6cf33fadc68c3"},
+	}
+	for _, tt := range tests {
+		if got := signWebhook(tt.secret, tt.timestamp, []byte(tt.body)); got != tt.want {
+			t.Errorf("signWebhook(%q, %d, %q) = %s, want %s", tt.secret, tt.timestamp, tt.body, got, tt.want)
+		}
+	}
+
+	// The timestamp is signed, so a replay with another one does not verify.
+	if signWebhook("whsec_test", 1700000000, nil) == signWebhook("whsec_test", 1700000001, nil) {
+		t.Error("signature does not cover the timestamp")
+	}
+}
+
+type fakeWebhookRepository struct {
+	domain.WebhookRepository
+	webhook *domain.Webhook
+}
+
+func (r *fakeWebhookRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*domain.Webhook, error) {
+	if r.webhook == nil || r.webhook.ID != id {
+		return nil, domain.ErrWebhookNotFound
+	}
+	return r.webhook, nil
+}
+
+type fakeDeliveryRepository struct {
+	domain.WebhookDeliveryRepository
+	deliveries []domain.WebhookDelivery
+}
+
+func (r *fakeDeliveryRepository) Log(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	r.deliveries = append(r.deliveries, *delivery)
+	return nil
+}
+
+func TestWebhookSenderSend(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		success bool
+	}{
+		{"accepted", http.StatusNoContent, true},
+		{"server error", http.StatusInternalServerError, false},
+		{"redirect", http.StatusFound, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var request *http.Request
+			var body []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				request = r
+				body, _ = io.ReadAll(r.Body)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			webhook := &domain.Webhook{ID: primitive.NewObjectID(), URL: server.URL, Secret: "whsec_test", Active: true}
+			message := &domain.WebhookMessage{
+				WebhookID: webhook.ID,
+				EventID:   primitive.NewObjectID(),
+				EventType: domain.WebhookPlanApproved,
+				Body:      []byte(`{"type":"plan.approved"}`),
+			}
+			deliveries := &fakeDeliveryRepository{}
+			sender := &webhookSender{deliveryRepository: deliveries, client: &http.Client{
+				CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+			}}
+
+			delivery, err := sender.Send(context.Background(), webhook, message, 3)
+			if (err == nil) != tt.success {
+				t.Fatalf("Send error = %v, want success %v", err, tt.success)
+			}
+			if delivery.StatusCode != tt.status || delivery.Success != tt.success || delivery.Attempt != 3 {
+				t.Errorf("delivery = %+v", delivery)
+			}
+			if len(deliveries.deliveries) != 1 {
+				t.Errorf("logged %d deliveries, want 1", len(deliveries.deliveries))
+			}
+
+			if string(body) != string(message.Body) {
+				t.Errorf("body = %s", body)
+			}
+			if got := request.Header.Get(webhookEventHeader); got != message.EventType {
+				t.Errorf("%s = %q", webhookEventHeader, got)
+			}
+			if got := request.Header.Get(webhookDeliveryHeader); got != message.EventID.Hex() {
+				t.Errorf("%s = %q", webhookDeliveryHeader, got)
+			}
+			if got := request.Header.Get(webhookAttemptHeader); got != "3" {
+				t.Errorf("%s = %q", webhookAttemptHeader, got)
+			}
+			timestamp, err := strconv.ParseInt(request.Header.Get(webhookTimestampHeader), 10, 64)
+			if err != nil {
+				t.Fatalf("%s: %v", webhookTimestampHeader, err)
+			}
+			want := "sha256=" + signWebhook(webhook.Secret, timestamp, message.Body)
+			if got := request.Header.Get(webhookSignatureHeader); got != want {
+				t.Errorf("%s = %q, want %q", webhookSignatureHeader, got, want)
+			}
+		})
+	}
+}
+
+func TestWebhookSenderDeliverDropsUnusableWebhooks(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	inactive := &domain.Webhook{ID: primitive.NewObjectID(), URL: server.URL, Active: false}
+	tests := []struct {
+		name    string
+		webhook *domain.Webhook
+		id      primitive.ObjectID
+	}{
+		{"deleted", nil, primitive.NewObjectID()},
+		{"inactive", inactive, inactive.ID},
+	}
+	for _, tt := range tests {
+		sender := &webhookSender{
+			webhookRepository:  &fakeWebhookRepository{webhook: tt.webhook},
+			deliveryRepository: &fakeDeliveryRepository{},
+			client:             server.Client(),
+		}
+		err := sender.Deliver(context.Background(), &domain.WebhookMessage{WebhookID: tt.id}, 1)
+		if err != nil {
+			t.Errorf("%s: Deliver error = %v, want the message dropped", tt.name, err)
+		}
+	}
+	if called {
+		t.Error("an unusable webhook was called")
+	}
+}
@@ -0,0 +1,252 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"plan/domain"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	defaultDeliveryLimit = 50
+	maxDeliveryLimit     = 200
+)
+
+type webhookUsecase struct {
+	webhookRepository  domain.WebhookRepository
+	deliveryRepository domain.WebhookDeliveryRepository
+	sender             domain.WebhookSender
+	contextTimeout     time.Duration
+}
+
+func NewWebhookUsecase(webhookRepository domain.WebhookRepository, deliveryRepository domain.WebhookDeliveryRepository, sender domain.WebhookSender, timeout time.Duration) domain.WebhookUsecase {
+	return &webhookUsecase{
+		webhookRepository:  webhookRepository,
+		deliveryRepository: deliveryRepository,
+		sender:             sender,
+		contextTimeout:     timeout,
+	}
+}
+
+// CreateWebhook registers a webhook, active unless the request says
+// otherwise. The response is the only one showing the secret.
+func (wu *webhookUsecase) CreateWebhook(c context.Context, claims *domain.JwtCustomClaims, request *domain.WebhookRequest) (*domain.Webhook, error) {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	now := time.Now()
+	webhook := &domain.Webhook{
+		ID:        primitive.NewObjectID(),
+		Events:    []string{},
+		Active:    true,
+		CreatedBy: claims.UserID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := applyWebhookRequest(webhook, request); err != nil {
+		return nil, err
+	}
+	if webhook.Secret == "" {
+		secret, err := newWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		webhook.Secret = secret
+	}
+
+	if err := wu.webhookRepository.Create(ctx, webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (wu *webhookUsecase) ListWebhooks(c context.Context) ([]domain.Webhook, error) {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	webhooks, err := wu.webhookRepository.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+	return webhooks, nil
+}
+
+func (wu *webhookUsecase) GetWebhook(c context.Context, id string) (*domain.Webhook, error) {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	webhook, err := wu.findWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	webhook.Secret = ""
+	return webhook, nil
+}
+
+// UpdateWebhook changes the fields set in the request. Sending a secret
+// rotates it; an empty one generates a new random secret, which the
+// response shows.
+func (wu *webhookUsecase) UpdateWebhook(c context.Context, id string, request *domain.WebhookRequest) (*domain.Webhook, error) {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	webhook, err := wu.findWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyWebhookRequest(webhook, request); err != nil {
+		return nil, err
+	}
+	rotated := request.Secret != nil
+	if rotated && webhook.Secret == "" {
+		secret, err := newWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		webhook.Secret = secret
+	}
+	webhook.UpdatedAt = time.Now()
+
+	if err := wu.webhookRepository.Update(ctx, webhook); err != nil {
+		return nil, err
+	}
+	if !rotated {
+		webhook.Secret = ""
+	}
+	return webhook, nil
+}
+
+func (wu *webhookUsecase) DeleteWebhook(c context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrWebhookNotFound
+	}
+	return wu.webhookRepository.Delete(ctx, objectID)
+}
+
+// PingWebhook sends a ping event to the webhook straight away, whether it is
+// active or not, and returns the logged attempt. A failed attempt is not an
+// error; it is reported in the delivery.
+func (wu *webhookUsecase) PingWebhook(c context.Context, id string) (*domain.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	webhook, err := wu.findWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := domain.WebhookPayload{
+		ID:        primitive.NewObjectID(),
+		Type:      domain.WebhookPing,
+		CreatedAt: time.Now(),
+		Data:      map[string]interface{}{"webhook_id": webhook.ID, "name": webhook.Name},
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := wu.sender.Send(ctx, webhook, &domain.WebhookMessage{
+		WebhookID: webhook.ID,
+		EventID:   payload.ID,
+		EventType: payload.Type,
+		Body:      body,
+	}, 1)
+	if delivery != nil {
+		return delivery, nil
+	}
+	return nil, err
+}
+
+func (wu *webhookUsecase) ListDeliveries(c context.Context, id string, limit int64) ([]domain.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	webhook, err := wu.findWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultDeliveryLimit
+	}
+	if limit > maxDeliveryLimit {
+		limit = maxDeliveryLimit
+	}
+	return wu.deliveryRepository.ListByWebhook(ctx, webhook.ID, limit)
+}
+
+func (wu *webhookUsecase) findWebhook(ctx context.Context, id string) (*domain.Webhook, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrWebhookNotFound
+	}
+	return wu.webhookRepository.GetByID(ctx, objectID)
+}
+
+// applyWebhookRequest copies the fields set in the request onto the webhook
+// and checks the result.
+func applyWebhookRequest(webhook *domain.Webhook, request *domain.WebhookRequest) error {
+	if request.Name != nil {
+		webhook.Name = strings.TrimSpace(*request.Name)
+	}
+	if request.URL != nil {
+		webhook.URL = strings.TrimSpace(*request.URL)
+	}
+	if request.Secret != nil {
+		webhook.Secret = *request.Secret
+	}
+	if request.Events != nil {
+		webhook.Events = *request.Events
+		if webhook.Events == nil {
+			webhook.Events = []string{}
+		}
+	}
+	if request.Active != nil {
+		webhook.Active = *request.Active
+	}
+
+	if webhook.Name == "" {
+		return fmt.Errorf("%w: name is required", domain.ErrInvalidWebhook)
+	}
+	target, err := url.Parse(webhook.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", domain.ErrInvalidWebhook)
+	}
+	for _, event := range webhook.Events {
+		if !isWebhookEventType(event) {
+			return fmt.Errorf("%w: unknown event type %q", domain.ErrInvalidWebhook, event)
+		}
+	}
+	return nil
+}
+
+func isWebhookEventType(eventType string) bool {
+	for _, known := range domain.WebhookEventTypes {
+		if eventType == known {
+			return true
+		}
+	}
+	return false
+}
+
+func newWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}